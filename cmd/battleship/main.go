@@ -1,37 +1,84 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"strings"
 	"syscall"
 
 	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/arena"
 	"github.com/Jagreen1970/battleship/internal/cli"
+	"github.com/Jagreen1970/battleship/internal/client"
+	"github.com/Jagreen1970/battleship/internal/engine"
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/health"
+	"github.com/Jagreen1970/battleship/internal/metrics"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+	"github.com/Jagreen1970/battleship/internal/rpc"
 	"github.com/Jagreen1970/battleship/internal/server"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
 	"github.com/Jagreen1970/battleship/internal/storage"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/tracing"
+	"github.com/Jagreen1970/battleship/internal/tui"
+	"github.com/Jagreen1970/battleship/internal/webhook"
 )
 
 func main() {
 	// Parse command line flags
 	cliMode := flag.Bool("cli", false, "Start in CLI mode")
-	dbUser := flag.String("dbuser", "", "MongoDB username")
-	dbPass := flag.String("dbpass", "", "MongoDB password") 
+	command := flag.String("c", "", "Run a single CLI command and exit, e.g. -c \"show-games\"")
+	script := flag.String("script", "", "Run the CLI commands of a script file and exit, - reads the script from stdin")
+	output := flag.String("output", "text", "Output format of the CLI: text, json or yaml")
+	serverURL := flag.String("server", "", "Run the CLI against a running server instead of the database, e.g. http://localhost:8080")
+	tuiMode := flag.Bool("tui", false, "Play a game in the full-screen terminal UI")
+	engineCommand := flag.String("engine", "", "Play a game with an engine program speaking the engine protocol, e.g. -engine \"./hunter --fast\"")
+	player := flag.String("player", "", "Player of the terminal UI or the engine")
+	gameID := flag.String("game", "", "Game ID or name the terminal UI or the engine joins or continues, a new game is created without it")
+	configFlags := app.RegisterFlags(flag.CommandLine)
 	flag.Parse()
 
-	// Load configuration
-	cfg, err := app.LoadConfig()
+	// config print shows the configuration the flags and the environment add up to
+	if flag.Arg(0) == "config" {
+		os.Exit(runConfig(flag.Args()[1:], configFlags))
+	}
+
+	// The arena is a subcommand with its own flags, it needs neither configuration nor database
+	if flag.Arg(0) == "arena" {
+		os.Exit(runArena(flag.Args()[1:]))
+	}
+
+	if *tuiMode && *player == "" {
+		log.Fatal("The terminal UI needs a player, e.g. -tui -player alice")
+	}
+	if *engineCommand != "" && *player == "" {
+		log.Fatal("The engine needs a player, e.g. -engine ./hunter -player hunter")
+	}
+
+	format, err := cli.ParseFormat(*output)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		log.Fatalf("Invalid output format: %v", err)
 	}
 
-	// Set MongoDB credentials from command line if provided
-	if *dbUser != "" {
-		cfg.Database.User = *dbUser
+	// The client mode needs neither configuration nor database
+	if *serverURL != "" {
+		os.Exit(runClientMode(*serverURL, format, *command, *script))
 	}
-	if *dbPass != "" {
-		cfg.Database.Password = *dbPass
+
+	// Load configuration
+	cfg, err := configFlags.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
 	// Validate configuration
@@ -39,8 +86,16 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
+	// Log as configured, the standard logger writes to the same output
+	loggers, err := app.NewLoggers(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create loggers: %v", err)
+	}
+	defer loggers.Close()
+	slog.SetDefault(loggers.App)
+
 	// Initialize database
-	db, err := storage.New(cfg.Database)
+	db, err := storage.New(cfg.Database, loggers.App.With("component", "storage"))
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
@@ -55,40 +110,335 @@ func main() {
 		log.Fatalf("Failed to ping database: %v", err)
 	}
 
+	exitCode := 0
 	defer func() {
 		if err := db.Disconnect(); err != nil {
 			log.Fatalf("Failed to disconnect from database: %v", err)
 		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
 	}()
 
-	if *cliMode {
-		runCLIMode(cfg, db)
+	if *tuiMode {
+		exitCode = runTUIMode(db, *player, *gameID)
+		return
+	}
+
+	if *engineCommand != "" {
+		exitCode = runEngineMode(db, loggers.App.With("component", "engine"), *engineCommand, *player, *gameID)
+		return
+	}
+
+	if *cliMode || *command != "" || *script != "" {
+		exitCode = runCLIMode(cfg, db, loggers.App.With("component", "cli"), format, *command, *script)
+		return
+	}
+
+	// Create the indexes the queries rely on, matchmaking needs the unique index of the tickets
+	if err := db.CreateIndexes(); err != nil {
+		slog.Error("Failed to create indexes, see the CLI command doctor", "error", err)
+		exitCode = 1
+		return
+	}
+
+	// REST and gRPC share the sessions, so both need the same secret
+	secret, err := server.SessionSecret(cfg.Server)
+	if err != nil {
+		slog.Error("Failed to create session secret", "error", err)
+		exitCode = 1
 		return
 	}
+	cfg.Server.SessionSecret = string(secret)
+
+	// Trace the requests with the game API calls and storage operations they make
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		slog.Error("Failed to set up tracing", "error", err)
+		exitCode = 1
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.Timeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
+	// Count the requests, games and storage operations of the server
+	m := metrics.New()
+	db = storage.Trace(storage.Instrument(db, m))
 
 	// Initialize server
+	api := game.NewApi(db)
+	api.MaxOpenGames = cfg.Server.RateLimit.MaxOpenGames
+	m.CountGames(api)
+	controller := endpoints.NewController(api, tournament.NewApi(db, api), webhook.NewApi(db, api))
+
+	// Report the server ready while the database answers and the background workers run
+	checker := health.New()
+	checker.AddCheck("storage", db.Ping)
+	controller.SetHealth(checker)
+
+	// The HTTP and the gRPC API share the rate limits
+	limits := ratelimit.NewMemoryStore()
+	router, err := server.NewRouter(cfg.Server, controller, loggers.Server, m, limits)
+	if err != nil {
+		slog.Error("Failed to create router", "error", err)
+		exitCode = 1
+		return
+	}
+
 	s := server.New(cfg.Server)
+	s.SetHandler(router)
+	s.SetLogger(loggers.Server)
+
+	// Deliver the events of the games played through the API to the webhooks
+	ctx, cancel := context.WithCancel(context.Background())
+	events, unsubscribe := api.Subscribe("")
+	defer cancel()
+	defer unsubscribe()
+	dispatcher := webhook.NewDispatcher(db)
+	dispatcher.Logger = loggers.App.With("component", "webhooks")
+	checker.Go("webhooks", func() { dispatcher.Run(ctx, events) })
+
+	// Count the moves of all games
+	moves, unsubscribeMoves := api.Subscribe("")
+	defer unsubscribeMoves()
+	checker.Go("metrics", func() { m.Watch(ctx, moves) })
 
 	// Start server
 	go func() {
-		if err := s.Start(); err != nil {
-			log.Printf("Server error: %v", err)
+		if err := s.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Server error", "error", err)
 		}
 	}()
 
+	// Start the gRPC API next to the HTTP server
+	var rpcServer *rpc.Server
+	if cfg.Server.GRPCPort != 0 {
+		rpcServer = rpc.New(cfg.Server, api, server.NewSessionCodec(secret), limits)
+		rpcServer.SetLogger(loggers.Server)
+		go func() {
+			if err := rpcServer.Start(); err != nil {
+				slog.Error("gRPC server error", "error", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
 	// Graceful shutdown
-	log.Println("Shutting down...")
+	slog.Info("Shutting down")
 	if err := s.Shutdown(); err != nil {
-		log.Printf("Error during server shutdown: %v", err)
+		slog.Error("Error during server shutdown", "error", err)
+	}
+	if rpcServer != nil {
+		rpcServer.Shutdown()
 	}
 }
 
-func runCLIMode(cfg *app.Config, db storage.Storage) {
+// Exit codes of the non-interactive CLI mode
+const (
+	exitCommandFailed = 1
+	exitScriptError   = 2
+)
+
+// runCLIMode runs the CLI on the database
+func runCLIMode(cfg *app.Config, db storage.Storage, logger *slog.Logger, format cli.Format, command string, script string) int {
 	c := cli.New(db, cfg)
-	c.Run()
+	c.SetFormat(format)
+	c.SetLogger(logger)
+	return runCLI(c, command, script)
+}
+
+// runClientMode runs the CLI against the REST API of a running server
+func runClientMode(serverURL string, format cli.Format, command string, script string) int {
+	server, err := client.New(serverURL)
+	if err != nil {
+		log.Print(err)
+		return exitScriptError
+	}
+
+	c := cli.NewRemote(server)
+	c.SetFormat(format)
+	return runCLI(c, command, script)
+}
+
+// runTUIMode plays a game in the full-screen terminal UI
+func runTUIMode(db storage.Storage, player string, gameIDOrName string) int {
+	ui := tui.New(game.NewApi(db), player)
+	if err := ui.Open(gameIDOrName); err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+
+	if err := ui.Run(); err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+	return 0
+}
+
+// runEngineMode plays a game with an engine program as the player until the game is over
+func runEngineMode(db storage.Storage, logger *slog.Logger, command string, playerName string, gameIDOrName string) int {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		log.Print("engine command is missing, e.g. -engine \"./hunter --fast\"")
+		return exitCommandFailed
+	}
+	e, err := engine.Start(logger, args[0], args[1:]...)
+	if err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+	defer e.Close()
+
+	api := game.NewApi(db)
+	if gameIDOrName == "" {
+		if _, err := api.NewPlayer(playerName); err != nil {
+			log.Printf("Error getting player: %v", err)
+			return exitCommandFailed
+		}
+		g, err := api.NewGame(playerName, "")
+		if err != nil {
+			log.Printf("Error creating game: %v", err)
+			return exitCommandFailed
+		}
+		log.Printf("Created game %s, waiting for an opponent", g.ID)
+		gameIDOrName = g.ID
+	}
+
+	player := engine.NewPlayer(api, playerName, e)
+	gameID, err := player.Join(gameIDOrName)
+	if err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Engine %s plays game %s as %s", e.Name(), gameID, playerName)
+	if err := player.Play(ctx, gameID); err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+	return 0
+}
+
+// runConfig runs the config subcommand. config print writes the effective configuration with the secrets
+// masked, in the format of a config file.
+func runConfig(args []string, flags *app.Flags) int {
+	if len(args) != 1 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "Usage: battleship [flags] config print")
+		return exitScriptError
+	}
+
+	cfg, err := flags.LoadConfig()
+	if err != nil {
+		log.Printf("Failed to load configuration: %v", err)
+		return exitCommandFailed
+	}
+	if err := app.WriteConfig(os.Stdout, cfg); err != nil {
+		log.Printf("Failed to write configuration: %v", err)
+		return exitCommandFailed
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return exitCommandFailed
+	}
+	return 0
+}
+
+// runArena plays games between two strategies or engines in memory and prints the results
+func runArena(args []string) int {
+	flags := flag.NewFlagSet("arena", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: battleship arena [flags] <strategy> <strategy>\n\n")
+		fmt.Fprintf(flags.Output(), "A strategy is one of %v or an engine command, e.g. \"./hunter --fast\".\n\n", engine.Builtins)
+		flags.PrintDefaults()
+	}
+	games := flags.Int("games", arena.DefaultGames, "Number of games to play")
+	parallel := flags.Int("parallel", runtime.GOMAXPROCS(0), "Number of games played at the same time")
+	seed := flags.Uint64("seed", 0, "Seed of the built-in strategies for reproducible results, random without it")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitScriptError
+	}
+	if *seed == 0 {
+		*seed = rand.Uint64()
+	}
+
+	var contestants [2]arena.Contestant
+	for i := range contestants {
+		c, err := arena.ParseContestant(flags.Arg(i), *seed+uint64(i))
+		if err != nil {
+			log.Print(err)
+			return exitScriptError
+		}
+		contestants[i] = c
+	}
+
+	a := arena.New(contestants[0], contestants[1])
+	a.Games = *games
+	a.Parallel = *parallel
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := a.Run(ctx)
+	if err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		log.Print(err)
+		return exitCommandFailed
+	}
+	fmt.Printf("\nSeed: %d\n", *seed)
+	return 0
+}
+
+// runCLI runs a single command, a script or the interactive CLI and returns the exit code
+func runCLI(c *cli.CLI, command string, script string) int {
+	switch {
+	case command != "":
+		if err := c.Exec(command); err != nil {
+			return exitCommandFailed
+		}
+
+	case script != "":
+		in := os.Stdin
+		if script != "-" {
+			f, err := os.Open(script)
+			if err != nil {
+				log.Printf("Failed to open script: %v", err)
+				return exitScriptError
+			}
+			defer f.Close()
+			in = f
+		}
+
+		err := c.RunScript(in, script)
+		if errors.Is(err, cli.ErrCommandFailed) {
+			log.Print(err)
+			return exitCommandFailed
+		}
+		if err != nil {
+			log.Print(err)
+			return exitScriptError
+		}
+
+	default:
+		c.Run()
+	}
+
+	return 0
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"time"
 
 	"github.com/Jagreen1970/battleship/internal/app"
@@ -24,7 +25,7 @@ func main() {
 	}
 
 	// Create and connect to the database
-	db, err := storage.New(cfg.Database)
+	db, err := storage.New(cfg.Database, slog.Default())
 	if err != nil {
 		log.Fatalf("Failed to create database connection: %v", err)
 	}
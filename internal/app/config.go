@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,7 @@ type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
 	Log      LogConfig
+	Tracing  TracingConfig
 }
 
 // DatabaseConfig holds database-specific configuration
@@ -26,18 +28,66 @@ type DatabaseConfig struct {
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
-	Port     int
+	Port int
+	// GRPCPort is the port of the gRPC API, which is disabled when it is 0
+	GRPCPort int
 	Timeout  time.Duration
 	LogLevel string
+	// SessionSecret signs the session cookies, a random secret is used when it is empty
+	SessionSecret string
+	// StrictResponses answers responses not matching the OpenAPI specification with an internal server
+	// error instead of logging them
+	StrictResponses bool
+	// TrustedProxies is a comma separated list of the IPs and CIDR ranges of the proxies whose
+	// X-Forwarded-For header gives the client IP. Without proxies the client IP is the peer address.
+	TrustedProxies string
+	RateLimit      RateLimitConfig
+}
+
+// RateLimitConfig holds the limits protecting the API from abuse. A rate of 0 disables its limit.
+type RateLimitConfig struct {
+	// IPRate is the number of API requests per second a client IP may send on average, IPBurst the number
+	// it may send at once
+	IPRate  float64
+	IPBurst int
+	// PlayerRate and PlayerBurst limit the API requests of a logged in player
+	PlayerRate  float64
+	PlayerBurst int
+	// MaxOpenGames limits the games in setup or in progress a player may have, 0 means no limit
+	MaxOpenGames int
 }
 
 // LogConfig holds logging-specific configuration
 type LogConfig struct {
-	Level  string
+	// Level is one of debug, info, warn or error
+	Level string
+	// Format is text or json
 	Format string
+	// Output is stdout, stderr or the path of a log file
 	Output string
 }
 
+// Exporters of TracingConfig, any other exporter is the path of a file the spans are appended to
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig holds the OpenTelemetry tracing configuration
+type TracingConfig struct {
+	// Exporter is none, stdout, otlp or the path of a file
+	Exporter string
+	// Endpoint is the URL of the OTLP/HTTP collector, e.g. http://localhost:4318. The OTEL_EXPORTER_OTLP_*
+	// variables apply when it is empty.
+	Endpoint string
+	// SampleRatio is the share of the traces which are recorded, from 0 to 1. Requests continuing a trace
+	// follow the decision of their caller.
+	SampleRatio float64
+	// ServiceName names the service in the traces
+	ServiceName string
+}
+
 // DefaultConfig returns a Config with default values
 func DefaultConfig() *Config {
 	return &Config{
@@ -48,71 +98,202 @@ func DefaultConfig() *Config {
 			Timeout: 5 * time.Second,
 		},
 		Server: ServerConfig{
-			Port:    8080,
-			Timeout: 5 * time.Second,
+			Port:     8080,
+			GRPCPort: 9090,
+			Timeout:  5 * time.Second,
+			RateLimit: RateLimitConfig{
+				IPRate:       20,
+				IPBurst:      40,
+				PlayerRate:   10,
+				PlayerBurst:  20,
+				MaxOpenGames: 10,
+			},
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: LogFormatText,
+			Output: LogOutputStderr,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+			ServiceName: "battleship",
+		},
+	}
+}
+
+// setting is one configuration value with its key in the config file, e.g. database.url, and its environment
+// variable. The flag is named after the variable, e.g. -db-url for DB_URL.
+type setting struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	value  func(*Config) any
+	set    func(*Config, string) error
+}
+
+// flag returns the command-line flag of the setting
+func (s setting) flag() string {
+	return strings.ToLower(strings.ReplaceAll(s.env, "_", "-"))
+}
+
+// field returns a setting of the field the function points to, parsing its values with parse
+func field[T any](key, env, usage string, ptr func(*Config) *T, parse func(string) (T, error)) setting {
+	return setting{
+		key:   key,
+		env:   env,
+		usage: usage,
+		value: func(c *Config) any { return *ptr(c) },
+		set: func(c *Config, s string) error {
+			v, err := parse(s)
+			if err != nil {
+				return err
+			}
+			*ptr(c) = v
+			return nil
 		},
 	}
 }
 
-// LoadConfig loads configuration from environment variables
+func parseString(s string) (string, error) { return s, nil }
+
+func parseFloat(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+
+// secret marks a setting whose value is masked when the configuration is printed
+func secret(s setting) setting {
+	s.secret = true
+	return s
+}
+
+// settings are all settings in the order they are printed
+var settings = []setting{
+	field("database.driver", "DB_DRIVER", "Database driver",
+		func(c *Config) *string { return &c.Database.Driver }, parseString),
+	field("database.url", "DB_URL", "Database connection URL",
+		func(c *Config) *string { return &c.Database.URL }, parseString),
+	field("database.name", "DB_NAME", "Database name",
+		func(c *Config) *string { return &c.Database.Name }, parseString),
+	field("database.timeout", "DB_TIMEOUT", "Timeout of the database operations",
+		func(c *Config) *time.Duration { return &c.Database.Timeout }, time.ParseDuration),
+	field("database.user", "DB_USER", "Database user",
+		func(c *Config) *string { return &c.Database.User }, parseString),
+	secret(field("database.password", "DB_PASSWORD", "Database password",
+		func(c *Config) *string { return &c.Database.Password }, parseString)),
+
+	field("server.port", "SERVER_PORT", "Port of the HTTP server",
+		func(c *Config) *int { return &c.Server.Port }, strconv.Atoi),
+	field("server.grpc_port", "GRPC_PORT", "Port of the gRPC API, 0 disables it",
+		func(c *Config) *int { return &c.Server.GRPCPort }, strconv.Atoi),
+	field("server.timeout", "SERVER_TIMEOUT", "Request timeout",
+		func(c *Config) *time.Duration { return &c.Server.Timeout }, time.ParseDuration),
+	field("server.log_level", "SERVER_LOG_LEVEL", "Log level of the HTTP and gRPC servers",
+		func(c *Config) *string { return &c.Server.LogLevel }, parseString),
+	secret(field("server.session_secret", "SESSION_SECRET", "Key of the session cookies",
+		func(c *Config) *string { return &c.Server.SessionSecret }, parseString)),
+	field("server.strict_responses", "STRICT_RESPONSES", "Fail responses not matching the OpenAPI specification",
+		func(c *Config) *bool { return &c.Server.StrictResponses }, strconv.ParseBool),
+
+	field("server.trusted_proxies", "TRUSTED_PROXIES", "Comma separated IPs and CIDR ranges of the proxies in front of the server",
+		func(c *Config) *string { return &c.Server.TrustedProxies }, parseString),
+
+	field("rate_limit.ip", "RATE_LIMIT_IP", "API requests per second per client IP, 0 disables the limit",
+		func(c *Config) *float64 { return &c.Server.RateLimit.IPRate }, parseFloat),
+	field("rate_limit.ip_burst", "RATE_LIMIT_IP_BURST", "API requests a client IP may send at once",
+		func(c *Config) *int { return &c.Server.RateLimit.IPBurst }, strconv.Atoi),
+	field("rate_limit.player", "RATE_LIMIT_PLAYER", "API requests per second per player, 0 disables the limit",
+		func(c *Config) *float64 { return &c.Server.RateLimit.PlayerRate }, parseFloat),
+	field("rate_limit.player_burst", "RATE_LIMIT_PLAYER_BURST", "API requests a player may send at once",
+		func(c *Config) *int { return &c.Server.RateLimit.PlayerBurst }, strconv.Atoi),
+	field("rate_limit.max_open_games", "MAX_OPEN_GAMES", "Games in setup or in progress per player, 0 means no limit",
+		func(c *Config) *int { return &c.Server.RateLimit.MaxOpenGames }, strconv.Atoi),
+
+	field("log.level", "LOG_LEVEL", "Log level: debug, info, warn or error",
+		func(c *Config) *string { return &c.Log.Level }, parseString),
+	field("log.format", "LOG_FORMAT", "Log format: text or json",
+		func(c *Config) *string { return &c.Log.Format }, parseString),
+	field("log.output", "LOG_OUTPUT", "Log output: stderr, stdout or the path of a file",
+		func(c *Config) *string { return &c.Log.Output }, parseString),
+
+	field("tracing.exporter", "TRACING_EXPORTER", "Span exporter: none, stdout, otlp or the path of a file",
+		func(c *Config) *string { return &c.Tracing.Exporter }, parseString),
+	field("tracing.endpoint", "TRACING_ENDPOINT", "URL of the OTLP/HTTP collector",
+		func(c *Config) *string { return &c.Tracing.Endpoint }, parseString),
+	field("tracing.sample_ratio", "TRACING_SAMPLE_RATIO", "Share of the traces which are recorded, from 0 to 1",
+		func(c *Config) *float64 { return &c.Tracing.SampleRatio }, parseFloat),
+	field("tracing.service_name", "TRACING_SERVICE_NAME", "Name of the service in the traces",
+		func(c *Config) *string { return &c.Tracing.ServiceName }, parseString),
+}
+
+// LoadConfig loads the configuration from the config file named by CONFIG_FILE, if any, and the environment
+// variables
 func LoadConfig() (*Config, error) {
+	return load("", nil)
+}
+
+// load layers the configuration: the defaults, then the config file, then the environment variables and
+// finally the flags, given by setting key
+func load(file string, flags map[string]string) (*Config, error) {
 	cfg := DefaultConfig()
 
-	// Database configuration
-	if driver := os.Getenv("DB_DRIVER"); driver != "" {
-		cfg.Database.Driver = driver
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
 	}
-	if url := os.Getenv("DB_URL"); url != "" {
-		cfg.Database.URL = url
-	}
-	if name := os.Getenv("DB_NAME"); name != "" {
-		cfg.Database.Name = name
-	}
-	if timeout := os.Getenv("DB_TIMEOUT"); timeout != "" {
-		duration, err := time.ParseDuration(timeout)
+	if file != "" {
+		values, err := readConfigFile(file)
 		if err != nil {
 			return nil, err
 		}
-		cfg.Database.Timeout = duration
-	}
-	if user := os.Getenv("DB_USER"); user != "" {
-		cfg.Database.User = user
-	}
-	if password := os.Getenv("DB_PASSWORD"); password != "" {
-		cfg.Database.Password = password
+		for _, s := range settings {
+			if v, ok := values[s.key]; ok {
+				if err := s.set(cfg, v); err != nil {
+					return nil, fmt.Errorf("%s in %s: %w", s.key, file, err)
+				}
+			}
+		}
 	}
 
-	// Server configuration
-	if port := os.Getenv("SERVER_PORT"); port != "" {
-		p, err := strconv.Atoi(port)
+	for _, s := range settings {
+		v, err := lookupEnv(s.env)
 		if err != nil {
 			return nil, err
 		}
-		cfg.Server.Port = p
-	}
-	if timeout := os.Getenv("SERVER_TIMEOUT"); timeout != "" {
-		duration, err := time.ParseDuration(timeout)
-		if err != nil {
-			return nil, err
+		if v == "" {
+			continue
+		}
+		if err := s.set(cfg, v); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.env, err)
 		}
-		cfg.Server.Timeout = duration
-	}
-	if logLevel := os.Getenv("SERVER_LOG_LEVEL"); logLevel != "" {
-		cfg.Server.LogLevel = logLevel
 	}
 
-	// Log configuration
-	if level := os.Getenv("LOG_LEVEL"); level != "" {
-		cfg.Log.Level = level
+	for _, s := range settings {
+		if v, ok := flags[s.key]; ok {
+			if err := s.set(cfg, v); err != nil {
+				return nil, fmt.Errorf("-%s: %w", s.flag(), err)
+			}
+		}
 	}
-	if format := os.Getenv("LOG_FORMAT"); format != "" {
-		cfg.Log.Format = format
+
+	return cfg, nil
+}
+
+// lookupEnv returns the value of an environment variable or, for secrets mounted as files, the content of
+// the file named by the variable with the suffix _FILE
+func lookupEnv(name string) (string, error) {
+	value := os.Getenv(name)
+	file := os.Getenv(name + "_FILE")
+	if file == "" {
+		return value, nil
 	}
-	if output := os.Getenv("LOG_OUTPUT"); output != "" {
-		cfg.Log.Output = output
+	if value != "" {
+		return "", fmt.Errorf("only one of %s and %s_FILE may be set", name, name)
 	}
 
-	return cfg, nil
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("%s_FILE: %w", name, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
 }
 
 // Validate checks if the configuration is valid
@@ -132,8 +313,42 @@ func (c *Config) Validate() error {
 	if c.Server.Port <= 0 {
 		return fmt.Errorf("server port must be positive")
 	}
+	if c.Server.GRPCPort < 0 {
+		return fmt.Errorf("gRPC port must not be negative")
+	}
+	if c.Server.GRPCPort == c.Server.Port {
+		return fmt.Errorf("gRPC port must differ from the server port")
+	}
 	if c.Server.Timeout <= 0 {
 		return fmt.Errorf("server timeout must be positive")
 	}
+	if err := c.Server.RateLimit.validate(); err != nil {
+		return err
+	}
+	if _, err := ParseLogLevel(c.Server.LogLevel); err != nil {
+		return fmt.Errorf("server log level: %w", err)
+	}
+	if _, err := ParseLogLevel(c.Log.Level); err != nil {
+		return err
+	}
+	if f := strings.ToLower(c.Log.Format); f != "" && f != LogFormatText && f != LogFormatJSON {
+		return fmt.Errorf("invalid log format %q, expected text or json", c.Log.Format)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+func (r RateLimitConfig) validate() error {
+	if r.IPRate < 0 || r.PlayerRate < 0 {
+		return fmt.Errorf("rate limits must not be negative")
+	}
+	if (r.IPRate > 0 && r.IPBurst < 1) || (r.PlayerRate > 0 && r.PlayerBurst < 1) {
+		return fmt.Errorf("rate limit bursts must be at least 1")
+	}
+	if r.MaxOpenGames < 0 {
+		return fmt.Errorf("max open games must not be negative")
+	}
 	return nil
 }
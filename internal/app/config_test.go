@@ -17,7 +17,12 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 5*time.Second, cfg.Database.Timeout)
 
 	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, 9090, cfg.Server.GRPCPort)
 	assert.Equal(t, 5*time.Second, cfg.Server.Timeout)
+
+	assert.Equal(t, TracingExporterNone, cfg.Tracing.Exporter)
+	assert.Equal(t, 1.0, cfg.Tracing.SampleRatio)
+	assert.NoError(t, cfg.Validate())
 }
 
 func TestLoadConfig(t *testing.T) {
@@ -27,14 +32,22 @@ func TestLoadConfig(t *testing.T) {
 	os.Setenv("DB_NAME", "testdb")
 	os.Setenv("DB_TIMEOUT", "10s")
 	os.Setenv("SERVER_PORT", "9090")
+	os.Setenv("GRPC_PORT", "9091")
 	os.Setenv("SERVER_TIMEOUT", "15s")
+	os.Setenv("STRICT_RESPONSES", "true")
+	os.Setenv("TRACING_EXPORTER", "otlp")
+	os.Setenv("TRACING_SAMPLE_RATIO", "0.25")
 	defer func() {
 		os.Unsetenv("DB_DRIVER")
 		os.Unsetenv("DB_URL")
 		os.Unsetenv("DB_NAME")
 		os.Unsetenv("DB_TIMEOUT")
 		os.Unsetenv("SERVER_PORT")
+		os.Unsetenv("GRPC_PORT")
 		os.Unsetenv("SERVER_TIMEOUT")
+		os.Unsetenv("STRICT_RESPONSES")
+		os.Unsetenv("TRACING_EXPORTER")
+		os.Unsetenv("TRACING_SAMPLE_RATIO")
 	}()
 
 	cfg, err := LoadConfig()
@@ -46,7 +59,12 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, 10*time.Second, cfg.Database.Timeout)
 
 	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.Equal(t, 9091, cfg.Server.GRPCPort)
 	assert.Equal(t, 15*time.Second, cfg.Server.Timeout)
+	assert.True(t, cfg.Server.StrictResponses)
+
+	assert.Equal(t, TracingExporterOTLP, cfg.Tracing.Exporter)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
 }
 
 func TestLoadConfigInvalidValues(t *testing.T) {
@@ -69,6 +87,13 @@ func TestLoadConfigInvalidValues(t *testing.T) {
 			},
 			expectedErr: "strconv.Atoi: parsing \"invalid\": invalid syntax",
 		},
+		{
+			name: "invalid_grpc_port",
+			envVars: map[string]string{
+				"GRPC_PORT": "invalid",
+			},
+			expectedErr: "strconv.Atoi: parsing \"invalid\": invalid syntax",
+		},
 		{
 			name: "invalid_server_timeout",
 			envVars: map[string]string{
@@ -76,6 +101,20 @@ func TestLoadConfigInvalidValues(t *testing.T) {
 			},
 			expectedErr: "time: invalid duration \"invalid\"",
 		},
+		{
+			name: "invalid_strict_responses",
+			envVars: map[string]string{
+				"STRICT_RESPONSES": "invalid",
+			},
+			expectedErr: "strconv.ParseBool: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "invalid_tracing_sample_ratio",
+			envVars: map[string]string{
+				"TRACING_SAMPLE_RATIO": "half",
+			},
+			expectedErr: "strconv.ParseFloat: parsing \"half\": invalid syntax",
+		},
 	}
 
 	for _, tt := range tests {
@@ -180,6 +219,23 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "same_grpc_and_server_port",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:     8080,
+					GRPCPort: 8080,
+					Timeout:  5 * time.Second,
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid_server_timeout",
 			config: Config{
@@ -196,6 +252,97 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "invalid_log_level",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:    8080,
+					Timeout: 5 * time.Second,
+				},
+				Log: LogConfig{
+					Level: "verbose",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid_log_format",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:    8080,
+					Timeout: 5 * time.Second,
+				},
+				Log: LogConfig{
+					Format: "xml",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid_tracing_sample_ratio",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:    8080,
+					Timeout: 5 * time.Second,
+				},
+				Tracing: TracingConfig{
+					SampleRatio: 1.5,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "rate_limit_without_burst",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:      8080,
+					Timeout:   5 * time.Second,
+					RateLimit: RateLimitConfig{IPRate: 10},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative_max_open_games",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver:  "mongo",
+					URL:     "mongodb://localhost:27017",
+					Name:    "testdb",
+					Timeout: 5 * time.Second,
+				},
+				Server: ServerConfig{
+					Port:      8080,
+					Timeout:   5 * time.Second,
+					RateLimit: RateLimitConfig{MaxOpenGames: -1},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
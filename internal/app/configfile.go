@@ -0,0 +1,139 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// maskedSecret replaces the secrets in the printed configuration
+const maskedSecret = "********"
+
+// readConfigFile reads a YAML or TOML config file, told apart by the extension, and returns its values by
+// setting key. The sections of the file are those of Config, e.g.
+//
+//	database:
+//	  url: mongodb://db:27017
+//	server:
+//	  port: 8080
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &doc)
+	case ".toml":
+		err = toml.Unmarshal(content, &doc)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten(values, "", doc); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	for key := range values {
+		if !knownSetting(key) {
+			return nil, fmt.Errorf("config file %s: unknown setting %s", path, key)
+		}
+	}
+	return values, nil
+}
+
+// flatten adds the values of a parsed file to values, joining the keys of nested sections with dots
+func flatten(values map[string]string, prefix string, doc map[string]any) error {
+	for k, v := range doc {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case nil:
+		case map[string]any:
+			if err := flatten(values, key, v); err != nil {
+				return err
+			}
+		case string:
+			values[key] = v
+		case bool, int, int64, uint64:
+			values[key] = fmt.Sprint(v)
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'g', -1, 64)
+		default:
+			return fmt.Errorf("%s must be a single value, not %T", key, v)
+		}
+	}
+	return nil
+}
+
+func knownSetting(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteConfig writes the configuration in the YAML config file format. Secrets and the passwords of URLs are
+// masked.
+func WriteConfig(w io.Writer, cfg *Config) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := make(map[string]*yaml.Node)
+	for _, s := range settings {
+		section, name, _ := strings.Cut(s.key, ".")
+		node, ok := sections[section]
+		if !ok {
+			node = &yaml.Node{Kind: yaml.MappingNode}
+			sections[section] = node
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: section}, node)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode(s, cfg))
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func valueNode(s setting, cfg *Config) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+	switch v := s.value(cfg).(type) {
+	case string:
+		node.Value = v
+		if s.secret && v != "" {
+			node.Value = maskedSecret
+		} else if u, err := url.Parse(v); err == nil && u.User != nil {
+			node.Value = u.Redacted()
+		}
+	case time.Duration:
+		node.Value = v.String()
+	case int:
+		node.Tag, node.Value = "!!int", strconv.Itoa(v)
+	case bool:
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(v)
+	case float64:
+		// plain, so whole numbers are not tagged as floats
+		node.Tag, node.Value = "", strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return node
+}
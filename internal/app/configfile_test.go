@@ -0,0 +1,150 @@
+package app
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "battleship.yaml",
+			content: `
+database:
+  url: mongodb://db:27017
+  timeout: 10s
+server:
+  port: 8000
+  strict_responses: true
+tracing:
+  sample_ratio: 0.5
+`,
+		},
+		{
+			name: "toml",
+			file: "battleship.toml",
+			content: `
+[database]
+url = "mongodb://db:27017"
+timeout = "10s"
+
+[server]
+port = 8000
+strict_responses = true
+
+[tracing]
+sample_ratio = 0.5
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", writeFile(t, tt.file, tt.content))
+
+			cfg, err := LoadConfig()
+			require.NoError(t, err)
+			assert.Equal(t, "mongodb://db:27017", cfg.Database.URL)
+			assert.Equal(t, 10*time.Second, cfg.Database.Timeout)
+			assert.Equal(t, 8000, cfg.Server.Port)
+			assert.True(t, cfg.Server.StrictResponses)
+			assert.Equal(t, 0.5, cfg.Tracing.SampleRatio)
+			assert.Equal(t, "battleship", cfg.Database.Name)
+		})
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		expectedErr string
+	}{
+		{"unknown_setting", "c.yaml", "server:\n  prot: 8000\n", "unknown setting server.prot"},
+		{"invalid_value", "c.yaml", "server:\n  port: http\n", "server.port in"},
+		{"list", "c.toml", "[server]\nport = [8000]\n", "server.port must be a single value"},
+		{"unsupported_format", "c.json", "{}", "expected .yaml, .yml or .toml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", writeFile(t, tt.file, tt.content))
+
+			_, err := LoadConfig()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	file := writeFile(t, "battleship.yaml", "server:\n  port: 8000\n  grpc_port: 8001\n  timeout: 20s\n")
+	t.Setenv("SERVER_PORT", "8100")
+	t.Setenv("GRPC_PORT", "8101")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-config", file, "-server-port", "8200", "-dbuser", "root"}))
+
+	cfg, err := flags.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 8200, cfg.Server.Port, "flags override the environment")
+	assert.Equal(t, 8101, cfg.Server.GRPCPort, "the environment overrides the file")
+	assert.Equal(t, 20*time.Second, cfg.Server.Timeout, "the file overrides the defaults")
+	assert.Equal(t, "battleship", cfg.Database.Name)
+	assert.Equal(t, "root", cfg.Database.User)
+}
+
+func TestLoadConfigSecretFiles(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "password", "battleship\n"))
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "battleship", cfg.Database.Password)
+
+	t.Setenv("DB_PASSWORD", "other")
+	_, err = LoadConfig()
+	assert.EqualError(t, err, "only one of DB_PASSWORD and DB_PASSWORD_FILE may be set")
+}
+
+func TestWriteConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Database.URL = "mongodb://root:battleship@db:27017"
+	cfg.Database.Password = "battleship"
+	cfg.Server.Port = 8000
+
+	var out bytes.Buffer
+	require.NoError(t, WriteConfig(&out, cfg))
+	assert.NotContains(t, out.String(), "battleship@")
+	assert.Contains(t, out.String(), "url: mongodb://root:xxxxx@db:27017")
+	assert.Contains(t, out.String(), "password: '********'")
+	assert.Contains(t, out.String(), "session_secret: \"\"")
+	assert.Contains(t, out.String(), "sample_ratio: 1\n")
+
+	// the output is a config file giving the same configuration, apart from the secrets
+	t.Setenv("CONFIG_FILE", writeFile(t, "printed.yaml", out.String()))
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 8000, loaded.Server.Port)
+	assert.Equal(t, cfg.Tracing, loaded.Tracing)
+	assert.Equal(t, cfg.Log, loaded.Log)
+}
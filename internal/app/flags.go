@@ -0,0 +1,39 @@
+package app
+
+import (
+	"flag"
+)
+
+// Flags are the command-line flags of the configuration, one per setting, named after its environment
+// variable, e.g. -db-url for DB_URL
+type Flags struct {
+	file   string
+	values map[string]string
+}
+
+// RegisterFlags adds the flags of all settings and -config, the config file, to the flag set
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{values: make(map[string]string)}
+	fs.StringVar(&f.file, "config", "", "YAML or TOML config file, overrides CONFIG_FILE")
+	for _, s := range settings {
+		fs.Func(s.flag(), s.usage, f.setter(s.key))
+	}
+
+	// the flags of the credentials from before the config file, kept for existing scripts
+	fs.Func("dbuser", "Same as -db-user", f.setter("database.user"))
+	fs.Func("dbpass", "Same as -db-password", f.setter("database.password"))
+	return f
+}
+
+func (f *Flags) setter(key string) func(string) error {
+	return func(v string) error {
+		f.values[key] = v
+		return nil
+	}
+}
+
+// LoadConfig loads the configuration with the precedence defaults < config file < environment < flags.
+// Call it once the flag set is parsed.
+func (f *Flags) LoadConfig() (*Config, error) {
+	return load(f.file, f.values)
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Formats and outputs of LogConfig, any other output is the path of a log file
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+)
+
+// ParseLogLevel reads one of debug, info, warn or error. An empty level is info.
+func ParseLogLevel(level string) (slog.Level, error) {
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", level)
+	}
+	return l, nil
+}
+
+// Loggers write the logs of the application to the output configured in LogConfig
+type Loggers struct {
+	// App logs everything but the servers, at the level of LogConfig
+	App *slog.Logger
+	// Server logs the HTTP and gRPC servers, at the level of ServerConfig.LogLevel if it is set
+	Server *slog.Logger
+
+	output io.Closer
+}
+
+// NewLoggers opens the log output and creates the loggers. Close them to close a log file.
+func NewLoggers(cfg *Config) (*Loggers, error) {
+	appLevel, err := ParseLogLevel(cfg.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+	serverLevel := appLevel
+	if cfg.Server.LogLevel != "" {
+		if serverLevel, err = ParseLogLevel(cfg.Server.LogLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		w      io.Writer
+		output io.Closer
+	)
+	switch cfg.Log.Output {
+	case "", LogOutputStderr:
+		w = os.Stderr
+	case LogOutputStdout:
+		w = os.Stdout
+	default:
+		f, err := os.OpenFile(cfg.Log.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("could not open log file: %w", err)
+		}
+		w, output = f, f
+	}
+
+	// the handler passes the lower of both levels, each logger filters its own
+	opts := &slog.HandlerOptions{Level: min(appLevel, serverLevel)}
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Log.Format) {
+	case "", LogFormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		if output != nil {
+			_ = output.Close()
+		}
+		return nil, fmt.Errorf("invalid log format %q, expected text or json", cfg.Log.Format)
+	}
+
+	return &Loggers{
+		App:    slog.New(&levelHandler{Handler: handler, level: appLevel}),
+		Server: slog.New(&levelHandler{Handler: handler, level: serverLevel}),
+		output: output,
+	}, nil
+}
+
+// Close closes the log file, if the logs are written to one
+func (l *Loggers) Close() error {
+	if l.output == nil {
+		return nil
+	}
+	return l.output.Close()
+}
+
+// levelHandler drops the records below its level
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.Handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a context carrying the logger, e.g. one with the ID of a request
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger of the context, or the default logger if it has none
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	for level, want := range map[string]slog.Level{
+		"":      slog.LevelInfo,
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		got, err := ParseLogLevel(level)
+		require.NoError(t, err, level)
+		assert.Equal(t, want, got, level)
+	}
+
+	_, err := ParseLogLevel("verbose")
+	assert.ErrorContains(t, err, `invalid log level "verbose"`)
+}
+
+func TestLoggers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "battleship.log")
+	cfg := DefaultConfig()
+	cfg.Log = LogConfig{Level: "warn", Format: LogFormatJSON, Output: file}
+	cfg.Server.LogLevel = "debug"
+
+	loggers, err := NewLoggers(cfg)
+	require.NoError(t, err)
+
+	loggers.App.Info("dropped")
+	loggers.App.Warn("app", "player", "alice")
+	loggers.Server.Debug("server")
+	require.NoError(t, loggers.Close())
+
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 2)
+	assert.Equal(t, "app", records[0]["msg"])
+	assert.Equal(t, "alice", records[0]["player"])
+	assert.Equal(t, "server", records[1]["msg"])
+	assert.Equal(t, "DEBUG", records[1]["level"])
+}
+
+func TestNewLoggersInvalidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Log.Format = "xml"
+	_, err := NewLoggers(cfg)
+	assert.ErrorContains(t, err, "invalid log format")
+
+	cfg = DefaultConfig()
+	cfg.Server.LogLevel = "loud"
+	_, err = NewLoggers(cfg)
+	assert.ErrorContains(t, err, "invalid log level")
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), LoggerFromContext(context.Background()))
+
+	logger := slog.Default().With("request_id", "1")
+	assert.Same(t, logger, LoggerFromContext(ContextWithLogger(context.Background(), logger)))
+}
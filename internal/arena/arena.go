@@ -0,0 +1,371 @@
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/engine"
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// DefaultGames is the number of games an arena plays
+const DefaultGames = 100
+
+// Contestant is one side of an arena. New is called once for each worker, so every worker plays its games
+// with its own strategy, and an engine runs as one process per worker.
+type Contestant struct {
+	Name string
+	New  func(worker int) (engine.Strategy, error)
+}
+
+// ParseContestant returns the contestant for a built-in strategy like "hunter", or for an engine command
+// like "./mybot --fast". The seed makes the games of the built-in strategies reproducible.
+func ParseContestant(spec string, seed uint64) (Contestant, error) {
+	if slices.Contains(engine.Builtins, spec) {
+		return Contestant{
+			Name: spec,
+			New: func(worker int) (engine.Strategy, error) {
+				return engine.NewBuiltin(spec, seed, uint64(worker))
+			},
+		}, nil
+	}
+
+	command := strings.Fields(spec)
+	if len(command) == 0 {
+		return Contestant{}, fmt.Errorf("strategy is missing, use one of %v or an engine command: %w", engine.Builtins, game.ErrorInvalidInput)
+	}
+	return Contestant{
+		Name: spec,
+		New: func(int) (engine.Strategy, error) {
+			return engine.Start(nil, command[0], command[1:]...)
+		},
+	}, nil
+}
+
+// Arena plays games between two contestants in memory, without any storage
+type Arena struct {
+	contestants [2]Contestant
+
+	// Games is the number of games to play. The contestants take turns moving first.
+	Games int
+	// Parallel is the number of games played at the same time
+	Parallel int
+	// MaxAttempts is the number of answers a strategy may give for a placement or a shot before it forfeits
+	MaxAttempts int
+}
+
+func New(a, b Contestant) *Arena {
+	return &Arena{
+		contestants: [2]Contestant{a, b},
+		Games:       DefaultGames,
+		Parallel:    runtime.GOMAXPROCS(0),
+		MaxAttempts: engine.DefaultMaxAttempts,
+	}
+}
+
+// result is the outcome of one game
+type result struct {
+	winner  int
+	shots   int
+	forfeit bool
+}
+
+// Run plays the games and reports the results. A forfeit counts as a loss; any other error of a strategy,
+// like an engine that stopped, ends the arena.
+func (a *Arena) Run(ctx context.Context) (*Report, error) {
+	if a.Games < 1 || a.Parallel < 1 {
+		return nil, fmt.Errorf("games and parallel must be at least 1: %w", game.ErrorInvalidInput)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	started := time.Now()
+	games := make(chan int)
+	results := make(chan result)
+	timings := make([][2]timing, min(a.Parallel, a.Games))
+
+	var wg sync.WaitGroup
+	for worker := range timings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.work(ctx, worker, games, results, &timings[worker]); err != nil {
+				cancel(err)
+			}
+		}()
+	}
+	go func() {
+		defer close(games)
+		for i := range a.Games {
+			select {
+			case games <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := &Report{Parallel: len(timings)}
+	var shots [2][]int
+	for r := range results {
+		report.Games++
+		c := &report.Contestants[r.winner]
+		c.Wins++
+		shots[r.winner] = append(shots[r.winner], r.shots)
+		if r.forfeit {
+			report.Contestants[1-r.winner].Forfeits++
+		}
+	}
+	report.Duration = time.Since(started)
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+
+	for i := range report.Contestants {
+		c := &report.Contestants[i]
+		c.Name = a.contestants[i].Name
+		c.WinRate, c.WinRateLow, c.WinRateHigh = wilson(c.Wins, report.Games)
+		c.ShotsToWin, c.ShotsToWinMargin = meanAndMargin(shots[i])
+		for _, t := range timings {
+			c.Decisions += t[i].decisions
+			c.DecisionTime += t[i].total
+			c.MaxDecision = max(c.MaxDecision, t[i].max)
+		}
+	}
+	return report, nil
+}
+
+// work plays games with the worker's own strategies until there are no games left
+func (a *Arena) work(ctx context.Context, worker int, games <-chan int, results chan<- result, timings *[2]timing) error {
+	var strategies [2]engine.Strategy
+	for i, c := range a.contestants {
+		s, err := c.New(worker)
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+		strategies[i] = &timedStrategy{Strategy: s, timing: &timings[i]}
+	}
+
+	for i := range games {
+		r, err := a.play(i, strategies)
+		if err != nil {
+			return err
+		}
+		select {
+		case results <- r:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// play plays one game. Contestant i%2 moves first.
+func (a *Arena) play(i int, strategies [2]engine.Strategy) (result, error) {
+	// both sides may play the same strategy, so the players are named by their side
+	names := [2]string{"A", "B"}
+	g := game.NewGame(&game.Player{Name: names[0]})
+	g.ID = fmt.Sprintf("arena-%d", i+1)
+	if err := g.Join(&game.Player{Name: names[1]}); err != nil {
+		return result{}, err
+	}
+
+	for side, s := range strategies {
+		if err := s.NewGame(g.ID, names[1-side]); err != nil {
+			return result{}, err
+		}
+	}
+	for side, s := range strategies {
+		if err := engine.PlaceFleet(g, names[side], s, a.MaxAttempts); err != nil {
+			return a.forfeit(side, strategies, err)
+		}
+	}
+	if err := g.Start(names[i%2]); err != nil {
+		return result{}, err
+	}
+
+	for !g.Finished() {
+		side := slices.Index(names[:], g.PlayerToMove)
+		if err := engine.Fire(g, names[side], strategies[side], a.MaxAttempts); err != nil {
+			return a.forfeit(side, strategies, err)
+		}
+
+		move := g.History[len(g.History)-1]
+		if err := strategies[1-side].OpponentShot(move.X, move.Y, engine.Outcome{Hit: move.Hit}); err != nil {
+			return result{}, err
+		}
+	}
+
+	winner := slices.Index(names[:], g.Winner())
+	shots := 0
+	for _, move := range g.History {
+		if move.Player == names[winner] {
+			shots++
+		}
+	}
+	return result{winner: winner, shots: shots}, gameOver(strategies, winner)
+}
+
+// forfeit ends the game for the side that forfeited, or returns the error if it wasn't a forfeit
+func (a *Arena) forfeit(side int, strategies [2]engine.Strategy, err error) (result, error) {
+	if !errors.Is(err, engine.ErrorForfeit) {
+		return result{}, err
+	}
+	return result{winner: 1 - side, forfeit: true}, gameOver(strategies, 1-side)
+}
+
+func gameOver(strategies [2]engine.Strategy, winner int) error {
+	for side, s := range strategies {
+		if err := s.GameOver(side == winner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timing sums up the time a strategy took for its fleets and shots
+type timing struct {
+	decisions int
+	total     time.Duration
+	max       time.Duration
+}
+
+func (t *timing) add(d time.Duration) {
+	t.decisions++
+	t.total += d
+	t.max = max(t.max, d)
+}
+
+// timedStrategy measures the time the strategy takes to decide on fleets and shots. It is used by a single
+// worker, so the timing doesn't need a lock.
+type timedStrategy struct {
+	engine.Strategy
+	timing *timing
+}
+
+func (s *timedStrategy) Place() ([]engine.Placement, error) {
+	started := time.Now()
+	fleet, err := s.Strategy.Place()
+	s.timing.add(time.Since(started))
+	return fleet, err
+}
+
+func (s *timedStrategy) Fire() (int, int, error) {
+	started := time.Now()
+	x, y, err := s.Strategy.Fire()
+	s.timing.add(time.Since(started))
+	return x, y, err
+}
+
+// Report is the result of an arena
+type Report struct {
+	Games       int
+	Parallel    int
+	Duration    time.Duration
+	Contestants [2]Standing
+}
+
+// Standing is the result of one contestant. The intervals are 95% confidence intervals.
+type Standing struct {
+	Name     string
+	Wins     int
+	Forfeits int
+
+	WinRate     float64
+	WinRateLow  float64
+	WinRateHigh float64
+
+	// ShotsToWin is the average number of shots in the games the contestant won, give or take ShotsToWinMargin
+	ShotsToWin       float64
+	ShotsToWinMargin float64
+
+	// Decisions counts the fleets and shots the strategy was asked for
+	Decisions    int
+	DecisionTime time.Duration
+	MaxDecision  time.Duration
+}
+
+// AvgDecision is the average time the strategy took for a fleet or a shot
+func (s Standing) AvgDecision() time.Duration {
+	if s.Decisions == 0 {
+		return 0
+	}
+	return s.DecisionTime / time.Duration(s.Decisions)
+}
+
+// Write prints the report as a table
+func (r *Report) Write(w io.Writer) error {
+	a, b := r.Contestants[0], r.Contestants[1]
+	if _, err := fmt.Fprintf(w, "%s vs %s: %d games in %s (%d in parallel)\n\n", a.Name, b.Name, r.Games, r.Duration.Round(time.Millisecond), r.Parallel); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STRATEGY\tWINS\tWIN RATE\t95% CI\tSHOTS TO WIN\tFORFEITS\tAVG DECISION\tMAX DECISION")
+	for _, s := range r.Contestants {
+		shots := "-"
+		if s.Wins > 0 {
+			shots = fmt.Sprintf("%.1f ± %.1f", s.ShotsToWin, s.ShotsToWinMargin)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%% - %.1f%%\t%s\t%d\t%s\t%s\n",
+			s.Name, s.Wins, 100*s.WinRate, 100*s.WinRateLow, 100*s.WinRateHigh, shots, s.Forfeits,
+			s.AvgDecision(), s.MaxDecision)
+	}
+	return tw.Flush()
+}
+
+// z is the quantile of the standard normal distribution for 95% confidence intervals
+const z = 1.96
+
+// wilson returns the rate of wins in games and its Wilson score interval, which unlike the normal
+// approximation stays within 0 and 1 for rates close to them
+func wilson(wins int, games int) (rate float64, low float64, high float64) {
+	if games == 0 {
+		return 0, 0, 0
+	}
+
+	n := float64(games)
+	rate = float64(wins) / n
+	center := (rate + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(rate*(1-rate)/n+z*z/(4*n*n))
+	return rate, max(0, center-margin), min(1, center+margin)
+}
+
+// meanAndMargin returns the mean of the samples and the margin of its confidence interval
+func meanAndMargin(samples []int) (mean float64, margin float64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	n := float64(len(samples))
+	for _, s := range samples {
+		mean += float64(s)
+	}
+	mean /= n
+	if len(samples) < 2 {
+		return mean, 0
+	}
+
+	var variance float64
+	for _, s := range samples {
+		variance += (float64(s) - mean) * (float64(s) - mean)
+	}
+	variance /= n - 1
+	return mean, z * math.Sqrt(variance/n)
+}
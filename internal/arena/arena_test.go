@@ -0,0 +1,125 @@
+package arena
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/engine"
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+func contestant(t *testing.T, spec string, seed uint64) Contestant {
+	c, err := ParseContestant(spec, seed)
+	require.NoError(t, err)
+	return c
+}
+
+func TestHunterBeatsRandom(t *testing.T) {
+	a := New(contestant(t, "hunter", 1), contestant(t, "random", 2))
+	a.Games = 200
+	a.Parallel = 4
+
+	report, err := a.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 200, report.Games)
+	assert.Equal(t, 4, report.Parallel)
+
+	hunter, random := report.Contestants[0], report.Contestants[1]
+	assert.Equal(t, "hunter", hunter.Name)
+	assert.Equal(t, 200, hunter.Wins+random.Wins)
+	assert.Greater(t, hunter.WinRate, 0.9)
+	assert.Less(t, hunter.WinRateLow, hunter.WinRate)
+	assert.GreaterOrEqual(t, hunter.WinRateHigh, hunter.WinRate)
+	assert.Less(t, hunter.ShotsToWin, 70.0)
+	assert.Positive(t, hunter.ShotsToWinMargin)
+	assert.Zero(t, hunter.Forfeits+random.Forfeits)
+
+	// 200 fleets plus at least 30 shots in each game won, as the fleet covers 30 fields
+	assert.GreaterOrEqual(t, hunter.Decisions, 200+30*hunter.Wins)
+	assert.Positive(t, hunter.AvgDecision())
+	assert.GreaterOrEqual(t, hunter.MaxDecision, hunter.AvgDecision())
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "hunter vs random: 200 games in")
+	assert.Contains(t, out.String(), "WIN RATE")
+}
+
+func TestSameStrategyOnBothSides(t *testing.T) {
+	a := New(contestant(t, "hunter", 1), contestant(t, "hunter", 2))
+	a.Games = 50
+
+	report, err := a.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 50, report.Contestants[0].Wins+report.Contestants[1].Wins)
+}
+
+// cheater fires at the same field over and over
+type cheater struct {
+	engine.Strategy
+}
+
+func (c *cheater) Fire() (int, int, error) { return 0, 0, nil }
+
+func TestForfeitsCountAsLosses(t *testing.T) {
+	cheat := Contestant{
+		Name: "cheater",
+		New: func(worker int) (engine.Strategy, error) {
+			s, err := engine.NewBuiltin("random", 1, uint64(worker))
+			return &cheater{s}, err
+		},
+	}
+	a := New(cheat, contestant(t, "random", 2))
+	a.Games = 10
+
+	report, err := a.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10, report.Contestants[1].Wins)
+	assert.Equal(t, 10, report.Contestants[0].Forfeits)
+}
+
+func TestStrategyErrorsEndTheArena(t *testing.T) {
+	broken := Contestant{
+		Name: "broken",
+		New: func(int) (engine.Strategy, error) {
+			return nil, errors.New("no such engine")
+		},
+	}
+	_, err := New(broken, contestant(t, "random", 2)).Run(context.Background())
+	assert.EqualError(t, err, "no such engine")
+
+	_, err = ParseContestant(" ", 1)
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+
+	a := New(contestant(t, "random", 1), contestant(t, "random", 2))
+	a.Games = 0
+	_, err = a.Run(context.Background())
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+}
+
+func TestWilson(t *testing.T) {
+	rate, low, high := wilson(50, 100)
+	assert.InDelta(t, 0.5, rate, 1e-9)
+	assert.InDelta(t, 0.404, low, 1e-3)
+	assert.InDelta(t, 0.596, high, 1e-3)
+
+	rate, low, high = wilson(10, 10)
+	assert.InDelta(t, 1, rate, 1e-9)
+	assert.InDelta(t, 0.722, low, 1e-3)
+	assert.InDelta(t, 1, high, 1e-9)
+}
+
+func TestMeanAndMargin(t *testing.T) {
+	mean, margin := meanAndMargin([]int{40, 50, 60})
+	assert.InDelta(t, 50, mean, 1e-9)
+	assert.InDelta(t, 1.96*10/1.7320508, margin, 1e-6)
+
+	mean, margin = meanAndMargin(nil)
+	assert.Zero(t, mean)
+	assert.Zero(t, margin)
+}
@@ -5,13 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/client"
 	"github.com/Jagreen1970/battleship/internal/game"
 	"github.com/Jagreen1970/battleship/internal/storage"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
 )
 
 type CLI struct {
@@ -19,19 +23,41 @@ type CLI struct {
 	config        *app.Config
 	reader        *bufio.Reader
 	api           *game.API
+	tournaments   *tournament.API
+	webhooks      *webhook.API
+	remote        *client.Client
 	currentGameID string
 	input         io.Reader
 	output        io.Writer
+	logger        *slog.Logger
+
+	// failed is set when the last command reported an error
+	failed bool
+	// line counts the lines read from the input
+	line int
+	// player is the player of the last command when connected to a server
+	player string
+
+	// format selects text, json or yaml output
+	format Format
+	// result and errors collect the outcome of the current command for the structured formats
+	result any
+	errors []string
 }
 
 func New(db storage.Storage, cfg *app.Config) *CLI {
+	api := game.NewApi(db)
 	return &CLI{
-		db:     db,
-		config: cfg,
-		reader: bufio.NewReader(os.Stdin),
-		api:    game.NewApi(db),
-		input:  os.Stdin,
-		output: os.Stdout,
+		db:          db,
+		config:      cfg,
+		reader:      bufio.NewReader(os.Stdin),
+		api:         api,
+		tournaments: tournament.NewApi(db, api),
+		webhooks:    webhook.NewApi(db, api),
+		input:       os.Stdin,
+		output:      os.Stdout,
+		logger:      slog.Default(),
+		format:      FormatText,
 	}
 }
 
@@ -44,48 +70,146 @@ func (c *CLI) SetIO(input io.Reader, output io.Writer) {
 	c.output = output
 }
 
+// SetLogger sets the logger of the commands, by default slog.Default() is used
+func (c *CLI) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
 func (c *CLI) Run() {
+	switch {
+	case c.structured():
+	case c.remote != nil:
+		c.printRemoteHelp()
+	default:
+		c.printHelp()
+	}
+
+	stopWebhooks := c.startWebhooks()
+	defer stopWebhooks()
+
+	for {
+		if !c.structured() {
+			fmt.Fprint(c.output, "> ")
+		}
+		input, err := c.readLine()
+		if err != nil || input == "exit" {
+			break
+		}
+
+		c.handleCommand(input)
+	}
+}
+
+func (c *CLI) printHelp() {
 	fmt.Fprintln(c.output, "Battleship CLI Mode")
 	fmt.Fprintln(c.output, "Available commands:")
 	fmt.Fprintln(c.output, "  create-game <player> [name]: Create a new game with optional friendly name")
 	fmt.Fprintln(c.output, "  show-games [page] [count]: List all active games (paginated)")
 	fmt.Fprintln(c.output, "  show-game <game-id|name>: Show status and boards of a specific game")
+	fmt.Fprintln(c.output, "  create-private-game <player> [name]: Create a game that can only be joined with its invite code")
 	fmt.Fprintln(c.output, "  join-game <game-id|name> <player>: Join an existing game as a player")
+	fmt.Fprintln(c.output, "  join-code <invite-code> <player>: Join a game with its invite code")
 	fmt.Fprintln(c.output, "  set-game <game-id|name>: Set the game ID for the current session")
-	fmt.Fprintln(c.output, "  place-ship <player> <ship-type> <x> <y> <orientation>: Place a ship")
+	fmt.Fprintln(c.output, "  place-ship <player> <ship-type> <coordinate> <orientation>: Place a ship with its top left end at a coordinate like B7")
 	fmt.Fprintln(c.output, "  start-game <player>: Start the game with the given player")
-	fmt.Fprintln(c.output, "  fire <player> <x> <y>: Fire at coordinates")
+	fmt.Fprintln(c.output, "  fire <player> <coordinate>: Fire at a coordinate like B7")
+	fmt.Fprintln(c.output, "  export-game [game-id|name] [file]: Export a game to a file, or print it without a file")
+	fmt.Fprintln(c.output, "  import-game <file>: Import an exported game as a new game and set it as the current game")
+	fmt.Fprintln(c.output, "  hot-seat <player1> <player2> [name] | hot-seat [game-id|name]: Play at one terminal, each player only sees their own fleet")
+	fmt.Fprintln(c.output, "  say <player> <message>: Send a chat message to the opponent in the current game")
+	fmt.Fprintln(c.output, "  chat-log [page] [count]: Show the chat of the current game (page 0 holds the latest messages)")
+	fmt.Fprintln(c.output, "  rematch <player>: Ask the opponent of the finished current game for a rematch")
+	fmt.Fprintln(c.output, "  accept-rematch <player>: Accept the rematch request and switch to the new game")
+	fmt.Fprintln(c.output, "  create-tournament <organizer> <name> <round-robin|single-elimination>: Open a tournament for registration")
+	fmt.Fprintln(c.output, "  show-tournaments [page] [count]: List tournaments (paginated)")
+	fmt.Fprintln(c.output, "  show-tournament <tournament-id>: Show rounds and standings of a tournament")
+	fmt.Fprintln(c.output, "  join-tournament <tournament-id> <player>: Register a player for a tournament")
+	fmt.Fprintln(c.output, "  start-tournament <tournament-id> <organizer>: Close registration and pair the first round")
+	fmt.Fprintln(c.output, "  advance-tournament <tournament-id> <player>: Collect results and pair the next round")
 	fmt.Fprintln(c.output, "  delete-game <game-id|name|all>: Delete a specific game or all games")
+	fmt.Fprintln(c.output, "  cancel-game <player> [game-id|name]: Cancel a game of the player nobody has joined yet, by default the current game")
+	fmt.Fprintln(c.output, "  find-match <player> [preset] [min-rating] [max-rating]: Queue for a game against a matching opponent")
+	fmt.Fprintln(c.output, "  match-status <player>: Show the matchmaking status of a player")
+	fmt.Fprintln(c.output, "  cancel-match <player>: Leave the matchmaking queue")
+	fmt.Fprintln(c.output, "  add-webhook <player> <url> [event...]: Post game events to a URL (events: game.created, game.updated, chat.message)")
+	fmt.Fprintln(c.output, "  show-webhooks <player>: List the webhooks of a player")
+	fmt.Fprintln(c.output, "  remove-webhook <webhook-id> <player>: Remove a webhook")
+	fmt.Fprintln(c.output, "  webhook-deliveries <webhook-id> <player> [page] [count]: Show the delivery log of a webhook")
+	fmt.Fprintln(c.output, "  doctor [create-indexes]: Check the configuration, the database and its indexes, optionally creating missing indexes")
+	fmt.Fprintln(c.output, "  output <text|json|yaml>: Select the output format of the following commands")
 	fmt.Fprintln(c.output, "  exit: Exit CLI mode")
 	fmt.Fprintln(c.output, "\nShip types: Battleship, Cruiser, Destroyer, Submarine")
 	fmt.Fprintln(c.output, "Orientation: Horizontal, Vertical")
+	fmt.Fprintln(c.output, "Coordinates: A1 to J10 (column letter, row number), or 0-based x and y")
+}
 
-	for {
-		fmt.Fprint(c.output, "> ")
-		input, _ := c.reader.ReadString('\n')
-		input = strings.TrimSpace(input)
+// handleCommand runs one command. In the json and yaml formats it writes exactly one document with the
+// result or the error of the command.
+func (c *CLI) handleCommand(input string) {
+	parts := strings.Fields(input)
+	if len(parts) == 0 {
+		return
+	}
 
-		if input == "exit" {
-			break
-		}
+	c.failed = false
+	c.result = nil
+	c.errors = nil
 
-		c.handleCommand(input)
+	if c.remote != nil && !c.remoteCommand(parts[0]) {
+		c.errorf("Error: %s is not available when connected to a server\n", parts[0])
+	} else {
+		c.dispatch(parts[0], parts[1:])
 	}
+	c.flush(parts[0])
+	c.logger.Debug("command", "command", parts[0], "args", len(parts)-1, "failed", c.failed)
 }
 
-func (c *CLI) handleCommand(input string) {
-	parts := strings.Fields(input)
-	if len(parts) == 0 {
+// errorf reports a failed command
+func (c *CLI) errorf(format string, args ...any) {
+	c.failed = true
+	if c.structured() {
+		c.errors = append(c.errors, strings.TrimSpace(fmt.Sprintf(format, args...)))
 		return
 	}
+	fmt.Fprintf(c.output, format, args...)
+}
 
-	cmd := parts[0]
-	args := parts[1:]
+// errorln reports a failed command
+func (c *CLI) errorln(args ...any) {
+	c.failed = true
+	if c.structured() {
+		c.errors = append(c.errors, strings.TrimSpace(fmt.Sprintln(args...)))
+		return
+	}
+	fmt.Fprintln(c.output, args...)
+}
 
+func (c *CLI) dispatch(cmd string, args []string) {
 	switch cmd {
+	case "output":
+		if len(args) < 1 {
+			c.errorln("Usage: output <text|json|yaml>")
+			return
+		}
+		c.setFormat(args[0])
+
+	case "login":
+		if len(args) < 1 {
+			c.errorln("Usage: login <player>")
+			return
+		}
+		c.login(args[0])
+
+	case "logout":
+		if len(args) < 1 {
+			c.errorln("Usage: logout <player>")
+			return
+		}
+		c.logout(args[0])
+
 	case "create-game":
 		if len(args) < 1 {
-			fmt.Fprintln(c.output, "Usage: create-game <player> [name]")
+			c.errorln("Usage: create-game <player> [name]")
 			return
 		}
 
@@ -97,27 +221,46 @@ func (c *CLI) handleCommand(input string) {
 
 		c.createGame(playerName, gameName)
 
+	case "create-private-game":
+		if len(args) < 1 {
+			c.errorln("Usage: create-private-game <player> [name]")
+			return
+		}
+
+		var gameName string
+		if len(args) > 1 {
+			gameName = args[1]
+		}
+		c.createPrivateGame(args[0], gameName)
+
+	case "join-code":
+		if len(args) < 2 {
+			c.errorln("Usage: join-code <invite-code> <player>")
+			return
+		}
+		c.joinGameByCode(args[0], args[1])
+
 	case "join-game":
 		if len(args) < 2 {
-			fmt.Fprintln(c.output, "Usage: join-game <game-id> <player>")
+			c.errorln("Usage: join-game <game-id> <player>")
 			return
 		}
 		c.joinGame(args[0], args[1])
 
 	case "set-game":
 		if len(args) < 1 {
-			fmt.Fprintln(c.output, "Usage: set-game <game-id>")
+			c.errorln("Usage: set-game <game-id>")
 			return
 		}
 		c.setGame(args[0])
 
 	case "start-game":
 		if c.currentGameID == "" {
-			fmt.Fprintln(c.output, "Error: No game ID set. Use set-game first or specify a game ID.")
+			c.errorln("Error: No game ID set. Use set-game first or specify a game ID.")
 			return
 		}
 		if len(args) < 1 {
-			fmt.Fprintln(c.output, "Usage: start-game <player>")
+			c.errorln("Usage: start-game <player>")
 			return
 		}
 		c.startGame(c.currentGameID, args[0])
@@ -129,7 +272,7 @@ func (c *CLI) handleCommand(input string) {
 			var err error
 			page, err = strconv.Atoi(args[0])
 			if err != nil {
-				fmt.Fprintln(c.output, "Invalid page number")
+				c.errorln("Invalid page number")
 				return
 			}
 		}
@@ -137,7 +280,7 @@ func (c *CLI) handleCommand(input string) {
 			var err error
 			count, err = strconv.Atoi(args[1])
 			if err != nil {
-				fmt.Fprintln(c.output, "Invalid count number")
+				c.errorln("Invalid count number")
 				return
 			}
 		}
@@ -145,7 +288,7 @@ func (c *CLI) handleCommand(input string) {
 
 	case "delete-game":
 		if len(args) < 1 {
-			fmt.Fprintln(c.output, "Usage: delete-game <game-id|all>")
+			c.errorln("Usage: delete-game <game-id|all>")
 			return
 		}
 
@@ -155,9 +298,20 @@ func (c *CLI) handleCommand(input string) {
 			c.deleteGame(args[0])
 		}
 
+	case "cancel-game":
+		if len(args) < 1 || (len(args) < 2 && c.currentGameID == "") {
+			c.errorln("Usage: cancel-game <player> <game-id|name> or set a game ID with set-game")
+			return
+		}
+		gameID := c.currentGameID
+		if len(args) > 1 {
+			gameID = args[1]
+		}
+		c.cancelGame(gameID, args[0])
+
 	case "show-game":
 		if len(args) < 1 && c.currentGameID == "" {
-			fmt.Fprintln(c.output, "Usage: show-game <game-id> or set a game ID with set-game")
+			c.errorln("Usage: show-game <game-id> or set a game ID with set-game")
 			return
 		}
 		gameID := c.currentGameID
@@ -168,91 +322,391 @@ func (c *CLI) handleCommand(input string) {
 
 	case "place-ship":
 		// First check if we have all the required parameters
-		if len(args) < 5 {
-			fmt.Fprintln(c.output, "Usage: place-ship <player> <ship-type> <x> <y> <orientation>")
-			fmt.Fprintln(c.output, "Orientation must be either 'Horizontal' or 'Vertical'")
+		if len(args) < 4 {
+			c.errorln("Usage: place-ship <player> <ship-type> <coordinate|x y> <orientation>")
+			c.errorln("Coordinate like B7, or 0-based x and y. Orientation must be either 'Horizontal' or 'Vertical'")
 			return
 		}
 
 		// Then check if we have a game ID
 		if c.currentGameID == "" {
-			fmt.Fprintln(c.output, "You must set a game ID first with set-game or include it in the command")
+			c.errorln("You must set a game ID first with set-game or include it in the command")
 			return
 		}
 
 		// Parse the coordinates
-		x, err := strconv.Atoi(args[2])
+		x, y, used, err := parseCoordinateArgs(args[2:])
 		if err != nil {
-			fmt.Fprintln(c.output, "Invalid x coordinate")
+			c.errorf("Invalid coordinate: %v\n", err)
 			return
 		}
-
-		y, err := strconv.Atoi(args[3])
-		if err != nil {
-			fmt.Fprintln(c.output, "Invalid y coordinate")
+		if len(args) < 3+used {
+			c.errorln("Usage: place-ship <player> <ship-type> <coordinate|x y> <orientation>")
 			return
 		}
 
 		// Validate orientation
-		orientation := args[4]
+		orientation := args[2+used]
 		if orientation != "Horizontal" && orientation != "Vertical" {
-			fmt.Fprintln(c.output, "Invalid orientation. Must be either 'Horizontal' or 'Vertical'")
+			c.errorln("Invalid orientation. Must be either 'Horizontal' or 'Vertical'")
 			return
 		}
 
 		c.placeShip(c.currentGameID, args[0], args[1], x, y, orientation)
 
 	case "fire":
-		if c.currentGameID == "" && len(args) < 3 {
-			fmt.Fprintln(c.output, "Usage: fire <player> <x> <y>")
-			fmt.Fprintln(c.output, "You must set a game ID first with set-game or include it in the command")
+		if c.currentGameID == "" || len(args) < 2 {
+			c.errorln("Usage: fire <player> <coordinate|x y>, e.g. fire player1 B7")
+			c.errorln("You must set a game ID first with set-game or include it in the command")
 			return
 		}
 
-		x, err := strconv.Atoi(args[1])
+		x, y, _, err := parseCoordinateArgs(args[1:])
 		if err != nil {
-			fmt.Fprintln(c.output, "Invalid x coordinate")
+			c.errorf("Invalid coordinate: %v\n", err)
 			return
 		}
 
-		y, err := strconv.Atoi(args[2])
-		if err != nil {
-			fmt.Fprintln(c.output, "Invalid y coordinate")
+		c.fire(c.currentGameID, args[0], x, y)
+
+	case "export-game":
+		if len(args) < 1 && c.currentGameID == "" {
+			c.errorln("Usage: export-game <game-id|name> [file] or set a game ID with set-game")
+			return
+		}
+		gameID := c.currentGameID
+		if len(args) > 0 {
+			gameID = args[0]
+		}
+		file := ""
+		if len(args) > 1 {
+			file = args[1]
+		}
+		c.exportGame(gameID, file)
+
+	case "import-game":
+		if len(args) < 1 {
+			c.errorln("Usage: import-game <file>")
 			return
 		}
+		c.importGame(args[0])
 
-		c.fire(c.currentGameID, args[0], x, y)
+	case "hot-seat":
+		c.hotSeat(args)
+
+	case "say":
+		if c.currentGameID == "" {
+			c.errorln("Error: No game ID set. Use set-game first or specify a game ID.")
+			return
+		}
+		if len(args) < 2 {
+			c.errorln("Usage: say <player> <message>")
+			return
+		}
+		c.say(c.currentGameID, args[0], strings.Join(args[1:], " "))
+
+	case "chat-log":
+		if c.currentGameID == "" {
+			c.errorln("Error: No game ID set. Use set-game first or specify a game ID.")
+			return
+		}
+
+		page := 0
+		count := 20
+		if len(args) > 0 {
+			var err error
+			page, err = strconv.Atoi(args[0])
+			if err != nil {
+				c.errorln("Invalid page number")
+				return
+			}
+		}
+		if len(args) > 1 {
+			var err error
+			count, err = strconv.Atoi(args[1])
+			if err != nil {
+				c.errorln("Invalid count number")
+				return
+			}
+		}
+		c.chatLog(c.currentGameID, page, count)
+
+	case "rematch":
+		if c.currentGameID == "" {
+			c.errorln("Error: No game ID set. Use set-game first or specify a game ID.")
+			return
+		}
+		if len(args) < 1 {
+			c.errorln("Usage: rematch <player>")
+			return
+		}
+		c.requestRematch(c.currentGameID, args[0])
+
+	case "accept-rematch":
+		if c.currentGameID == "" {
+			c.errorln("Error: No game ID set. Use set-game first or specify a game ID.")
+			return
+		}
+		if len(args) < 1 {
+			c.errorln("Usage: accept-rematch <player>")
+			return
+		}
+		c.acceptRematch(c.currentGameID, args[0])
+
+	case "create-tournament":
+		if len(args) < 3 {
+			c.errorln("Usage: create-tournament <organizer> <name> <round-robin|single-elimination>")
+			return
+		}
+		c.createTournament(args[0], args[1], tournament.Format(args[2]))
+
+	case "show-tournaments":
+		page := 0
+		count := 10
+		if len(args) > 0 {
+			var err error
+			page, err = strconv.Atoi(args[0])
+			if err != nil {
+				c.errorln("Invalid page number")
+				return
+			}
+		}
+		if len(args) > 1 {
+			var err error
+			count, err = strconv.Atoi(args[1])
+			if err != nil {
+				c.errorln("Invalid count number")
+				return
+			}
+		}
+		c.showTournaments(page, count)
+
+	case "show-tournament":
+		if len(args) < 1 {
+			c.errorln("Usage: show-tournament <tournament-id>")
+			return
+		}
+		c.showTournament(args[0])
+
+	case "join-tournament":
+		if len(args) < 2 {
+			c.errorln("Usage: join-tournament <tournament-id> <player>")
+			return
+		}
+		c.joinTournament(args[0], args[1])
+
+	case "start-tournament":
+		if len(args) < 2 {
+			c.errorln("Usage: start-tournament <tournament-id> <organizer>")
+			return
+		}
+		c.startTournament(args[0], args[1])
+
+	case "advance-tournament":
+		if len(args) < 2 {
+			c.errorln("Usage: advance-tournament <tournament-id> <player>")
+			return
+		}
+		c.advanceTournament(args[0], args[1])
+
+	case "find-match":
+		if len(args) < 1 {
+			c.errorln("Usage: find-match <player> [preset] [min-rating] [max-rating]")
+			return
+		}
+
+		var request game.MatchRequest
+		if len(args) > 1 {
+			request.Preset = game.RulePreset(args[1])
+		}
+		if len(args) > 2 {
+			var err error
+			request.MinRating, err = strconv.Atoi(args[2])
+			if err != nil {
+				c.errorln("Invalid minimum rating")
+				return
+			}
+		}
+		if len(args) > 3 {
+			var err error
+			request.MaxRating, err = strconv.Atoi(args[3])
+			if err != nil {
+				c.errorln("Invalid maximum rating")
+				return
+			}
+		}
+		c.findMatch(args[0], request)
+
+	case "match-status":
+		if len(args) < 1 {
+			c.errorln("Usage: match-status <player>")
+			return
+		}
+		c.matchStatus(args[0])
+
+	case "cancel-match":
+		if len(args) < 1 {
+			c.errorln("Usage: cancel-match <player>")
+			return
+		}
+		c.cancelMatch(args[0])
+
+	case "add-webhook":
+		if len(args) < 2 {
+			c.errorln("Usage: add-webhook <player> <url> [event...]")
+			return
+		}
+
+		events := make([]game.EventType, 0, len(args)-2)
+		for _, e := range args[2:] {
+			events = append(events, game.EventType(e))
+		}
+		c.addWebhook(args[0], args[1], events)
+
+	case "show-webhooks":
+		if len(args) < 1 {
+			c.errorln("Usage: show-webhooks <player>")
+			return
+		}
+		c.showWebhooks(args[0])
+
+	case "remove-webhook":
+		if len(args) < 2 {
+			c.errorln("Usage: remove-webhook <webhook-id> <player>")
+			return
+		}
+		c.removeWebhook(args[0], args[1])
+
+	case "webhook-deliveries":
+		if len(args) < 2 {
+			c.errorln("Usage: webhook-deliveries <webhook-id> <player> [page] [count]")
+			return
+		}
+
+		page := 0
+		count := 10
+		if len(args) > 2 {
+			var err error
+			page, err = strconv.Atoi(args[2])
+			if err != nil {
+				c.errorln("Invalid page number")
+				return
+			}
+		}
+		if len(args) > 3 {
+			var err error
+			count, err = strconv.Atoi(args[3])
+			if err != nil {
+				c.errorln("Invalid count number")
+				return
+			}
+		}
+		c.webhookDeliveries(args[0], args[1], page, count)
+
+	case "doctor":
+		if len(args) > 0 && args[0] != "create-indexes" {
+			c.errorln("Usage: doctor [create-indexes]")
+			return
+		}
+		c.doctor(len(args) > 0)
 
 	default:
-		fmt.Fprintf(c.output, "Unknown command: %s\n", cmd)
+		c.errorf("Unknown command: %s\n", cmd)
 	}
 }
 
+// parseCoordinateArgs reads a field given either in letter-number notation like "B7" or as 0-based x and y.
+// It also returns the number of arguments it used.
+func parseCoordinateArgs(args []string) (int, int, int, error) {
+	if len(args) == 0 {
+		return 0, 0, 0, errors.New("missing coordinate")
+	}
+
+	if x, y, err := game.ParseCoordinate(args[0]); err == nil {
+		return x, y, 1, nil
+	}
+
+	x, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%q is neither A1 to J10 nor a 0-based x", args[0])
+	}
+
+	if len(args) < 2 {
+		return 0, 0, 0, errors.New("missing y")
+	}
+
+	y, err := strconv.Atoi(args[1])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%q is not a 0-based y", args[1])
+	}
+
+	return x, y, 2, nil
+}
+
 func (c *CLI) createGame(playerName, gameName string) {
+	c.createGameWithVisibility(playerName, gameName, game.VisibilityPublic)
+}
+
+func (c *CLI) createPrivateGame(playerName, gameName string) {
+	c.createGameWithVisibility(playerName, gameName, game.VisibilityPrivate)
+}
+
+func (c *CLI) createGameWithVisibility(playerName, gameName string, visibility game.Visibility) {
+	if c.remote != nil {
+		g, err := c.remoteCreateGame(playerName, gameName, visibility)
+		if err != nil {
+			c.errorf("Error creating game: %v\n", err)
+			return
+		}
+		c.reportGameCreated(g, gameName, visibility)
+		return
+	}
+
 	// First ensure the player exists
 	player, err := c.api.NewPlayer(playerName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error creating player: %v\n", err)
+		c.errorf("Error creating player: %v\n", err)
 		return
 	}
 
-	g, err := c.api.NewGame(player.Name, gameName)
+	var g *game.Game
+	if visibility == game.VisibilityPrivate {
+		g, err = c.api.NewPrivateGame(player.Name, gameName)
+	} else {
+		g, err = c.api.NewGame(player.Name, gameName)
+	}
 	if err != nil {
-		fmt.Fprintf(c.output, "Error creating game: %v\n", err)
+		c.errorf("Error creating game: %v\n", err)
 		return
 	}
 
-	if gameName != "" {
-		fmt.Fprintf(c.output, "Created new game '%s' with ID: %s\n", gameName, g.ID)
-	} else {
-		fmt.Fprintf(c.output, "Created new game with ID: %s\n", g.ID)
+	c.reportGameCreated(g, gameName, visibility)
+}
+
+func (c *CLI) reportGameCreated(g *game.Game, gameName string, visibility game.Visibility) {
+	kind := "game"
+	if visibility == game.VisibilityPrivate {
+		kind = "private game"
 	}
 
+	c.emit(newGameResult(g), func() {
+		if gameName != "" {
+			fmt.Fprintf(c.output, "Created new %s '%s' with ID: %s\n", kind, gameName, g.ID)
+		} else {
+			fmt.Fprintf(c.output, "Created new %s with ID: %s\n", kind, g.ID)
+		}
+		fmt.Fprintf(c.output, "Invite code: %s\n", game.FormatInviteCode(g.InviteCode))
+	})
+
 	c.currentGameID = g.ID
 }
 
 // getGameByIDOrName tries to find a game by ID first, then by name if that fails
 func (c *CLI) getGameByIDOrName(idOrName string) (*game.Game, error) {
+	if c.remote != nil {
+		return c.remoteGame(idOrName)
+	}
+
 	// Try by ID first
 	g, err := c.api.GetGame(idOrName)
 	if err == nil {
@@ -269,39 +723,80 @@ func (c *CLI) getGameByIDOrName(idOrName string) (*game.Game, error) {
 }
 
 func (c *CLI) joinGame(gameIDOrName, playerName string) {
+	if c.remote != nil {
+		g, err := c.remoteJoinGame(gameIDOrName, playerName)
+		if err != nil {
+			c.errorf("Error joining game: %v\n", err)
+			return
+		}
+		c.reportJoined(g, playerName)
+		return
+	}
+
 	g, err := c.getGameByIDOrName(gameIDOrName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting game: %v\n", err)
+		c.errorf("Error getting game: %v\n", err)
 		return
 	}
 
 	// First ensure the player exists
 	player, err := c.api.NewPlayer(playerName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting player: %v\n", err)
+		c.errorf("Error getting player: %v\n", err)
 		return
 	}
 
 	err = g.Join(player)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error joining game: %v\n", err)
+		c.errorf("Error joining game: %v\n", err)
 		return
 	}
 
 	_, err = c.api.UpdateGame(g)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error updating game: %v\n", err)
+		c.errorf("Error updating game: %v\n", err)
+		return
+	}
+
+	c.reportJoined(g, playerName)
+}
+
+func (c *CLI) joinGameByCode(code, playerName string) {
+	if c.remote != nil {
+		g, err := c.remoteJoinGameByCode(code, playerName)
+		if err != nil {
+			c.errorf("Error joining game: %v\n", err)
+			return
+		}
+		c.reportJoined(g, playerName)
+		return
+	}
+
+	// First ensure the player exists
+	_, err := c.api.NewPlayer(playerName)
+	if err != nil {
+		c.errorf("Error getting player: %v\n", err)
 		return
 	}
 
-	gameName := ""
+	g, err := c.api.JoinGameByCode(code, playerName)
+	if err != nil {
+		c.errorf("Error joining game: %v\n", err)
+		return
+	}
+
+	c.reportJoined(g, playerName)
+}
+
+func (c *CLI) reportJoined(g *game.Game, playerName string) {
+	gameName := g.ID
 	if g.Name != "" {
 		gameName = fmt.Sprintf("'%s'", g.Name)
-	} else {
-		gameName = g.ID
 	}
 
-	fmt.Fprintf(c.output, "Player %s joined game %s\n", playerName, gameName)
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Player %s joined game %s\n", playerName, gameName)
+	})
 	c.currentGameID = g.ID
 }
 
@@ -309,7 +804,7 @@ func (c *CLI) setGame(gameIDOrName string) {
 	// Verify the game exists
 	g, err := c.getGameByIDOrName(gameIDOrName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error: Game %s not found\n", gameIDOrName)
+		c.errorf("Error: Game %s not found\n", gameIDOrName)
 		return
 	}
 
@@ -320,69 +815,128 @@ func (c *CLI) setGame(gameIDOrName string) {
 		displayName = fmt.Sprintf("%s (ID: %s)", g.Name, g.ID)
 	}
 
-	fmt.Fprintf(c.output, "Current game set to: %s\n", displayName)
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Current game set to: %s\n", displayName)
+	})
 }
 
 func (c *CLI) startGame(gameID, playerName string) {
+	if c.remote != nil {
+		g, err := c.remoteStartGame(gameID, playerName)
+		if err != nil {
+			c.errorf("Error starting game: %v\n", err)
+			return
+		}
+		c.reportStarted(g)
+		return
+	}
+
 	g, err := c.api.GetGame(gameID)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting game: %v\n", err)
+		c.errorf("Error getting game: %v\n", err)
 		return
 	}
 
 	err = g.Start(playerName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error starting game: %v\n", err)
+		c.errorf("Error starting game: %v\n", err)
 		return
 	}
 
 	_, err = c.api.UpdateGame(g)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error updating game: %v\n", err)
+		c.errorf("Error updating game: %v\n", err)
 		return
 	}
 
-	fmt.Fprintf(c.output, "Game %s started! Player to move: %s\n", gameID, g.PlayerToMove)
+	c.reportStarted(g)
+}
+
+func (c *CLI) reportStarted(g *game.Game) {
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Game %s started! Player to move: %s\n", g.ID, g.PlayerToMove)
+	})
 }
 
 func (c *CLI) placeShip(gameID, playerName, shipTypeStr string, x, y int, orientationStr string) {
+	shipType := game.ShipType(shipTypeStr)
+	orientation := game.ShipOrientation(orientationStr)
+
+	if c.remote != nil {
+		g, started, err := c.remotePlaceShip(gameID, playerName, shipType, x, y, orientation)
+		if err != nil {
+			c.errorf("Error placing ship: %v\n", err)
+			return
+		}
+		c.reportPlacement(g, playerName, shipType, x, y, orientation, started)
+		return
+	}
+
 	g, err := c.api.GetGame(gameID)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting game: %v\n", err)
+		c.errorf("Error getting game: %v\n", err)
 		return
 	}
 
-	shipType := game.ShipType(shipTypeStr)
-	orientation := game.ShipOrientation(orientationStr)
-
 	err = g.PlaceShip(playerName, shipType, x, y, orientation)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error placing ship: %v\n", err)
+		c.errorf("Error placing ship: %v\n", err)
 		return
 	}
 
 	// Check if we can automatically start the game
+	started := false
 	if g.Status == game.StatusSetup {
 		// Try to start the game - if it's not ready yet (not all pins placed), it will return an error
-		err = g.Start(playerName)
-		if err == nil {
-			fmt.Fprintf(c.output, "All ships placed. Game automatically started! Player to move: %s\n", g.PlayerToMove)
-		}
+		started = g.Start(playerName) == nil
 	}
 
 	_, err = c.api.UpdateGame(g)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error updating game: %v\n", err)
+		c.errorf("Error updating game: %v\n", err)
 		return
 	}
 
-	fmt.Fprintf(c.output, "Placed %s at (%d,%d) %s for player %s\n", shipType, x, y, orientation, playerName)
+	c.reportPlacement(g, playerName, shipType, x, y, orientation, started)
+}
+
+func (c *CLI) reportPlacement(g *game.Game, playerName string, shipType game.ShipType, x, y int, orientation game.ShipOrientation, started bool) {
+	result := placementResult{
+		GameID:      g.ID,
+		Player:      playerName,
+		Ship:        shipType,
+		Coordinate:  game.FormatCoordinate(x, y),
+		X:           x,
+		Y:           y,
+		Orientation: orientation,
+		Started:     started,
+	}
+	if started {
+		result.PlayerToMove = g.PlayerToMove
+	}
+
+	c.emit(result, func() {
+		if started {
+			fmt.Fprintf(c.output, "All ships placed. Game automatically started! Player to move: %s\n", g.PlayerToMove)
+		}
+		fmt.Fprintf(c.output, "Placed %s at %s %s for player %s\n", shipType, result.Coordinate, orientation, playerName)
+	})
 }
 
 func (c *CLI) fire(gameID, playerName string, x, y int) {
+	if c.remote != nil {
+		g, err := c.remoteFire(gameID, playerName, x, y)
+		if err != nil {
+			c.errorf("Error making move: %v\n", err)
+			return
+		}
+		c.reportShot(g, playerName, x, y)
+		return
+	}
+
 	g, err := c.api.GetGame(gameID)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting game: %v\n", err)
+		c.errorf("Error getting game: %v\n", err)
 		return
 	}
 
@@ -394,10 +948,20 @@ func (c *CLI) fire(gameID, playerName string, x, y int) {
 
 	err = g.MakeMove(move)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error making move: %v\n", err)
+		c.errorf("Error making move: %v\n", err)
 		return
 	}
 
+	_, err = c.api.UpdateGame(g)
+	if err != nil {
+		c.errorf("Error updating game: %v\n", err)
+		return
+	}
+
+	c.reportShot(g, playerName, x, y)
+}
+
+func (c *CLI) reportShot(g *game.Game, playerName string, x, y int) {
 	// Check hit or miss
 	lastMove := g.History[len(g.History)-1]
 	hitStatus := "miss"
@@ -405,30 +969,40 @@ func (c *CLI) fire(gameID, playerName string, x, y int) {
 		hitStatus = "hit"
 	}
 
-	_, err = c.api.UpdateGame(g)
-	if err != nil {
-		fmt.Fprintf(c.output, "Error updating game: %v\n", err)
-		return
+	result := shotResult{
+		GameID:     g.ID,
+		Player:     playerName,
+		Coordinate: game.FormatCoordinate(x, y),
+		X:          x,
+		Y:          y,
+		Hit:        lastMove.Hit,
+		Status:     g.Status.String(),
+		Winner:     g.Winner(),
+	}
+	if g.Status == game.StatusPlaying {
+		result.PlayerToMove = g.PlayerToMove
 	}
 
-	fmt.Fprintf(c.output, "Player %s fired at (%d,%d) - %s\n", playerName, x, y, hitStatus)
+	c.emit(result, func() {
+		fmt.Fprintf(c.output, "Player %s fired at %s - %s\n", playerName, result.Coordinate, hitStatus)
 
-	// Check if game is over
-	switch g.Status {
-	case game.StatusWon:
-		fmt.Fprintf(c.output, "Game over! %s won!\n", playerName)
-	case game.StatusLost:
-		fmt.Fprintf(c.output, "Game over! %s lost!\n", playerName)
-	default:
-		fmt.Fprintf(c.output, "Next player to move: %s\n", g.PlayerToMove)
-	}
+		// Check if game is over
+		switch g.Status {
+		case game.StatusWon:
+			fmt.Fprintf(c.output, "Game over! %s won!\n", playerName)
+		case game.StatusLost:
+			fmt.Fprintf(c.output, "Game over! %s lost!\n", playerName)
+		default:
+			fmt.Fprintf(c.output, "Next player to move: %s\n", g.PlayerToMove)
+		}
+	})
 }
 
 func (c *CLI) deleteGame(gameIDOrName string) {
 	// Check if the game exists first
 	g, err := c.getGameByIDOrName(gameIDOrName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error: Game %s not found\n", gameIDOrName)
+		c.errorf("Error: Game %s not found\n", gameIDOrName)
 		return
 	}
 
@@ -441,55 +1015,113 @@ func (c *CLI) deleteGame(gameIDOrName string) {
 	// Delete the game
 	err = c.api.DeleteGame(g.ID)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error deleting game: %v\n", err)
+		c.errorf("Error deleting game: %v\n", err)
 		return
 	}
 
-	fmt.Fprintf(c.output, "Game %s successfully deleted\n", displayName)
-
 	// If we deleted the current game, clear the current game ID
-	if g.ID == c.currentGameID {
+	unset := g.ID == c.currentGameID
+	if unset {
 		c.currentGameID = ""
-		fmt.Fprintln(c.output, "Current game unset")
 	}
+
+	c.emit(deleteResult{Deleted: 1, GameID: g.ID, CurrentGameUnset: unset}, func() {
+		fmt.Fprintf(c.output, "Game %s successfully deleted\n", displayName)
+		if unset {
+			fmt.Fprintln(c.output, "Current game unset")
+		}
+	})
 }
 
-func (c *CLI) deleteAllGames() {
-	// Prompt for confirmation
-	fmt.Fprintln(c.output, "WARNING: This will delete ALL games. This action cannot be undone.")
-	fmt.Fprintln(c.output, "Type 'confirm' to proceed:")
+// cancelGame deletes a game of the player which nobody has joined yet
+func (c *CLI) cancelGame(gameIDOrName, playerName string) {
+	g, err := c.getGameByIDOrName(gameIDOrName)
+	if err != nil {
+		c.errorf("Error: Game %s not found\n", gameIDOrName)
+		return
+	}
+
+	if c.remote != nil {
+		err = c.remoteCancelGame(g.ID, playerName)
+	} else {
+		err = c.api.CancelGame(g.ID, playerName)
+	}
+	if err != nil {
+		c.errorf("Error cancelling game: %v\n", err)
+		return
+	}
 
-	input, _ := c.reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	unset := g.ID == c.currentGameID
+	if unset {
+		c.currentGameID = ""
+	}
+
+	c.emit(deleteResult{Deleted: 1, GameID: g.ID, CurrentGameUnset: unset}, func() {
+		fmt.Fprintf(c.output, "Game %s cancelled\n", g.ID)
+		if unset {
+			fmt.Fprintln(c.output, "Current game unset")
+		}
+	})
+}
+
+func (c *CLI) deleteAllGames() {
+	// Prompt for confirmation, the structured formats expect the confirmation without prompting
+	if !c.structured() {
+		fmt.Fprintln(c.output, "WARNING: This will delete ALL games. This action cannot be undone.")
+		fmt.Fprintln(c.output, "Type 'confirm' to proceed:")
+	}
 
+	input, _ := c.readLine()
 	if input != "confirm" {
-		fmt.Fprintln(c.output, "Operation cancelled")
+		c.emit(deleteResult{Cancelled: true}, func() {
+			fmt.Fprintln(c.output, "Operation cancelled")
+		})
 		return
 	}
 
 	// Delete all games
 	count, err := c.api.DeleteAllGames()
 	if err != nil {
-		fmt.Fprintf(c.output, "Error deleting all games: %v\n", err)
+		c.errorf("Error deleting all games: %v\n", err)
 		return
 	}
 
-	fmt.Fprintf(c.output, "Successfully deleted %d games\n", count)
-
 	// Clear the current game ID
-	if c.currentGameID != "" {
-		c.currentGameID = ""
-		fmt.Fprintln(c.output, "Current game unset")
-	}
+	unset := c.currentGameID != ""
+	c.currentGameID = ""
+
+	c.emit(deleteResult{Deleted: count, CurrentGameUnset: unset}, func() {
+		fmt.Fprintf(c.output, "Successfully deleted %d games\n", count)
+		if unset {
+			fmt.Fprintln(c.output, "Current game unset")
+		}
+	})
 }
 
 func (c *CLI) showGames(page, count int) {
-	games, err := c.api.Games(page, count)
+	var games []game.Summary
+	var err error
+	if c.remote != nil {
+		games, err = c.remote.Games(page, count)
+	} else {
+		games, err = c.api.GameSummaries(page, count)
+	}
 	if err != nil {
-		fmt.Fprintf(c.output, "Error retrieving games: %v\n", err)
+		c.errorf("Error retrieving games: %v\n", err)
 		return
 	}
 
+	results := make([]summaryResult, len(games))
+	for i, g := range games {
+		results[i] = newSummaryResult(g)
+	}
+
+	c.emit(newPageResult(page, count, results), func() {
+		c.printGames(games, page, count)
+	})
+}
+
+func (c *CLI) printGames(games []game.Summary, page, count int) {
 	if len(games) == 0 {
 		fmt.Fprintf(c.output, "No games found on page %d\n", page)
 		return
@@ -500,17 +1132,6 @@ func (c *CLI) showGames(page, count int) {
 	fmt.Fprintln(c.output, "--------------------------|----------------------|-----------|---------------|---------------|------")
 
 	for _, g := range games {
-		// Format status string
-		status := "Setup"
-		switch g.Status {
-		case game.StatusPlaying:
-			status = "Playing"
-		case game.StatusWon:
-			status = "Won"
-		case game.StatusLost:
-			status = "Lost"
-		}
-
 		// Get player names or placeholders
 		player1 := "-"
 		if g.Player1 != nil {
@@ -530,7 +1151,7 @@ func (c *CLI) showGames(page, count int) {
 
 		// Format the output in columns
 		fmt.Fprintf(c.output, "%-24s | %-20s | %-9s | %-13s | %-13s | %d\n",
-			g.ID, name, status, player1, player2, len(g.History))
+			g.ID, name, g.Status, player1, player2, g.Moves)
 	}
 
 	// Add pagination help
@@ -545,15 +1166,28 @@ func (c *CLI) showGames(page, count int) {
 func (c *CLI) showGame(gameIDOrName string) {
 	g, err := c.getGameByIDOrName(gameIDOrName)
 	if err != nil {
-		fmt.Fprintf(c.output, "Error getting game: %v\n", err)
+		c.errorf("Error getting game: %v\n", err)
 		return
 	}
 
+	c.emit(newGameResult(g), func() {
+		c.printGame(g)
+	})
+}
+
+func (c *CLI) printGame(g *game.Game) {
 	fmt.Fprintf(c.output, "Game ID: %s\n", g.ID)
 	if g.Name != "" {
 		fmt.Fprintf(c.output, "Game Name: %s\n", g.Name)
 	}
 
+	if g.Visibility == game.VisibilityPrivate {
+		fmt.Fprintln(c.output, "Visibility: private")
+	}
+	if g.InviteCode != "" {
+		fmt.Fprintf(c.output, "Invite code: %s\n", game.FormatInviteCode(g.InviteCode))
+	}
+
 	fmt.Fprintf(c.output, "Status: ")
 	switch g.Status {
 	case game.StatusSetup:
@@ -568,36 +1202,29 @@ func (c *CLI) showGame(gameIDOrName string) {
 
 	fmt.Fprintf(c.output, "Player 1: %s\n", g.Player1.Name)
 	fmt.Fprintf(c.output, "Player 2: %s\n", g.Player2.Name)
-	fmt.Fprintf(c.output, "Player to move: %s\n\n", g.PlayerToMove)
+	fmt.Fprintf(c.output, "Player to move: %s\n", g.PlayerToMove)
+	if series := g.SeriesScore(); series.Games > 0 {
+		fmt.Fprintf(c.output, "Series: %s\n", formatSeries(series, g))
+	}
+	if g.RematchOf != "" {
+		fmt.Fprintf(c.output, "Rematch of: %s\n", g.RematchOf)
+	}
+	if g.RematchID != "" {
+		fmt.Fprintf(c.output, "Rematch: %s\n", g.RematchID)
+	} else if g.RematchRequestedBy != "" {
+		fmt.Fprintf(c.output, "Rematch requested by: %s\n", g.RematchRequestedBy)
+	}
+	fmt.Fprintln(c.output)
 
 	// Show board for each player
 	for playerName, board := range g.Boards {
 		fmt.Fprintf(c.output, "=== %s's Board ===\n", playerName)
 
-		// Ships map
 		fmt.Fprintln(c.output, "Own ships:")
-		fmt.Fprintln(c.output, "  0 1 2 3 4 5 6 7 8 9")
-		for y := 0; y < 10; y++ {
-			fmt.Fprintf(c.output, "%d ", y)
-			for x := 0; x < 10; x++ {
-				// Now that we've fixed the board.Map FieldState function to swap coordinates,
-				// we can call it with the correct (x,y) parameters
-				fmt.Fprintf(c.output, "%c ", board.Maps[0].FieldState(x, y))
-			}
-			fmt.Fprintln(c.output)
-		}
+		board.ShipsMap().WriteGrid(c.output)
 
-		// Shots map
 		fmt.Fprintln(c.output, "\nShots fired at opponent:")
-		fmt.Fprintln(c.output, "  0 1 2 3 4 5 6 7 8 9")
-		for y := 0; y < 10; y++ {
-			fmt.Fprintf(c.output, "%d ", y)
-			for x := 0; x < 10; x++ {
-				// Use the same coordinate system as for the ships
-				fmt.Fprintf(c.output, "%c ", board.Maps[1].FieldState(x, y))
-			}
-			fmt.Fprintln(c.output)
-		}
+		board.ShotsMap().WriteGrid(c.output)
 		fmt.Fprintln(c.output)
 	}
 
@@ -611,8 +1238,144 @@ func (c *CLI) showGame(gameIDOrName string) {
 			if move.Hit {
 				hitStatus = "hit"
 			}
-			fmt.Fprintf(c.output, "%d. %s fired at (%d,%d) - %s\n", i+1, move.Player, move.X, move.Y, hitStatus)
+			fmt.Fprintf(c.output, "%d. %s fired at %s - %s\n", i+1, move.Player, game.FormatCoordinate(move.X, move.Y), hitStatus)
 		}
 	}
 }
 
+func (c *CLI) say(gameID, playerName, text string) {
+	message, err := c.api.SendChatMessage(gameID, playerName, text)
+	if err != nil {
+		c.errorf("Error sending message: %v\n", err)
+		return
+	}
+
+	c.emit(message, func() {
+		fmt.Fprintf(c.output, "[%s] %s: %s\n", message.CreatedAt.Format("15:04:05"), message.Player, message.Text)
+	})
+}
+
+func (c *CLI) chatLog(gameID string, page, count int) {
+	messages, err := c.api.ChatMessages(gameID, page, count)
+	if err != nil {
+		c.errorf("Error retrieving chat: %v\n", err)
+		return
+	}
+
+	c.emit(newPageResult(page, count, messages), func() {
+		c.printChat(messages, page, count)
+	})
+}
+
+func (c *CLI) printChat(messages []*game.ChatMessage, page, count int) {
+	if len(messages) == 0 {
+		fmt.Fprintf(c.output, "No chat messages on page %d\n", page)
+		return
+	}
+
+	fmt.Fprintf(c.output, "=== Chat (Page %d, Count %d) ===\n", page, count)
+	for _, message := range messages {
+		fmt.Fprintf(c.output, "[%s] %s: %s\n", message.CreatedAt.Format("15:04:05"), message.Player, message.Text)
+	}
+
+	if len(messages) == count {
+		fmt.Fprintf(c.output, "\nFor older messages: chat-log %d %d\n", page+1, count)
+	}
+}
+
+func (c *CLI) requestRematch(gameID, playerName string) {
+	g, err := c.api.RequestRematch(gameID, playerName)
+	if err != nil {
+		c.errorf("Error requesting rematch: %v\n", err)
+		return
+	}
+
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Player %s asked for a rematch of game %s\n", playerName, gameID)
+	})
+}
+
+func (c *CLI) acceptRematch(gameID, playerName string) {
+	g, err := c.api.AcceptRematch(gameID, playerName)
+	if err != nil {
+		c.errorf("Error accepting rematch: %v\n", err)
+		return
+	}
+
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Rematch accepted! New game ID: %s\n", g.ID)
+		fmt.Fprintf(c.output, "Series: %s\n", formatSeries(g.SeriesScore(), g))
+		fmt.Fprintf(c.output, "%s moves first\n", g.FirstToMove)
+	})
+	c.currentGameID = g.ID
+}
+
+// formatSeries renders a series score like "alice 2 - 1 bob (3 games)"
+func formatSeries(series game.Series, g *game.Game) string {
+	return fmt.Sprintf("%s %d - %d %s (%d games)",
+		g.Player1.Name, series.Wins[g.Player1.Name], series.Wins[g.Player2.Name], g.Player2.Name, series.Games)
+}
+
+func (c *CLI) findMatch(playerName string, request game.MatchRequest) {
+	// First ensure the player exists
+	_, err := c.api.NewPlayer(playerName)
+	if err != nil {
+		c.errorf("Error getting player: %v\n", err)
+		return
+	}
+
+	ticket, err := c.api.FindMatch(playerName, request)
+	if err != nil {
+		c.errorf("Error finding match: %v\n", err)
+		return
+	}
+
+	c.reportMatchTicket(ticket)
+}
+
+func (c *CLI) matchStatus(playerName string) {
+	ticket, err := c.api.MatchStatus(playerName)
+	if err != nil {
+		c.errorf("Error getting match status: %v\n", err)
+		return
+	}
+
+	c.reportMatchTicket(ticket)
+}
+
+func (c *CLI) cancelMatch(playerName string) {
+	err := c.api.CancelMatch(playerName)
+	if err != nil {
+		c.errorf("Error cancelling match: %v\n", err)
+		return
+	}
+
+	c.emit(map[string]string{"player": playerName, "status": "cancelled"}, func() {
+		fmt.Fprintf(c.output, "Player %s left the matchmaking queue\n", playerName)
+	})
+}
+
+// reportMatchTicket shows a ticket and switches to its game once the player was matched
+func (c *CLI) reportMatchTicket(ticket *game.MatchTicket) {
+	if ticket.Status == game.MatchStatusMatched && ticket.GameID != "" {
+		c.currentGameID = ticket.GameID
+	}
+
+	c.emit(ticket, func() {
+		c.printMatchTicket(ticket)
+	})
+}
+
+func (c *CLI) printMatchTicket(ticket *game.MatchTicket) {
+	if ticket.Status != game.MatchStatusMatched {
+		fmt.Fprintf(c.output, "Player %s is waiting for an opponent (%s rules)\n", ticket.Player, ticket.Preset)
+		return
+	}
+
+	if ticket.GameID == "" {
+		fmt.Fprintf(c.output, "Player %s has been matched, the game is being created\n", ticket.Player)
+		return
+	}
+
+	fmt.Fprintf(c.output, "Player %s matched against %s in game %s\n", ticket.Player, ticket.Opponent, ticket.GameID)
+}
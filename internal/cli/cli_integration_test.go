@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ func TestCLIIntegration(t *testing.T) {
 	cfg := testutil.NewTestConfig()
 
 	// Create database connection
-	db, err := storage.New(cfg.Database)
+	db, err := storage.New(cfg.Database, slog.Default())
 	if err != nil {
 		t.Logf("Database connection creation failed: %v", err)
 		t.Skip("Skipping integration test: failed to create database connection")
@@ -183,6 +183,36 @@ func TestCLI_HandleCommand(t *testing.T) {
 				assert.Equal(t, "myAwesomeGame", m.games["game456"].Name)
 			},
 		},
+		{
+			name:    "Create Private Game",
+			command: "create-private-game player1 secret",
+			setup: func(m *mockStorage) {
+				m.mockCreateGame = func(g *game.Game) (*game.Game, error) {
+					g.ID = "game789"
+					return g, nil
+				}
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				assert.Equal(t, "game789", c.currentGameID)
+				assert.Equal(t, game.VisibilityPrivate, m.games["game789"].Visibility)
+				assert.NotEmpty(t, m.games["game789"].InviteCode)
+			},
+		},
+		{
+			name:    "Join Game By Code",
+			command: "join-code abc-def player2",
+			setup: func(m *mockStorage) {
+				g := game.NewGame(&game.Player{Name: "player1"}, "")
+				g.ID = "game789"
+				g.Visibility = game.VisibilityPrivate
+				g.InviteCode = "ABCDEF"
+				m.games["game789"] = g
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				assert.Equal(t, "game789", c.currentGameID)
+				assert.Contains(t, m.games["game789"].Boards, "player2")
+			},
+		},
 		{
 			name:    "Join Game",
 			command: "join-game game123 player2",
@@ -240,6 +270,97 @@ func TestCLI_HandleCommand(t *testing.T) {
 				assert.False(t, exists)
 			},
 		},
+		{
+			name:    "Find Match",
+			command: "find-match player2 classic",
+			setup: func(m *mockStorage) {
+				m.players["player1"] = &game.Player{Name: "player1"}
+				_, err := m.CreateMatchTicket(&game.MatchTicket{
+					Player: "player1",
+					Preset: game.RulesClassic,
+					Status: game.MatchStatusWaiting,
+				})
+				require.NoError(t, err)
+				m.mockCreateGame = func(g *game.Game) (*game.Game, error) {
+					g.ID = "match123"
+					return g, nil
+				}
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				assert.Equal(t, "match123", c.currentGameID)
+				assert.Equal(t, "player2", m.games["match123"].Player2.Name)
+				ticket, err := m.FindMatchTicket("player1")
+				require.NoError(t, err)
+				assert.Equal(t, "match123", ticket.GameID)
+			},
+		},
+		{
+			name:    "Accept Rematch",
+			command: "accept-rematch player2",
+			setup: func(m *mockStorage) {
+				g := game.NewGame(&game.Player{Name: "player1"}, "")
+				g.ID = "game123"
+				require.NoError(t, g.Join(&game.Player{Name: "player2"}))
+				g.Status = game.StatusWon
+				g.RematchRequestedBy = "player1"
+				m.games["game123"] = g
+				m.mockCreateGame = func(g *game.Game) (*game.Game, error) {
+					g.ID = "game456"
+					return g, nil
+				}
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				assert.Equal(t, "game456", c.currentGameID)
+				assert.Equal(t, "game456", m.games["game123"].RematchID)
+				assert.Equal(t, "game123", m.games["game456"].RematchOf)
+				assert.Equal(t, 1, m.games["game456"].Series.Wins["player1"])
+			},
+		},
+		{
+			name:    "Place Ship With Coordinate",
+			command: "place-ship player1 Submarine B7 Vertical",
+			setup: func(m *mockStorage) {
+				g := game.NewGame(&game.Player{Name: "player1"}, "")
+				g.ID = "game123"
+				m.games["game123"] = g
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				board := m.games["game123"].Boards["player1"]
+				require.NotEmpty(t, board.Fleet)
+				assert.Equal(t, game.ShipPosition{X: 1, Y: 6}, board.Fleet[0].Position)
+				assert.Equal(t, game.OrientationVertical, board.Fleet[0].Orientation)
+			},
+		},
+		{
+			name:    "Say",
+			command: "say player2 good luck, have fun",
+			setup: func(m *mockStorage) {
+				g := game.NewGame(&game.Player{Name: "player1"}, "")
+				g.ID = "game123"
+				require.NoError(t, g.Join(&game.Player{Name: "player2"}))
+				m.games["game123"] = g
+			},
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				messages, err := m.QueryChatMessages("game123", 0, 10)
+				require.NoError(t, err)
+				require.Len(t, messages, 1)
+				assert.Equal(t, "game123", messages[0].GameID)
+				assert.Equal(t, "player2", messages[0].Player)
+				assert.Equal(t, "good luck, have fun", messages[0].Text)
+			},
+		},
+		{
+			name:    "Add Webhook",
+			command: "add-webhook player1 https://example.com/hook chat.message",
+			assert: func(t *testing.T, c *CLI, m *mockStorage) {
+				require.Len(t, m.webhooks, 1)
+				hook := m.webhooks["webhook1"]
+				assert.Equal(t, "player1", hook.Owner)
+				assert.Equal(t, "https://example.com/hook", hook.URL)
+				assert.Equal(t, []game.EventType{game.EventChatMessage}, hook.Events)
+				assert.NotEmpty(t, hook.Secret)
+			},
+		},
 		{
 			name:    "Place Ship",
 			command: "place-ship player1 Battleship 0 0 Horizontal",
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/health"
+	"github.com/Jagreen1970/battleship/internal/storage"
+)
+
+// doctorResult is the structured form of doctor
+type doctorResult struct {
+	Config  string                `json:"config,omitempty"`
+	Server  string                `json:"server,omitempty"`
+	Checks  []health.Check        `json:"checks"`
+	Workers []health.Worker       `json:"workers,omitempty"`
+	Indexes []storage.IndexStatus `json:"indexes,omitempty"`
+}
+
+// doctor checks the configuration, the connection to the database and its indexes. Connected to a server
+// it reports the readiness of the server instead. Missing indexes are created with create-indexes.
+func (c *CLI) doctor(createIndexes bool) {
+	if c.remote != nil {
+		c.remoteDoctor()
+		return
+	}
+
+	result := doctorResult{Config: health.StatusOK}
+	var problems []string
+	if c.config != nil {
+		if err := c.config.Validate(); err != nil {
+			result.Config = err.Error()
+			problems = append(problems, "invalid configuration: "+err.Error())
+		}
+	}
+
+	checker := health.New()
+	checker.AddCheck("database", c.db.Ping)
+	report := checker.Ready()
+	result.Checks = report.Checks
+	if !report.Ready {
+		problems = append(problems, report.Error)
+	} else {
+		if createIndexes {
+			if err := c.db.CreateIndexes(); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+		indexes, err := c.db.Indexes()
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		result.Indexes = indexes
+	}
+
+	c.emit(result, func() {
+		fmt.Fprintf(c.output, "Configuration: %s\n", result.Config)
+		for _, check := range result.Checks {
+			printCheck(c, check)
+		}
+
+		missing := 0
+		if len(result.Indexes) > 0 {
+			fmt.Fprintln(c.output, "Indexes:")
+		}
+		for _, index := range result.Indexes {
+			status := "present"
+			if !index.Present {
+				status = "missing"
+				missing++
+			}
+			keys := strings.Join(index.Keys, ", ")
+			if index.Unique {
+				keys += " (unique)"
+			}
+			fmt.Fprintf(c.output, "  %-20s %-50s %s\n", index.Collection, keys, status)
+		}
+		if missing > 0 {
+			fmt.Fprintf(c.output, "%d of %d indexes are missing, create them with: doctor create-indexes\n",
+				missing, len(result.Indexes))
+		}
+	})
+
+	for _, problem := range problems {
+		c.errorf("Problem: %s\n", problem)
+	}
+}
+
+// remoteDoctor reports the readiness of the server the CLI is connected to
+func (c *CLI) remoteDoctor() {
+	report, err := c.remote.Ready()
+	if err != nil {
+		c.errorf("Problem: %v\n", err)
+		return
+	}
+
+	result := doctorResult{
+		Server:  "ready",
+		Checks:  report.Checks,
+		Workers: report.Workers,
+	}
+	if !report.Ready {
+		result.Server = "not ready"
+	}
+
+	c.emit(result, func() {
+		fmt.Fprintf(c.output, "Server %s: %s\n", c.remote.URL(), result.Server)
+		for _, check := range result.Checks {
+			printCheck(c, check)
+		}
+		for _, w := range result.Workers {
+			fmt.Fprintf(c.output, "%-14s %s\n", w.Name+":", w.Status)
+		}
+	})
+
+	if !report.Ready {
+		c.errorf("Problem: %s\n", report.Error)
+	}
+}
+
+func printCheck(c *CLI, check health.Check) {
+	fmt.Fprintf(c.output, "%-14s %s (%s)", check.Name+":", check.Status, check.Duration.Round(100*time.Microsecond))
+	if check.Error != "" {
+		fmt.Fprintf(c.output, ": %s", check.Error)
+	}
+	fmt.Fprintln(c.output)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+)
+
+func TestDoctor(t *testing.T) {
+	mockDB := newMockStorage(t)
+	cfg := app.DefaultConfig()
+
+	var outputBuffer bytes.Buffer
+	cli := New(mockDB, cfg)
+	cli.SetIO(nil, &outputBuffer)
+
+	cli.handleCommand("doctor")
+	assert.False(t, cli.failed)
+	assert.Contains(t, outputBuffer.String(), "Configuration: ok")
+	assert.Contains(t, outputBuffer.String(), "database:      ok")
+	assert.Contains(t, outputBuffer.String(), "ticket.player (unique)")
+	assert.Contains(t, outputBuffer.String(), "2 of 2 indexes are missing, create them with: doctor create-indexes")
+
+	outputBuffer.Reset()
+	cli.handleCommand("doctor create-indexes")
+	assert.False(t, cli.failed)
+	assert.True(t, mockDB.indexesCreated)
+	assert.NotContains(t, outputBuffer.String(), "missing")
+
+	outputBuffer.Reset()
+	cli.handleCommand("doctor now")
+	assert.True(t, cli.failed)
+	assert.Contains(t, outputBuffer.String(), "Usage: doctor [create-indexes]")
+}
+
+func TestDoctorProblems(t *testing.T) {
+	mockDB := newMockStorage(t)
+	mockDB.pingErr = errors.New("connection refused")
+	cfg := app.DefaultConfig()
+	cfg.Server.Port = 0
+
+	var outputBuffer bytes.Buffer
+	cli := New(mockDB, cfg)
+	cli.SetIO(nil, &outputBuffer)
+
+	cli.handleCommand("output json")
+	outputBuffer.Reset()
+	cli.handleCommand("doctor")
+	assert.True(t, cli.failed)
+	assert.Contains(t, outputBuffer.String(), "invalid configuration")
+	assert.Contains(t, outputBuffer.String(), "not ready: database: connection refused")
+}
+
+func TestRemoteDoctor(t *testing.T) {
+	cli, _, output := newRemoteCLI(t)
+
+	cli.handleCommand("doctor")
+	assert.False(t, cli.failed)
+	assert.Contains(t, output.String(), "ready")
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+func (c *CLI) exportGame(gameIDOrName, file string) {
+	g, err := c.getGameByIDOrName(gameIDOrName)
+	if err != nil {
+		c.errorf("Error getting game: %v\n", err)
+		return
+	}
+
+	export, err := c.api.ExportGame(g.ID)
+	if err != nil {
+		c.errorf("Error exporting game: %v\n", err)
+		return
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		c.errorf("Error encoding game: %v\n", err)
+		return
+	}
+
+	if file == "" {
+		c.emit(export, func() {
+			fmt.Fprintln(c.output, string(data))
+		})
+		return
+	}
+
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		c.errorf("Error writing %s: %v\n", file, err)
+		return
+	}
+
+	result := map[string]any{"game_id": g.ID, "moves": len(export.Moves), "file": file}
+	c.emit(result, func() {
+		fmt.Fprintf(c.output, "Exported game %s with %d moves to %s\n", g.ID, len(export.Moves), file)
+	})
+}
+
+func (c *CLI) importGame(file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		c.errorf("Error reading %s: %v\n", file, err)
+		return
+	}
+
+	export, err := game.ParseExport(data)
+	if err != nil {
+		c.errorf("Error importing game: %v\n", err)
+		return
+	}
+
+	g, err := c.api.ImportGame(export)
+	if err != nil {
+		c.errorf("Error importing game: %v\n", err)
+		return
+	}
+
+	c.currentGameID = g.ID
+	c.emit(newGameResult(g), func() {
+		fmt.Fprintf(c.output, "Imported game with ID: %s (%d moves replayed, status %s)\n", g.ID, len(g.History), g.Status)
+		fmt.Fprintf(c.output, "Current game set to: %s\n", g.ID)
+	})
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportImportCommands exports a game to a file and imports it again
+func TestExportImportCommands(t *testing.T) {
+	mockDB := newMockStorage(t)
+	cfg := &app.Config{
+		Database: app.DatabaseConfig{
+			Name: "test",
+		},
+	}
+
+	var outputBuffer bytes.Buffer
+	cli := New(mockDB, cfg)
+	cli.SetIO(nil, &outputBuffer)
+
+	g := game.NewGame(&game.Player{Name: "player1"}, "original")
+	g.ID = "game123"
+	require.NoError(t, g.Join(&game.Player{Name: "player2"}))
+	require.NoError(t, g.PlaceShip("player1", game.Submarine, 2, 3, game.OrientationVertical))
+	mockDB.games["game123"] = g
+
+	file := filepath.Join(t.TempDir(), "game.json")
+	cli.handleCommand("export-game game123 " + file)
+	assert.Contains(t, outputBuffer.String(), "Exported game game123 with 0 moves to "+file)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"format": "battleship-game"`)
+
+	outputBuffer.Reset()
+	cli.handleCommand("import-game " + file)
+	assert.Contains(t, outputBuffer.String(), "Imported game with ID: mock-game-id")
+	assert.Equal(t, "mock-game-id", cli.currentGameID)
+
+	imported := mockDB.games["mock-game-id"]
+	require.NotNil(t, imported)
+	assert.Equal(t, "original", imported.Name)
+	assert.Equal(t, "player2", imported.Player2.Name)
+	assert.Equal(t, g.Boards["player1"].Maps, imported.Boards["player1"].Maps)
+
+	outputBuffer.Reset()
+	cli.handleCommand("import-game " + filepath.Join(t.TempDir(), "missing.json"))
+	assert.Contains(t, outputBuffer.String(), "Error reading")
+}
@@ -0,0 +1,307 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// hotSeat plays a game with two players sharing one terminal. Each player only sees their own fleet and
+// shot map; the screen is cleared and the terminal passed on between turns. The game is saved after every
+// action, so it can be continued later.
+func (c *CLI) hotSeat(args []string) {
+	if c.structured() {
+		c.errorln("Error: hot-seat needs the text output format")
+		return
+	}
+
+	var (
+		g   *game.Game
+		err error
+	)
+	switch len(args) {
+	case 0:
+		if c.currentGameID == "" {
+			c.errorln("Usage: hot-seat <player1> <player2> [name] or hot-seat <game-id|name>")
+			return
+		}
+		g, err = c.api.GetGame(c.currentGameID)
+	case 1:
+		g, err = c.getGameByIDOrName(args[0])
+	default:
+		g, err = c.newHotSeatGame(args)
+	}
+	if err != nil {
+		c.errorf("Error getting game: %v\n", err)
+		return
+	}
+
+	if len(g.Boards) < 2 {
+		c.errorf("Error: game %s is still waiting for a second player\n", g.ID)
+		return
+	}
+
+	c.currentGameID = g.ID
+	c.playHotSeat(g)
+}
+
+func (c *CLI) newHotSeatGame(args []string) (*game.Game, error) {
+	for _, playerName := range args[:2] {
+		if _, err := c.api.NewPlayer(playerName); err != nil {
+			return nil, err
+		}
+	}
+
+	var gameName string
+	if len(args) > 2 {
+		gameName = args[2]
+	}
+	return c.api.NewGameBetween(args[0], args[1], gameName)
+}
+
+func (c *CLI) playHotSeat(g *game.Game) {
+	for {
+		switch g.Status {
+		case game.StatusSetup:
+			player := hotSeatPlayerToPlace(g)
+			if player == "" {
+				if err := g.Start(g.Player1.Name); err != nil {
+					c.errorf("Error starting game: %v\n", err)
+					return
+				}
+				if !c.saveHotSeat(g) {
+					return
+				}
+				continue
+			}
+
+			if !c.passTo(g, player) || !c.hotSeatSetup(g, player) {
+				return
+			}
+
+		case game.StatusPlaying:
+			player := g.PlayerToMove
+			if !c.passTo(g, player) || !c.hotSeatTurn(g, player) {
+				return
+			}
+
+		default:
+			c.hotSeatGameOver(g)
+			return
+		}
+	}
+}
+
+// hotSeatPlayerToPlace returns the first player whose fleet is incomplete
+func hotSeatPlayerToPlace(g *game.Game) string {
+	for _, player := range []string{g.Player1.Name, g.Player2.Name} {
+		if g.Boards[player].PinsAvailable > 0 {
+			return player
+		}
+	}
+	return ""
+}
+
+// passTo clears the screen and waits until the player has taken the terminal. It reports false when the
+// players stopped the game.
+func (c *CLI) passTo(g *game.Game, player string) bool {
+	fmt.Fprint(c.output, clearScreen)
+	fmt.Fprintf(c.output, "Pass the terminal to %s and press Enter (quit to stop)\n", player)
+	return c.hotSeatInput(g) != "quit"
+}
+
+// hide waits until the player is done and clears the screen before the terminal is passed on
+func (c *CLI) hide(g *game.Game, message string) bool {
+	fmt.Fprintln(c.output, message)
+	input := c.hotSeatInput(g)
+	fmt.Fprint(c.output, clearScreen)
+	return input != "quit"
+}
+
+// hotSeatInput reads the next line. At the end of the input it returns quit.
+func (c *CLI) hotSeatInput(g *game.Game) string {
+	input, err := c.readLine()
+	if err != nil || input == "quit" {
+		fmt.Fprintf(c.output, "Hot-seat game stopped, continue it with: hot-seat %s\n", g.ID)
+		return "quit"
+	}
+	return input
+}
+
+func (c *CLI) hotSeatSetup(g *game.Game, player string) bool {
+	board := g.Boards[player]
+	for board.PinsAvailable > 0 {
+		fmt.Fprintf(c.output, "=== %s: place your fleet ===\n", player)
+		board.ShipsMap().WriteGrid(c.output)
+		fmt.Fprintf(c.output, "\nShips to place: %s\n", formatShipsToPlace(board.ShipsToPlace()))
+		fmt.Fprintln(c.output, "Commands: <ship-type> <coordinate> <h|v>, remove <coordinate>, quit")
+		fmt.Fprintf(c.output, "%s> ", player)
+
+		input := c.hotSeatInput(g)
+		if input == "quit" {
+			return false
+		}
+
+		if err := hotSeatPlacement(g, player, strings.Fields(input)); err != nil {
+			fmt.Fprint(c.output, clearScreen)
+			fmt.Fprintf(c.output, "%v\n\n", err)
+			continue
+		}
+		if !c.saveHotSeat(g) {
+			return false
+		}
+		fmt.Fprint(c.output, clearScreen)
+	}
+
+	fmt.Fprintf(c.output, "=== %s: fleet complete ===\n", player)
+	board.ShipsMap().WriteGrid(c.output)
+	return c.hide(g, "\nPress Enter to hide your fleet")
+}
+
+// hotSeatPlacement places or removes a ship, e.g. "Cruiser B2 v" or "remove B2"
+func hotSeatPlacement(g *game.Game, player string, fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("enter a ship, e.g. Cruiser B2 v")
+	}
+
+	if strings.EqualFold(fields[0], "remove") {
+		x, y, _, err := parseCoordinateArgs(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid coordinate: %v", err)
+		}
+		return g.RemoveShip(player, x, y)
+	}
+
+	shipType, ok := parseShipType(fields[0])
+	if !ok {
+		return fmt.Errorf("unknown ship type %q", fields[0])
+	}
+
+	x, y, used, err := parseCoordinateArgs(fields[1:])
+	if err != nil {
+		return fmt.Errorf("invalid coordinate: %v", err)
+	}
+	if len(fields) < 2+used {
+		return errors.New("missing orientation, use h or v")
+	}
+
+	orientation, ok := parseOrientation(fields[1+used])
+	if !ok {
+		return fmt.Errorf("invalid orientation %q, use h or v", fields[1+used])
+	}
+
+	return g.PlaceShip(player, shipType, x, y, orientation)
+}
+
+func (c *CLI) hotSeatTurn(g *game.Game, player string) bool {
+	board := g.Boards[player]
+	for {
+		fmt.Fprintf(c.output, "=== %s's turn ===\n", player)
+		if n := len(g.History); n > 0 && g.History[n-1].Player != player {
+			last := g.History[n-1]
+			fmt.Fprintf(c.output, "%s fired at %s - %s\n", last.Player, game.FormatCoordinate(last.X, last.Y), hitOrMiss(last.Hit))
+		}
+		fmt.Fprintln(c.output, "\nYour fleet:")
+		board.ShipsMap().WriteGrid(c.output)
+		fmt.Fprintln(c.output, "\nYour shots:")
+		board.ShotsMap().WriteGrid(c.output)
+		fmt.Fprintf(c.output, "\n%s, fire at (e.g. B7, quit to stop)> ", player)
+
+		input := c.hotSeatInput(g)
+		if input == "quit" {
+			return false
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(input, "fire "))
+		x, y, used, err := parseCoordinateArgs(fields)
+		if err == nil && used < len(fields) {
+			err = fmt.Errorf("unexpected %q after the coordinate", strings.Join(fields[used:], " "))
+		}
+		if err == nil {
+			err = g.MakeMove(game.Move{Player: player, X: x, Y: y})
+		}
+		if err != nil {
+			fmt.Fprint(c.output, clearScreen)
+			fmt.Fprintf(c.output, "Invalid shot: %v\n\n", err)
+			continue
+		}
+		if !c.saveHotSeat(g) {
+			return false
+		}
+
+		fmt.Fprintf(c.output, "%s - %s!\n", game.FormatCoordinate(x, y), hitOrMiss(g.History[len(g.History)-1].Hit))
+		if g.Finished() {
+			return true
+		}
+		return c.hide(g, fmt.Sprintf("Press Enter to hide your boards and pass to %s", g.PlayerToMove))
+	}
+}
+
+func (c *CLI) hotSeatGameOver(g *game.Game) {
+	fmt.Fprint(c.output, clearScreen)
+
+	winner := g.Winner()
+	shots := 0
+	for _, move := range g.History {
+		if move.Player == winner {
+			shots++
+		}
+	}
+	fmt.Fprintf(c.output, "Game over! %s won with %d shots\n", winner, shots)
+
+	for _, player := range []string{g.Player1.Name, g.Player2.Name} {
+		fmt.Fprintf(c.output, "\n=== %s's fleet ===\n", player)
+		g.Boards[player].ShipsMap().WriteGrid(c.output)
+	}
+}
+
+func (c *CLI) saveHotSeat(g *game.Game) bool {
+	if _, err := c.api.UpdateGame(g); err != nil {
+		c.errorf("Error updating game: %v\n", err)
+		return false
+	}
+	return true
+}
+
+func hitOrMiss(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
+
+func parseShipType(s string) (game.ShipType, bool) {
+	for _, shipType := range game.ShipTypes {
+		if strings.EqualFold(s, string(shipType)) {
+			return shipType, true
+		}
+	}
+	return game.InvalidShip, false
+}
+
+func parseOrientation(s string) (game.ShipOrientation, bool) {
+	switch strings.ToLower(s) {
+	case "h", "horizontal":
+		return game.OrientationHorizontal, true
+	case "v", "vertical":
+		return game.OrientationVertical, true
+	default:
+		return "", false
+	}
+}
+
+// formatShipsToPlace lists the remaining ships like "Battleship x1, Cruiser x2"
+func formatShipsToPlace(toPlace map[game.ShipType]int) string {
+	var ships []string
+	for _, shipType := range game.ShipTypes {
+		if n := toPlace[shipType]; n > 0 {
+			ships = append(ships, fmt.Sprintf("%s x%d", shipType, n))
+		}
+	}
+	return strings.Join(ships, ", ")
+}
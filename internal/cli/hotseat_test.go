@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// hotSeatFleet is the fleet of remoteFleet mirrored at the diagonal, so both fleets look different
+var hotSeatFleet = []string{
+	"battleship A1 v",
+	"cruiser C1 v",
+	"cruiser C6 v",
+	"destroyer E1 v",
+	"destroyer E5 v",
+	"destroyer G1 v",
+	"submarine G5 v",
+	"submarine G9 v",
+	"submarine I1 v",
+	"submarine I4 v",
+}
+
+func TestHotSeat(t *testing.T) {
+	cli, mockDB, output := newScriptCLI(t)
+
+	lines := []string{""}
+	lines = append(lines, remoteFleet...)
+	lines = append(lines, "", "")
+	lines = append(lines, "cruiser Z9 v")
+	lines = append(lines, hotSeatFleet...)
+	lines = append(lines, "", "")
+	lines = append(lines, "A1", "", "")
+	lines = append(lines, "A1 again", "J10", "")
+	cli.SetIO(strings.NewReader(strings.Join(lines, "\n")+"\n"), output)
+
+	require.NoError(t, cli.Exec("hot-seat alice bob"))
+
+	g := mockDB.games["mock-game-id"]
+	require.NotNil(t, g)
+	assert.Equal(t, game.StatusPlaying, g.Status)
+	require.Len(t, g.History, 2)
+	assert.Equal(t, game.Move{Player: "alice", X: 0, Y: 0, Hit: true}, g.History[0])
+	assert.Equal(t, game.Move{Player: "bob", X: 9, Y: 9, Hit: false}, g.History[1])
+	assert.Equal(t, "alice", g.PlayerToMove)
+
+	screens := strings.Split(output.String(), clearScreen)
+	assert.Contains(t, output.String(), "Pass the terminal to bob and press Enter")
+	assert.Contains(t, output.String(), "invalid coordinate")
+	assert.Contains(t, output.String(), "Hot-seat game stopped, continue it with: hot-seat mock-game-id")
+
+	// a screen never shows the fleet of the other player
+	for _, screen := range screens {
+		if strings.Contains(screen, "=== bob") {
+			assert.NotContains(t, screen, " 1 O O O O O", screen)
+		}
+		if strings.Contains(screen, "=== alice") {
+			assert.NotContains(t, screen, " 1 O   O   O   O   O", screen)
+		}
+	}
+
+	bobsTurn := screenWith(t, screens, "=== bob's turn ===")
+	assert.Contains(t, bobsTurn, "alice fired at A1 - hit")
+	assert.Contains(t, bobsTurn, " 1 X   O   O   O   O")
+}
+
+func TestHotSeatResume(t *testing.T) {
+	cli, _, output := newScriptCLI(t)
+	cli.SetIO(strings.NewReader("quit\n"), output)
+	require.NoError(t, cli.Exec("hot-seat alice bob"))
+
+	output.Reset()
+	cli.SetIO(strings.NewReader("\nquit\n"), output)
+	require.NoError(t, cli.Exec("hot-seat"))
+	assert.Contains(t, output.String(), "=== alice: place your fleet ===")
+	assert.Contains(t, output.String(), "Ships to place: Battleship x1, Cruiser x2, Destroyer x3, Submarine x4")
+}
+
+func TestHotSeatNeedsTwoPlayers(t *testing.T) {
+	cli, _, output := newScriptCLI(t)
+	require.NoError(t, cli.Exec("create-game alice"))
+
+	assert.ErrorIs(t, cli.Exec("hot-seat"), ErrCommandFailed)
+	assert.Contains(t, output.String(), "is still waiting for a second player")
+}
+
+func screenWith(t *testing.T, screens []string, text string) string {
+	for _, screen := range screens {
+		if strings.Contains(screen, text) {
+			return screen
+		}
+	}
+	t.Fatalf("no screen shows %q", text)
+	return ""
+}
@@ -1,27 +1,46 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+	"github.com/Jagreen1970/battleship/internal/storage"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
 	"github.com/stretchr/testify/require"
 )
 
-// mockStorage implements the storage.Storage interface for testing
+// mockStorage implements the storage.Storage interface for testing. The match tickets and chat messages are
+// kept by the embedded gametest.Database.
 type mockStorage struct {
+	*gametest.Database
+
 	t              *testing.T
 	players        map[string]*game.Player
 	games          map[string]*game.Game
+	tournaments    map[string]*tournament.Tournament
+	webhooksMu     sync.Mutex
+	webhooks       map[string]*webhook.Subscription
+	deliveries     []*webhook.Delivery
 	mockCreateGame func(*game.Game) (*game.Game, error)
+	pingErr        error
+	indexesCreated bool
 }
 
 // newMockStorage creates a new mock storage for testing
 func newMockStorage(t *testing.T) *mockStorage {
 	return &mockStorage{
-		t:       t,
-		players: make(map[string]*game.Player),
-		games:   make(map[string]*game.Game),
+		Database:    gametest.NewDatabase(),
+		t:           t,
+		players:     make(map[string]*game.Player),
+		games:       make(map[string]*game.Game),
+		tournaments: make(map[string]*tournament.Tournament),
+		webhooks:    make(map[string]*webhook.Subscription),
 		mockCreateGame: func(g *game.Game) (*game.Game, error) {
 			g.ID = "mock-game-id"
 			return g, nil
@@ -41,7 +60,7 @@ func (m *mockStorage) Disconnect() error {
 
 // Ping implements the storage.Storage interface
 func (m *mockStorage) Ping() error {
-	return nil
+	return m.pingErr
 }
 
 // Close implements the storage.Storage interface
@@ -49,6 +68,20 @@ func (m *mockStorage) Close() error {
 	return nil
 }
 
+// Indexes implements the storage.Storage interface
+func (m *mockStorage) Indexes() ([]storage.IndexStatus, error) {
+	return []storage.IndexStatus{
+		{Collection: "players", Keys: []string{"player.name"}, Present: m.indexesCreated},
+		{Collection: "lobby", Keys: []string{"ticket.player"}, Unique: true, Present: m.indexesCreated},
+	}, nil
+}
+
+// CreateIndexes implements the storage.Storage interface
+func (m *mockStorage) CreateIndexes() error {
+	m.indexesCreated = true
+	return nil
+}
+
 // CreatePlayer implements the storage.Storage interface
 func (m *mockStorage) CreatePlayer(playerName string) (*game.Player, error) {
 	player := &game.Player{
@@ -72,13 +105,16 @@ func (m *mockStorage) FindPlayerByName(username string) (*game.Player, error) {
 func (m *mockStorage) QueryGames(page int, count int) ([]*game.Game, error) {
 	var games []*game.Game
 	for _, g := range m.games {
+		if g.Visibility == game.VisibilityPrivate {
+			continue
+		}
 		games = append(games, g)
 	}
-	
+
 	// Apply pagination
 	start := page * count
 	end := start + count
-	
+
 	// Handle edge cases
 	if start >= len(games) {
 		return []*game.Game{}, nil
@@ -86,7 +122,7 @@ func (m *mockStorage) QueryGames(page int, count int) ([]*game.Game, error) {
 	if end > len(games) {
 		end = len(games)
 	}
-	
+
 	return games[start:end], nil
 }
 
@@ -95,7 +131,7 @@ func (m *mockStorage) CreateGame(g *game.Game) (*game.Game, error) {
 	if m.mockCreateGame != nil {
 		game, err := m.mockCreateGame(g)
 		if err == nil && game != nil && game.ID != "" {
-			m.games[game.ID] = game  // Make sure to store the game in the map
+			m.games[game.ID] = game // Make sure to store the game in the map
 		}
 		return game, err
 	}
@@ -118,16 +154,27 @@ func (m *mockStorage) FindGameByName(name string) (*game.Game, error) {
 	if name == "" {
 		return nil, fmt.Errorf("game name cannot be empty: %w", game.ErrorInvalidInput)
 	}
-	
+
 	for _, g := range m.games {
 		if g.Name == name {
 			return g, nil
 		}
 	}
-	
+
 	return nil, fmt.Errorf("game with name '%s' not found: %w", name, game.ErrorNotFound)
 }
 
+// FindGameByInviteCode implements the storage.Storage interface
+func (m *mockStorage) FindGameByInviteCode(code string) (*game.Game, error) {
+	for _, g := range m.games {
+		if g.InviteCode != "" && g.InviteCode == code {
+			return g, nil
+		}
+	}
+
+	return nil, fmt.Errorf("game with invite code '%s' not found: %w", code, game.ErrorNotFound)
+}
+
 // UpdateGame implements the storage.Storage interface
 func (m *mockStorage) UpdateGame(g *game.Game) (*game.Game, error) {
 	require.NotEmpty(m.t, g.ID, "Game ID should not be empty in UpdateGame")
@@ -135,13 +182,23 @@ func (m *mockStorage) UpdateGame(g *game.Game) (*game.Game, error) {
 	return g, nil
 }
 
+// ClaimRematch implements the storage.Storage interface
+func (m *mockStorage) ClaimRematch(gameID string, rematchID string) error {
+	g, ok := m.games[gameID]
+	if !ok || g.RematchID != "" {
+		return game.ErrorNotFound
+	}
+	g.RematchID = rematchID
+	return nil
+}
+
 // DeleteGame implements the storage.Storage interface
 func (m *mockStorage) DeleteGame(id string) error {
 	_, exists := m.games[id]
 	if !exists {
 		return game.ErrorNotFound
 	}
-	
+
 	delete(m.games, id)
 	return nil
 }
@@ -151,4 +208,161 @@ func (m *mockStorage) DeleteAllGames() (int, error) {
 	count := len(m.games)
 	m.games = make(map[string]*game.Game)
 	return count, nil
-}
\ No newline at end of file
+}
+
+// CountOpenGames implements the storage.Storage interface
+func (m *mockStorage) CountOpenGames(playerName string) (int, error) {
+	count := 0
+	for _, g := range m.games {
+		open := g.Status == game.StatusSetup || g.Status == game.StatusPlaying
+		if open && ((g.Player1 != nil && g.Player1.Name == playerName) || (g.Player2 != nil && g.Player2.Name == playerName)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountGamesByStatus implements the storage.Storage interface
+func (m *mockStorage) CountGamesByStatus() (map[game.Status]int, error) {
+	counts := make(map[game.Status]int)
+	for _, g := range m.games {
+		counts[g.Status]++
+	}
+	return counts, nil
+}
+
+// CreateTournament implements the storage.Storage interface
+func (m *mockStorage) CreateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	t.ID = fmt.Sprintf("tournament%d", len(m.tournaments)+1)
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+// FindTournamentByID implements the storage.Storage interface
+func (m *mockStorage) FindTournamentByID(id string) (*tournament.Tournament, error) {
+	t, ok := m.tournaments[id]
+	if !ok {
+		return nil, fmt.Errorf("tournament not found: %w", game.ErrorNotFound)
+	}
+	return copyTournament(t)
+}
+
+// QueryTournaments implements the storage.Storage interface
+func (m *mockStorage) QueryTournaments(page int, count int) ([]*tournament.Tournament, error) {
+	var tournaments []*tournament.Tournament
+	for _, t := range m.tournaments {
+		tournaments = append(tournaments, t)
+	}
+
+	start := page * count
+	if start >= len(tournaments) {
+		return []*tournament.Tournament{}, nil
+	}
+	end := min(start+count, len(tournaments))
+	return tournaments[start:end], nil
+}
+
+// UpdateTournament implements the storage.Storage interface
+func (m *mockStorage) UpdateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	if _, ok := m.tournaments[t.ID]; !ok {
+		return nil, game.ErrorNotFound
+	}
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+// UpdateTournamentRound implements the storage.Storage interface
+func (m *mockStorage) UpdateTournamentRound(t *tournament.Tournament, rounds int) (*tournament.Tournament, error) {
+	stored, ok := m.tournaments[t.ID]
+	if !ok || len(stored.Rounds) != rounds {
+		return nil, game.ErrorNotFound
+	}
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+// copyTournament returns a deep copy, so that changes to a tournament that was read are only stored by
+// an update, like with a real database
+func copyTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var copied tournament.Tournament
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// CreateWebhook implements the storage.Storage interface
+func (m *mockStorage) CreateWebhook(s *webhook.Subscription) (*webhook.Subscription, error) {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	s.ID = fmt.Sprintf("webhook%d", len(m.webhooks)+1)
+	m.webhooks[s.ID] = s
+	return s, nil
+}
+
+// FindWebhookByID implements the storage.Storage interface
+func (m *mockStorage) FindWebhookByID(id string) (*webhook.Subscription, error) {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	s, ok := m.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook not found: %w", game.ErrorNotFound)
+	}
+	return s, nil
+}
+
+// QueryWebhooks implements the storage.Storage interface
+func (m *mockStorage) QueryWebhooks(owners ...string) ([]*webhook.Subscription, error) {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	var subscriptions []*webhook.Subscription
+	for _, s := range m.webhooks {
+		if slices.Contains(owners, s.Owner) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebhook implements the storage.Storage interface
+func (m *mockStorage) DeleteWebhook(id string) error {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	if _, ok := m.webhooks[id]; !ok {
+		return game.ErrorNotFound
+	}
+	delete(m.webhooks, id)
+	return nil
+}
+
+// CreateWebhookDelivery implements the storage.Storage interface
+func (m *mockStorage) CreateWebhookDelivery(d *webhook.Delivery) (*webhook.Delivery, error) {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	d.ID = fmt.Sprintf("delivery%d", len(m.deliveries)+1)
+	m.deliveries = append(m.deliveries, d)
+	return d, nil
+}
+
+// QueryWebhookDeliveries implements the storage.Storage interface
+func (m *mockStorage) QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*webhook.Delivery, error) {
+	m.webhooksMu.Lock()
+	defer m.webhooksMu.Unlock()
+	var deliveries []*webhook.Delivery
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		if m.deliveries[i].SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, m.deliveries[i])
+		}
+	}
+
+	start := page * count
+	if start >= len(deliveries) {
+		return []*webhook.Delivery{}, nil
+	}
+	end := min(start+count, len(deliveries))
+	return deliveries[start:end], nil
+}
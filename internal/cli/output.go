@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+// Format selects how command results are written
+type Format string
+
+const (
+	// FormatText writes tables and messages for humans
+	FormatText Format = "text"
+	// FormatJSON writes one JSON document per command and line
+	FormatJSON Format = "json"
+	// FormatYAML writes one YAML document per command
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat validates an output format name. An empty name selects text.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, use text, json or yaml", name)
+	}
+}
+
+// SetFormat selects the output format of all following commands
+func (c *CLI) SetFormat(format Format) {
+	c.format = format
+}
+
+// setFormat switches the output format within a session
+func (c *CLI) setFormat(name string) {
+	format, err := ParseFormat(name)
+	if err != nil {
+		c.errorf("Error: %v\n", err)
+		return
+	}
+
+	c.format = format
+	c.emit(map[string]Format{"format": format}, func() {
+		fmt.Fprintf(c.output, "Output format set to %s\n", format)
+	})
+}
+
+func (c *CLI) structured() bool {
+	return c.format == FormatJSON || c.format == FormatYAML
+}
+
+// commandResult is the document written for every command in the json and yaml formats
+type commandResult struct {
+	Command string `json:"command"`
+	OK      bool   `json:"ok"`
+	Result  any    `json:"result,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// emit reports the result of a successful command. The text function prints it for humans, the structured
+// formats encode the result value instead.
+func (c *CLI) emit(result any, text func()) {
+	if !c.structured() {
+		text()
+		return
+	}
+	c.result = result
+}
+
+// flush writes the document of a command in the structured formats
+func (c *CLI) flush(command string) {
+	if !c.structured() {
+		return
+	}
+
+	doc := commandResult{
+		Command: command,
+		OK:      !c.failed,
+		Result:  c.result,
+	}
+	if c.failed {
+		doc.Result = nil
+		doc.Error = strings.Join(c.errors, "\n")
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		fmt.Fprintf(c.output, "{\"command\":%q,\"ok\":false,\"error\":%q}\n", command, err.Error())
+		return
+	}
+
+	if c.format == FormatJSON {
+		fmt.Fprintln(c.output, string(data))
+		return
+	}
+
+	// YAML is written from the JSON document, so both formats use the same field names
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		fmt.Fprintf(c.output, "---\ncommand: %q\nok: false\nerror: %q\n", command, err.Error())
+		return
+	}
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		fmt.Fprintf(c.output, "---\ncommand: %q\nok: false\nerror: %q\n", command, err.Error())
+		return
+	}
+	fmt.Fprintf(c.output, "---\n%s", out)
+}
+
+// boardResult is a board with one string per row, e.g. "OOOOO     "
+type boardResult struct {
+	Ships []string `json:"ships"`
+	Shots []string `json:"shots"`
+	// ShipsAfloat is the number of ships which are not sunk yet
+	ShipsAfloat int `json:"ships_afloat"`
+}
+
+// gameResult is the structured form of show-game
+type gameResult struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name,omitempty"`
+	Status       string                 `json:"status"`
+	Visibility   game.Visibility        `json:"visibility"`
+	InviteCode   string                 `json:"invite_code,omitempty"`
+	Rules        game.RulePreset        `json:"rules"`
+	Player1      string                 `json:"player_1"`
+	Player2      string                 `json:"player_2"`
+	PlayerToMove string                 `json:"player_to_move,omitempty"`
+	Series       *game.Series           `json:"series,omitempty"`
+	RematchOf    string                 `json:"rematch_of,omitempty"`
+	RematchID    string                 `json:"rematch_id,omitempty"`
+	Boards       map[string]boardResult `json:"boards"`
+	History      []game.Move            `json:"history"`
+}
+
+func newGameResult(g *game.Game) gameResult {
+	r := gameResult{
+		ID:           g.ID,
+		Name:         g.Name,
+		Status:       g.Status.String(),
+		Visibility:   g.Visibility,
+		InviteCode:   g.InviteCode,
+		Rules:        g.Rules,
+		Player1:      g.Player1.Name,
+		Player2:      g.Player2.Name,
+		PlayerToMove: g.PlayerToMove,
+		RematchOf:    g.RematchOf,
+		RematchID:    g.RematchID,
+		Boards:       make(map[string]boardResult, len(g.Boards)),
+		History:      g.History,
+	}
+
+	if series := g.SeriesScore(); series.Games > 0 {
+		r.Series = &series
+	}
+
+	for playerName, board := range g.Boards {
+		r.Boards[playerName] = boardResult{
+			Ships:       mapRows(board.ShipsMap()),
+			Shots:       mapRows(board.ShotsMap()),
+			ShipsAfloat: len(board.Fleet),
+		}
+	}
+
+	return r
+}
+
+func mapRows(m *game.BoardMap) []string {
+	rows := make([]string, len(m.Map))
+	for y, row := range m.Map {
+		fields := make([]byte, len(row))
+		for x, field := range row {
+			fields[x] = byte(field)
+		}
+		rows[y] = string(fields)
+	}
+	return rows
+}
+
+// summaryResult is a game in the structured form of show-games
+type summaryResult struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Status  string `json:"status"`
+	Player1 string `json:"player_1,omitempty"`
+	Player2 string `json:"player_2,omitempty"`
+	Moves   int    `json:"moves"`
+}
+
+func newSummaryResult(s game.Summary) summaryResult {
+	r := summaryResult{ID: s.ID, Name: s.Name, Status: s.Status.String(), Moves: s.Moves}
+	if s.Player1 != nil {
+		r.Player1 = s.Player1.Name
+	}
+	if s.Player2 != nil {
+		r.Player2 = s.Player2.Name
+	}
+	return r
+}
+
+// pageResult is one page of a paginated listing
+type pageResult[T any] struct {
+	Page  int `json:"page"`
+	Count int `json:"count"`
+	Items []T `json:"items"`
+}
+
+func newPageResult[T any](page, count int, items []T) pageResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return pageResult[T]{Page: page, Count: count, Items: items}
+}
+
+// placementResult is the structured form of place-ship
+type placementResult struct {
+	GameID       string               `json:"game_id"`
+	Player       string               `json:"player"`
+	Ship         game.ShipType        `json:"ship"`
+	Coordinate   string               `json:"coordinate"`
+	X            int                  `json:"x"`
+	Y            int                  `json:"y"`
+	Orientation  game.ShipOrientation `json:"orientation"`
+	Started      bool                 `json:"started"`
+	PlayerToMove string               `json:"player_to_move,omitempty"`
+}
+
+// shotResult is the structured form of fire
+type shotResult struct {
+	GameID       string `json:"game_id"`
+	Player       string `json:"player"`
+	Coordinate   string `json:"coordinate"`
+	X            int    `json:"x"`
+	Y            int    `json:"y"`
+	Hit          bool   `json:"hit"`
+	Status       string `json:"status"`
+	PlayerToMove string `json:"player_to_move,omitempty"`
+	Winner       string `json:"winner,omitempty"`
+}
+
+// deleteResult is the structured form of delete-game
+type deleteResult struct {
+	Deleted   int    `json:"deleted"`
+	GameID    string `json:"game_id,omitempty"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	// CurrentGameUnset is set when the current game of the session was deleted
+	CurrentGameUnset bool `json:"current_game_unset,omitempty"`
+}
+
+// tournamentResult is a tournament with its standings
+type tournamentResult struct {
+	*tournament.Tournament
+	Standings []tournament.Standing `json:"standings"`
+	// Pending explains why advance-tournament could not advance yet
+	Pending string `json:"pending,omitempty"`
+}
+
+func newTournamentResult(t *tournament.Tournament) tournamentResult {
+	return tournamentResult{Tournament: t, Standings: t.Standings()}
+}
+
+// webhookResult is a webhook including its secret, which is only shown when it is added
+type webhookResult struct {
+	*webhook.Subscription
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": FormatText, "text": FormatText, "JSON": FormatJSON, "yaml": FormatYAML} {
+		got, err := ParseFormat(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestJSONOutput(t *testing.T) {
+	cli, _, output := newScriptCLI(t)
+	cli.SetFormat(FormatJSON)
+
+	script := `create-game player1 fixture
+join-game fixture player2
+place-ship player1 Battleship A1 Horizontal
+show-games
+show-game
+fire player1 A1
+`
+	err := cli.RunScript(strings.NewReader(script), "test.bs")
+	require.ErrorIs(t, err, ErrCommandFailed)
+	require.ErrorIs(t, cli.Exec("no-such-command"), ErrCommandFailed)
+
+	// every line is one document, nothing else is written
+	var docs []map[string]any
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc), scanner.Text())
+		docs = append(docs, doc)
+	}
+	require.Len(t, docs, 7)
+
+	created := docs[0]
+	assert.Equal(t, "create-game", created["command"])
+	assert.Equal(t, true, created["ok"])
+	assert.Equal(t, "mock-game-id", created["result"].(map[string]any)["id"])
+
+	placed := docs[2]["result"].(map[string]any)
+	assert.Equal(t, "A1", placed["coordinate"])
+	assert.Equal(t, false, placed["started"])
+
+	games := docs[3]["result"].(map[string]any)
+	items := games["items"].([]any)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Setup", items[0].(map[string]any)["status"])
+
+	g := docs[4]["result"].(map[string]any)
+	assert.Equal(t, "fixture", g["name"])
+	assert.Equal(t, "player2", g["player_2"])
+	ships := g["boards"].(map[string]any)["player1"].(map[string]any)["ships"].([]any)
+	require.Len(t, ships, 10)
+	assert.Equal(t, "OOOOO     ", ships[0])
+
+	fired := docs[5]
+	assert.Equal(t, false, fired["ok"])
+	assert.Contains(t, fired["error"], "Error making move")
+	assert.NotContains(t, fired, "result")
+
+	unknown := docs[6]
+	assert.Equal(t, "no-such-command", unknown["command"])
+	assert.Equal(t, "Unknown command: no-such-command", unknown["error"])
+}
+
+func TestYAMLOutput(t *testing.T) {
+	cli, _, output := newScriptCLI(t)
+	cli.SetFormat(FormatYAML)
+
+	require.NoError(t, cli.Exec("create-game player1 fixture"))
+	require.ErrorIs(t, cli.Exec("set-game unknown"), ErrCommandFailed)
+
+	decoder := yaml.NewDecoder(output)
+	var created, failed map[string]any
+	require.NoError(t, decoder.Decode(&created))
+	require.NoError(t, decoder.Decode(&failed))
+
+	assert.Equal(t, "create-game", created["command"])
+	assert.Equal(t, true, created["ok"])
+	assert.Equal(t, "mock-game-id", created["result"].(map[string]any)["id"])
+
+	assert.Equal(t, false, failed["ok"])
+	assert.Equal(t, "Error: Game unknown not found", failed["error"])
+}
+
+func TestOutputCommand(t *testing.T) {
+	cli, _, output := newScriptCLI(t)
+
+	require.NoError(t, cli.Exec("output json"))
+	require.NoError(t, cli.Exec("create-game player1"))
+	assert.Equal(t, FormatJSON, cli.format)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"command":"output","ok":true,"result":{"format":"json"}}`, lines[0])
+
+	assert.ErrorIs(t, cli.Exec("output xml"), ErrCommandFailed)
+	assert.Equal(t, FormatJSON, cli.format)
+}
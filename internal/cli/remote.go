@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Jagreen1970/battleship/internal/client"
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// remoteCommands are the commands available when the CLI is connected to a server, with their help
+var remoteCommands = []struct {
+	name string
+	help string
+}{
+	{"login", "login <player>: Log in as a player, players are also logged in by their first command"},
+	{"logout", "logout <player>: Log out a player"},
+	{"create-game", "create-game <player> [name]: Create a new game with optional friendly name"},
+	{"create-private-game", "create-private-game <player> [name]: Create a game that can only be joined with its invite code"},
+	{"show-games", "show-games [page] [count]: List all active games (paginated)"},
+	{"show-game", "show-game <game-id|name>: Show the game as the last active player sees it"},
+	{"join-game", "join-game <game-id|name> <player>: Join an existing game as a player"},
+	{"join-code", "join-code <invite-code> <player>: Join a game with its invite code"},
+	{"cancel-game", "cancel-game <player> [game-id|name]: Cancel a game of the player nobody has joined yet"},
+	{"set-game", "set-game <game-id|name>: Set the game ID for the current session"},
+	{"place-ship", "place-ship <player> <ship-type> <coordinate> <orientation>: Place a ship with its top left end at a coordinate like B7"},
+	{"start-game", "start-game <player>: Start the game with the given player"},
+	{"fire", "fire <player> <coordinate>: Fire at a coordinate like B7"},
+	{"doctor", "doctor: Check whether the server is ready: its database answers and its background workers run"},
+	{"output", "output <text|json|yaml>: Select the output format of the following commands"},
+}
+
+// NewRemote returns a CLI which sends its commands to a running server through the REST API instead of
+// using a database, so the rules and sessions of the server apply. Commands act as the player they name;
+// show-game shows the game as the player of the last command sees it.
+func NewRemote(server *client.Client) *CLI {
+	return &CLI{
+		remote: server,
+		reader: bufio.NewReader(os.Stdin),
+		input:  os.Stdin,
+		output: os.Stdout,
+		logger: slog.Default(),
+		format: FormatText,
+	}
+}
+
+func (c *CLI) remoteCommand(cmd string) bool {
+	for _, command := range remoteCommands {
+		if command.name == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CLI) printRemoteHelp() {
+	fmt.Fprintf(c.output, "Battleship CLI Mode - connected to %s\n", c.remote.URL())
+	fmt.Fprintln(c.output, "Available commands:")
+	for _, command := range remoteCommands {
+		fmt.Fprintf(c.output, "  %s\n", command.help)
+	}
+	fmt.Fprintln(c.output, "  exit: Exit CLI mode")
+	fmt.Fprintln(c.output, "\nShip types: Battleship, Cruiser, Destroyer, Submarine")
+	fmt.Fprintln(c.output, "Orientation: Horizontal, Vertical")
+	fmt.Fprintln(c.output, "Coordinates: A1 to J10 (column letter, row number), or 0-based x and y")
+}
+
+func (c *CLI) login(playerName string) {
+	if c.remote == nil {
+		c.errorln("Error: login is only available when connected to a server with -server")
+		return
+	}
+
+	player, err := c.remote.Login(playerName)
+	if err != nil {
+		c.errorf("Error logging in: %v\n", err)
+		return
+	}
+
+	c.player = player.Name
+	c.emit(player, func() {
+		fmt.Fprintf(c.output, "Logged in as %s (score %d)\n", player.Name, player.Score)
+	})
+}
+
+func (c *CLI) logout(playerName string) {
+	if c.remote == nil {
+		c.errorln("Error: logout is only available when connected to a server with -server")
+		return
+	}
+
+	if err := c.remote.Logout(playerName); err != nil {
+		c.errorf("Error logging out: %v\n", err)
+		return
+	}
+
+	if c.player == playerName {
+		c.player = ""
+	}
+	c.emit(map[string]string{"player": playerName, "status": "logged out"}, func() {
+		fmt.Fprintf(c.output, "Logged out %s\n", playerName)
+	})
+}
+
+// remoteGame finds a game on the server by ID, or by name among the listed games
+func (c *CLI) remoteGame(idOrName string) (*game.Game, error) {
+	view, err := c.remote.Game(c.player, idOrName)
+	if err == nil {
+		return view.Game(), nil
+	}
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		return nil, err
+	}
+
+	for page := 0; ; page++ {
+		games, listErr := c.remote.Games(page, 100)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for _, g := range games {
+			if g.Name == idOrName {
+				view, err := c.remote.Game(c.player, g.ID)
+				if err != nil {
+					return nil, err
+				}
+				return view.Game(), nil
+			}
+		}
+		if len(games) < 100 {
+			return nil, err
+		}
+	}
+}
+
+func (c *CLI) remoteCreateGame(playerName, gameName string, visibility game.Visibility) (*game.Game, error) {
+	view, err := c.remote.CreateGame(playerName, gameName, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	c.player = playerName
+	return view.Game(), nil
+}
+
+func (c *CLI) remoteJoinGame(gameIDOrName, playerName string) (*game.Game, error) {
+	g, err := c.remoteGame(gameIDOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := c.remote.JoinGame(playerName, g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.player = playerName
+	return view.Game(), nil
+}
+
+func (c *CLI) remoteJoinGameByCode(code, playerName string) (*game.Game, error) {
+	view, err := c.remote.JoinGameByCode(playerName, code)
+	if err != nil {
+		return nil, err
+	}
+
+	c.player = playerName
+	return view.Game(), nil
+}
+
+func (c *CLI) remoteCancelGame(gameID, playerName string) error {
+	if err := c.remote.CancelGame(playerName, gameID); err != nil {
+		return err
+	}
+
+	c.player = playerName
+	return nil
+}
+
+func (c *CLI) remoteStartGame(gameID, playerName string) (*game.Game, error) {
+	view, err := c.remote.StartGame(playerName, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.player = playerName
+	return view.Game(), nil
+}
+
+// remotePlaceShip places a ship and, like the local CLI, starts the game once the fleet is complete
+func (c *CLI) remotePlaceShip(gameID, playerName string, shipType game.ShipType, x, y int, orientation game.ShipOrientation) (*game.Game, bool, error) {
+	view, err := c.remote.PlaceShip(playerName, gameID, shipType, x, y, orientation)
+	if err != nil {
+		return nil, false, err
+	}
+	c.player = playerName
+
+	if view.Status != game.StatusSetup || view.Board == nil || view.Board.PinsAvailable > 0 {
+		return view.Game(), false, nil
+	}
+
+	// the game can't start while the opponent is still placing ships
+	started, err := c.remote.StartGame(playerName, gameID)
+	if err != nil {
+		return view.Game(), false, nil
+	}
+	return started.Game(), true, nil
+}
+
+func (c *CLI) remoteFire(gameID, playerName string, x, y int) (*game.Game, error) {
+	view, err := c.remote.Fire(playerName, gameID, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	c.player = playerName
+	return view.Game(), nil
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/client"
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/server"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+var remoteFleet = []string{
+	"Battleship A1 Horizontal",
+	"Cruiser A3 Horizontal",
+	"Cruiser F3 Horizontal",
+	"Destroyer A5 Horizontal",
+	"Destroyer E5 Horizontal",
+	"Destroyer A7 Horizontal",
+	"Submarine E7 Horizontal",
+	"Submarine I7 Horizontal",
+	"Submarine A9 Horizontal",
+	"Submarine D9 Horizontal",
+}
+
+// newRemoteServer serves the REST API on the mock storage, with the given write timeout if not zero
+func newRemoteServer(t *testing.T, writeTimeout time.Duration) (*httptest.Server, *game.API, *mockStorage) {
+	gin.SetMode(gin.TestMode)
+
+	mockDB := newMockStorage(t)
+	api := game.NewApi(mockDB)
+	controller := endpoints.NewController(api, tournament.NewApi(mockDB, api), webhook.NewApi(mockDB, api))
+	router, err := server.NewRouter(app.ServerConfig{SessionSecret: "test", StrictResponses: true}, controller,
+		slog.New(slog.DiscardHandler), nil, nil)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config.WriteTimeout = writeTimeout
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, api, mockDB
+}
+
+func newRemoteCLI(t *testing.T) (*CLI, *mockStorage, *bytes.Buffer) {
+	srv, _, mockDB := newRemoteServer(t, 0)
+
+	remote, err := client.New(srv.URL)
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	cli := NewRemote(remote)
+	cli.SetIO(nil, &output)
+	return cli, mockDB, &output
+}
+
+func TestRemoteGame(t *testing.T) {
+	cli, mockDB, output := newRemoteCLI(t)
+
+	var script strings.Builder
+	script.WriteString("create-game alice fixture\njoin-game fixture bob\n")
+	for _, player := range []string{"alice", "bob"} {
+		for _, ship := range remoteFleet {
+			fmt.Fprintf(&script, "place-ship %s %s\n", player, ship)
+		}
+	}
+	script.WriteString("fire bob A1\nfire alice J10\n")
+
+	require.NoError(t, cli.RunScript(strings.NewReader(script.String()), "remote.bs"), output.String())
+
+	g := mockDB.games["mock-game-id"]
+	require.NotNil(t, g)
+	assert.Equal(t, game.StatusPlaying, g.Status)
+	assert.Equal(t, "bob", g.Player2.Name)
+	require.Len(t, g.History, 2)
+	assert.True(t, g.History[0].Hit)
+	assert.False(t, g.History[1].Hit)
+
+	assert.Contains(t, output.String(), "All ships placed. Game automatically started! Player to move: bob")
+	assert.Contains(t, output.String(), "Player bob fired at A1 - hit")
+	assert.Contains(t, output.String(), "Player alice fired at J10 - miss")
+}
+
+func TestRemoteShowGameHidesOpponentBoard(t *testing.T) {
+	cli, _, output := newRemoteCLI(t)
+	cli.SetFormat(FormatJSON)
+
+	require.NoError(t, cli.Exec("create-game alice fixture"))
+	require.NoError(t, cli.Exec("join-game fixture bob"))
+	require.NoError(t, cli.Exec("place-ship bob Battleship A1 Horizontal"))
+	output.Reset()
+
+	require.NoError(t, cli.Exec("show-game fixture"))
+	assert.Contains(t, output.String(), `"bob":{"ships":["OOOOO     "`)
+	assert.NotContains(t, output.String(), `"alice":{`)
+}
+
+func TestRemoteErrors(t *testing.T) {
+	cli, _, output := newRemoteCLI(t)
+
+	assert.ErrorIs(t, cli.Exec("delete-game all"), ErrCommandFailed)
+	assert.Contains(t, output.String(), "Error: delete-game is not available when connected to a server")
+
+	assert.ErrorIs(t, cli.Exec("show-game unknown"), ErrCommandFailed)
+	assert.Contains(t, output.String(), "Error getting game:")
+
+	require.NoError(t, cli.Exec("create-game alice"))
+	assert.ErrorIs(t, cli.Exec("join-game mock-game-id alice"), ErrCommandFailed)
+	assert.Contains(t, output.String(), "Error joining game: seems you already joined the game")
+}
+
+func TestRemoteCancelGame(t *testing.T) {
+	srv, api, mockDB := newRemoteServer(t, 0)
+	api.MaxOpenGames = 1
+	remote, err := client.New(srv.URL)
+	require.NoError(t, err)
+	var output bytes.Buffer
+	cli := NewRemote(remote)
+	cli.SetIO(nil, &output)
+
+	require.NoError(t, cli.Exec("create-game alice first"))
+	_, err = remote.CreateGame("alice", "second", game.VisibilityPublic)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode, "only finishing or cancelling a game makes room")
+
+	assert.ErrorIs(t, cli.Exec("cancel-game bob mock-game-id"), ErrCommandFailed)
+	require.NoError(t, cli.Exec("cancel-game alice"))
+	assert.Contains(t, output.String(), "Game mock-game-id cancelled")
+	assert.Empty(t, mockDB.games)
+
+	require.NoError(t, cli.Exec("create-game alice second"))
+}
+
+// remoteSession logs a player in and returns a client keeping the session cookie
+func remoteSession(t *testing.T, srv *httptest.Server, player string) *http.Client {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	session := &http.Client{Jar: jar}
+	resp, err := session.Post(srv.URL+"/api/login", "application/json",
+		strings.NewReader(fmt.Sprintf(`{"username": %q}`, player)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return session
+}
+
+func TestRemotePrivateGame(t *testing.T) {
+	srv, api, _ := newRemoteServer(t, 0)
+	alice := remoteSession(t, srv, "alice")
+	bob := remoteSession(t, srv, "bob")
+
+	g, err := api.NewPrivateGame("alice", "secret")
+	require.NoError(t, err)
+	_, err = api.SendChatMessage(g.ID, "alice", "hello")
+	require.NoError(t, err)
+
+	for _, path := range []string{"", "/export", "/chat", "/events"} {
+		for _, session := range []*http.Client{http.DefaultClient, bob} {
+			resp, err := session.Get(srv.URL + "/api/games/" + g.ID + path)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		}
+	}
+
+	// the players find the game, which can't be exported before it is finished
+	for path, status := range map[string]int{"": http.StatusOK, "/export": http.StatusForbidden} {
+		resp, err := alice.Get(srv.URL + "/api/games/" + g.ID + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+
+	resp, err := alice.Get(srv.URL + "/api/games/" + g.ID + "/chat")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"text":"hello"`)
+}
+
+func TestRemoteEventStreamOutlivesWriteTimeout(t *testing.T) {
+	srv, api, _ := newRemoteServer(t, 50*time.Millisecond)
+	_, err := api.NewPlayer("alice")
+	require.NoError(t, err)
+
+	g, err := api.NewGame("alice", "fixture")
+	require.NoError(t, err)
+
+	// the headers are only sent with the first event, which comes after the write timeout passed
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, err := api.SendChatMessage(g.ID, "alice", "still there?")
+		assert.NoError(t, err)
+	}()
+
+	resp, err := http.Get(srv.URL + "/api/games/" + g.ID + "/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event:chat.message\n", line)
+}
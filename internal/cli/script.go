@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrCommandFailed is returned when a command reported an error
+var ErrCommandFailed = errors.New("command failed")
+
+// ScriptError points to the line of a script that stopped it
+type ScriptError struct {
+	Script  string
+	Line    int
+	Command string
+	Err     error
+}
+
+func (e *ScriptError) Error() string {
+	if e.Command == "" {
+		return fmt.Sprintf("%s:%d: %v", e.Script, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %s: %v", e.Script, e.Line, e.Command, e.Err)
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+var assignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+// Exec runs a single command without the interactive prompt
+func (c *CLI) Exec(command string) error {
+	stopWebhooks := c.startWebhooks()
+	defer stopWebhooks()
+
+	return c.exec(command)
+}
+
+// RunScript runs the commands of a script line by line and stops at the first command that fails.
+//
+// Empty lines and lines starting with # are skipped. A line NAME=value sets a variable, which later lines
+// use as $NAME or ${NAME}. Variables not set by the script are looked up in the environment, and $GAME_ID
+// is the current game. $$ is a literal $. The script ends at its last line or at an exit command.
+func (c *CLI) RunScript(script io.Reader, name string) error {
+	stopWebhooks := c.startWebhooks()
+	defer stopWebhooks()
+
+	c.reader = bufio.NewReader(script)
+	c.line = 0
+	vars := make(map[string]string)
+
+	for {
+		input, err := c.readLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return &ScriptError{Script: name, Line: c.line, Err: err}
+		}
+
+		if input == "" || strings.HasPrefix(input, "#") {
+			continue
+		}
+
+		line := c.line
+		input, err = c.expand(input, vars)
+		if err != nil {
+			return &ScriptError{Script: name, Line: line, Err: err}
+		}
+
+		if m := assignment.FindStringSubmatch(input); m != nil {
+			vars[m[1]] = strings.TrimSpace(m[2])
+			continue
+		}
+
+		if input == "exit" {
+			return nil
+		}
+
+		if err := c.exec(input); err != nil {
+			return &ScriptError{Script: name, Line: line, Command: input, Err: err}
+		}
+	}
+}
+
+func (c *CLI) exec(command string) error {
+	c.failed = false
+	c.handleCommand(command)
+	if c.failed {
+		return ErrCommandFailed
+	}
+	return nil
+}
+
+// expand replaces the variables in a script line
+func (c *CLI) expand(input string, vars map[string]string) (string, error) {
+	var missing []string
+	expanded := os.Expand(input, func(name string) string {
+		if value, ok := vars[name]; ok {
+			return value
+		}
+
+		switch name {
+		case "$":
+			return "$"
+		case "GAME_ID":
+			if c.currentGameID != "" {
+				return c.currentGameID
+			}
+		default:
+			if value, ok := os.LookupEnv(name); ok {
+				return value
+			}
+		}
+
+		missing = append(missing, name)
+		return ""
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variable $%s", missing[0])
+	}
+	return expanded, nil
+}
+
+// readLine returns the next line of input without surrounding whitespace
+func (c *CLI) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	c.line++
+	return strings.TrimSpace(line), nil
+}
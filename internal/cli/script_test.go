@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScriptCLI(t *testing.T) (*CLI, *mockStorage, *bytes.Buffer) {
+	mockDB := newMockStorage(t)
+	cfg := &app.Config{
+		Database: app.DatabaseConfig{
+			Name: "test",
+		},
+	}
+
+	var outputBuffer bytes.Buffer
+	cli := New(mockDB, cfg)
+	cli.SetIO(nil, &outputBuffer)
+	return cli, mockDB, &outputBuffer
+}
+
+func TestExec(t *testing.T) {
+	cli, mockDB, output := newScriptCLI(t)
+
+	require.NoError(t, cli.Exec("create-game player1 fixture"))
+	assert.Contains(t, mockDB.games, "mock-game-id")
+	assert.NotContains(t, output.String(), "> ")
+
+	assert.ErrorIs(t, cli.Exec("join-game"), ErrCommandFailed)
+	assert.ErrorIs(t, cli.Exec("no-such-command"), ErrCommandFailed)
+	require.NoError(t, cli.Exec("show-games"))
+}
+
+func TestRunScript(t *testing.T) {
+	t.Setenv("BATTLESHIP_TEST_PLAYER", "player2")
+	cli, mockDB, output := newScriptCLI(t)
+
+	script := `# seed a game with one ship
+GAME=fixture
+
+create-game player1 $GAME
+join-game ${GAME} $BATTLESHIP_TEST_PLAYER
+set-game $GAME_ID
+place-ship player1 Submarine B7 Vertical
+say player1 that costs $$5
+exit
+create-game never-run
+`
+	require.NoError(t, cli.RunScript(strings.NewReader(script), "seed.txt"))
+
+	g := mockDB.games["mock-game-id"]
+	require.NotNil(t, g)
+	assert.Equal(t, "fixture", g.Name)
+	assert.Equal(t, "player2", g.Player2.Name)
+	assert.Len(t, g.Boards["player1"].Fleet, 1)
+	messages, err := mockDB.QueryChatMessages("mock-game-id", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "that costs $5", messages[0].Text)
+	assert.NotContains(t, mockDB.players, "never-run")
+	assert.Contains(t, output.String(), "Placed Submarine at B7 Vertical for player player1")
+}
+
+func TestRunScriptFailFast(t *testing.T) {
+	cli, mockDB, _ := newScriptCLI(t)
+
+	script := `create-game player1
+# the next line fails
+fire player1 Z9
+create-game player2
+`
+	err := cli.RunScript(strings.NewReader(script), "broken.txt")
+	assert.ErrorIs(t, err, ErrCommandFailed)
+
+	var scriptErr *ScriptError
+	require.ErrorAs(t, err, &scriptErr)
+	assert.Equal(t, 3, scriptErr.Line)
+	assert.Equal(t, "broken.txt:3: fire player1 Z9: command failed", err.Error())
+	assert.NotContains(t, mockDB.players, "player2")
+
+	err = cli.RunScript(strings.NewReader("show-game $UNDEFINED_VARIABLE_FOR_TEST\n"), "vars.txt")
+	require.ErrorAs(t, err, &scriptErr)
+	assert.NotErrorIs(t, err, ErrCommandFailed)
+	assert.Equal(t, "vars.txt:1: undefined variable $UNDEFINED_VARIABLE_FOR_TEST", err.Error())
+}
+
+func TestRunScriptConfirm(t *testing.T) {
+	cli, mockDB, output := newScriptCLI(t)
+
+	script := `create-game player1
+delete-game all
+confirm
+show-games`
+	require.NoError(t, cli.RunScript(strings.NewReader(script), "reset.txt"))
+	assert.Empty(t, mockDB.games)
+	assert.NotContains(t, output.String(), "Unknown command: confirm")
+}
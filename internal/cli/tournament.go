@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+)
+
+func (c *CLI) createTournament(organizer, name string, format tournament.Format) {
+	// First ensure the organizer exists
+	_, err := c.api.NewPlayer(organizer)
+	if err != nil {
+		c.errorf("Error creating player: %v\n", err)
+		return
+	}
+
+	t, err := c.tournaments.Create(name, format, organizer)
+	if err != nil {
+		c.errorf("Error creating tournament: %v\n", err)
+		return
+	}
+
+	c.emit(newTournamentResult(t), func() {
+		fmt.Fprintf(c.output, "Created %s tournament '%s' with ID: %s\n", t.Format, t.Name, t.ID)
+	})
+}
+
+func (c *CLI) showTournaments(page, count int) {
+	tournaments, err := c.tournaments.Tournaments(page, count)
+	if err != nil {
+		c.errorf("Error retrieving tournaments: %v\n", err)
+		return
+	}
+
+	c.emit(newPageResult(page, count, tournaments), func() {
+		c.printTournaments(tournaments, page, count)
+	})
+}
+
+func (c *CLI) printTournaments(tournaments []*tournament.Tournament, page, count int) {
+	if len(tournaments) == 0 {
+		fmt.Fprintf(c.output, "No tournaments found on page %d\n", page)
+		return
+	}
+
+	fmt.Fprintf(c.output, "=== Tournaments (Page %d, Count %d) ===\n", page, count)
+	fmt.Fprintln(c.output, "ID                       | Name                 | Format             | Status       | Players | Round")
+	fmt.Fprintln(c.output, "--------------------------|----------------------|--------------------|--------------|---------|------")
+
+	for _, t := range tournaments {
+		fmt.Fprintf(c.output, "%-24s | %-20s | %-18s | %-12s | %-7d | %d\n",
+			t.ID, t.Name, t.Format, t.Status, len(t.Players), len(t.Rounds))
+	}
+
+	if len(tournaments) == count {
+		fmt.Fprintf(c.output, "\nFor next page: show-tournaments %d %d\n", page+1, count)
+	}
+	if page > 0 {
+		fmt.Fprintf(c.output, "For previous page: show-tournaments %d %d\n", page-1, count)
+	}
+}
+
+func (c *CLI) showTournament(id string) {
+	t, err := c.tournaments.Get(id)
+	if err != nil {
+		c.errorf("Error getting tournament: %v\n", err)
+		return
+	}
+
+	c.emit(newTournamentResult(t), func() {
+		c.printTournament(t)
+	})
+}
+
+func (c *CLI) joinTournament(id, playerName string) {
+	// First ensure the player exists
+	_, err := c.api.NewPlayer(playerName)
+	if err != nil {
+		c.errorf("Error getting player: %v\n", err)
+		return
+	}
+
+	t, err := c.tournaments.Join(id, playerName)
+	if err != nil {
+		c.errorf("Error joining tournament: %v\n", err)
+		return
+	}
+
+	c.emit(newTournamentResult(t), func() {
+		fmt.Fprintf(c.output, "Player %s joined tournament '%s' (%d players)\n", playerName, t.Name, len(t.Players))
+	})
+}
+
+func (c *CLI) startTournament(id, organizer string) {
+	t, err := c.tournaments.Start(id, organizer)
+	if err != nil {
+		c.errorf("Error starting tournament: %v\n", err)
+		return
+	}
+
+	c.emit(newTournamentResult(t), func() {
+		fmt.Fprintf(c.output, "Tournament '%s' started!\n", t.Name)
+		c.printTournament(t)
+	})
+}
+
+func (c *CLI) advanceTournament(id, playerName string) {
+	t, err := c.tournaments.Advance(id, playerName)
+	if errors.Is(err, game.ErrorNotReady) && t != nil {
+		result := newTournamentResult(t)
+		result.Pending = err.Error()
+		c.emit(result, func() {
+			fmt.Fprintf(c.output, "Tournament '%s' can't advance yet: %v\n", t.Name, err)
+		})
+		return
+	}
+	if err != nil {
+		c.errorf("Error advancing tournament: %v\n", err)
+		return
+	}
+
+	c.emit(newTournamentResult(t), func() {
+		if t.Status == tournament.StatusFinished {
+			fmt.Fprintf(c.output, "Tournament '%s' finished! Champion: %s\n", t.Name, t.Champion)
+		} else {
+			fmt.Fprintf(c.output, "Tournament '%s' advanced to round %d\n", t.Name, t.CurrentRound().Number)
+		}
+		c.printTournament(t)
+	})
+}
+
+func (c *CLI) printTournament(t *tournament.Tournament) {
+	fmt.Fprintf(c.output, "Tournament ID: %s\n", t.ID)
+	fmt.Fprintf(c.output, "Name: %s\n", t.Name)
+	fmt.Fprintf(c.output, "Format: %s\n", t.Format)
+	fmt.Fprintf(c.output, "Status: %s\n", t.Status)
+	fmt.Fprintf(c.output, "Organizer: %s\n", t.Organizer)
+	if t.Champion != "" {
+		fmt.Fprintf(c.output, "Champion: %s\n", t.Champion)
+	}
+
+	for _, round := range t.Rounds {
+		fmt.Fprintf(c.output, "\n=== Round %d ===\n", round.Number)
+		for _, p := range round.Pairings {
+			if p.Bye() {
+				fmt.Fprintf(c.output, "%s has a bye\n", p.Player1)
+				continue
+			}
+
+			result := "playing"
+			if p.Decided() {
+				result = fmt.Sprintf("%s won in %d shots", p.Winner, p.Shots)
+			}
+			fmt.Fprintf(c.output, "%s vs %s (game %s) - %s\n", p.Player1, p.Player2, p.GameID, result)
+		}
+	}
+
+	fmt.Fprintln(c.output, "\n=== Standings ===")
+	fmt.Fprintln(c.output, "#  | Player        | Played | Won | Lost | Byes | Points | SB  | Shots")
+	for i, s := range t.Standings() {
+		fmt.Fprintf(c.output, "%-2d | %-13s | %-6d | %-3d | %-4d | %-4d | %-6d | %-3d | %d\n",
+			i+1, s.Player, s.Played, s.Wins, s.Losses, s.Byes, s.Points, s.SonnebornBerger, s.Shots)
+	}
+}
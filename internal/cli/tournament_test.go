@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTournamentCommands runs a two player tournament through the CLI
+func TestTournamentCommands(t *testing.T) {
+	mockDB := newMockStorage(t)
+	cfg := &app.Config{
+		Database: app.DatabaseConfig{
+			Name: "test",
+		},
+	}
+
+	var outputBuffer bytes.Buffer
+	cli := New(mockDB, cfg)
+	cli.SetIO(nil, &outputBuffer)
+
+	cli.handleCommand("create-tournament alice cup round-robin")
+	require.Contains(t, mockDB.tournaments, "tournament1")
+	assert.Contains(t, outputBuffer.String(), "Created round-robin tournament 'cup' with ID: tournament1")
+
+	cli.handleCommand("join-tournament tournament1 alice")
+	cli.handleCommand("join-tournament tournament1 bob")
+	assert.Equal(t, []string{"alice", "bob"}, mockDB.tournaments["tournament1"].Players)
+
+	outputBuffer.Reset()
+	cli.handleCommand("start-tournament tournament1 alice")
+	assert.Equal(t, tournament.StatusRunning, mockDB.tournaments["tournament1"].Status)
+	assert.Contains(t, outputBuffer.String(), "alice vs bob (game mock-game-id) - playing")
+
+	outputBuffer.Reset()
+	cli.handleCommand("advance-tournament tournament1 bob")
+	assert.Contains(t, outputBuffer.String(), "can't advance yet")
+
+	outputBuffer.Reset()
+	cli.handleCommand("show-tournaments")
+	assert.Contains(t, outputBuffer.String(), "tournament1")
+	assert.Contains(t, outputBuffer.String(), "running")
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+// webhookGracePeriod is how long deliveries may take to finish when the CLI stops
+const webhookGracePeriod = 5 * time.Second
+
+func (c *CLI) addWebhook(playerName, url string, events []game.EventType) {
+	// First ensure the player exists
+	_, err := c.api.NewPlayer(playerName)
+	if err != nil {
+		c.errorf("Error creating player: %v\n", err)
+		return
+	}
+
+	s, err := c.webhooks.Subscribe(playerName, url, "", events)
+	if err != nil {
+		c.errorf("Error adding webhook: %v\n", err)
+		return
+	}
+
+	c.emit(webhookResult{Subscription: s, Secret: s.Secret}, func() {
+		fmt.Fprintf(c.output, "Added webhook %s for %s\n", s.ID, s.URL)
+		fmt.Fprintf(c.output, "Events: %s\n", formatWebhookEvents(s.Events))
+		fmt.Fprintf(c.output, "Secret: %s\n", s.Secret)
+		fmt.Fprintln(c.output, "Keep the secret to verify the X-Battleship-Signature header, it is not shown again.")
+	})
+}
+
+func (c *CLI) showWebhooks(playerName string) {
+	subscriptions, err := c.webhooks.Webhooks(playerName)
+	if err != nil {
+		c.errorf("Error retrieving webhooks: %v\n", err)
+		return
+	}
+
+	if subscriptions == nil {
+		subscriptions = []*webhook.Subscription{}
+	}
+
+	c.emit(subscriptions, func() {
+		if len(subscriptions) == 0 {
+			fmt.Fprintf(c.output, "No webhooks for %s\n", playerName)
+			return
+		}
+
+		fmt.Fprintf(c.output, "=== Webhooks of %s ===\n", playerName)
+		for _, s := range subscriptions {
+			fmt.Fprintf(c.output, "%s  %s  [%s]\n", s.ID, s.URL, formatWebhookEvents(s.Events))
+		}
+	})
+}
+
+func (c *CLI) removeWebhook(id, playerName string) {
+	if err := c.webhooks.Unsubscribe(id, playerName); err != nil {
+		c.errorf("Error removing webhook: %v\n", err)
+		return
+	}
+
+	c.emit(map[string]string{"id": id, "status": "removed"}, func() {
+		fmt.Fprintf(c.output, "Removed webhook %s\n", id)
+	})
+}
+
+func (c *CLI) webhookDeliveries(id, playerName string, page, count int) {
+	deliveries, err := c.webhooks.Deliveries(id, playerName, page, count)
+	if err != nil {
+		c.errorf("Error retrieving deliveries: %v\n", err)
+		return
+	}
+
+	c.emit(newPageResult(page, count, deliveries), func() {
+		c.printDeliveries(deliveries, id, playerName, page, count)
+	})
+}
+
+func (c *CLI) printDeliveries(deliveries []*webhook.Delivery, id, playerName string, page, count int) {
+	if len(deliveries) == 0 {
+		fmt.Fprintf(c.output, "No deliveries on page %d\n", page)
+		return
+	}
+
+	fmt.Fprintf(c.output, "=== Deliveries of %s (Page %d, Count %d) ===\n", id, page, count)
+	for _, d := range deliveries {
+		result := "delivered"
+		if !d.Delivered {
+			result = "failed: " + d.Error
+		}
+		fmt.Fprintf(c.output, "%s  %-13s game %s  %d attempt(s)  %s\n",
+			d.CreatedAt.Format("2006-01-02 15:04:05"), d.Event, d.GameID, d.Attempts, result)
+	}
+
+	if len(deliveries) == count {
+		fmt.Fprintf(c.output, "\nFor next page: webhook-deliveries %s %s %d %d\n", id, playerName, page+1, count)
+	}
+}
+
+func formatWebhookEvents(events []game.EventType) string {
+	if len(events) == 0 {
+		return "all events"
+	}
+
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = string(e)
+	}
+	return strings.Join(names, ", ")
+}
+
+// startWebhooks delivers the events of the games played in this session to the webhooks. The returned
+// function stops the delivery and gives pending deliveries a grace period to finish.
+func (c *CLI) startWebhooks() func() {
+	// a server delivers the events of its games itself
+	if c.remote != nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, unsubscribe := c.api.Subscribe("")
+	dispatcher := webhook.NewDispatcher(c.db)
+
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx, events)
+		close(done)
+	}()
+
+	return func() {
+		unsubscribe()
+		select {
+		case <-done:
+		case <-time.After(webhookGracePeriod):
+			cancel()
+			<-done
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,355 @@
+// Package client drives a running battleship server through its REST API.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/health"
+)
+
+// DefaultTimeout limits every request to the server
+const DefaultTimeout = 10 * time.Second
+
+// Error is an error response of the server. It unwraps to the game error matching the status code, so
+// errors.Is(err, game.ErrorNotFound) works like with game.API.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return game.ErrorNotFound
+	case http.StatusForbidden:
+		return game.ErrorIllegal
+	case http.StatusBadRequest:
+		return game.ErrorInvalidInput
+	case http.StatusConflict:
+		return game.ErrorNotReady
+	case http.StatusTooManyRequests:
+		return game.ErrorLimitExceeded
+	default:
+		return nil
+	}
+}
+
+// GameView is a game as the server shows it to one player: only the player's own board is included
+type GameView struct {
+	ID      string      `json:"_id,omitempty"`
+	Name    string      `json:"name,omitempty"`
+	User    string      `json:"user"`
+	Board   *game.Board `json:"board"`
+	History []game.Move `json:"history"`
+	Status  game.Status `json:"status"`
+
+	Visibility game.Visibility `json:"visibility"`
+	InviteCode string          `json:"invite_code,omitempty"`
+
+	Player1      *game.Player `json:"player_1"`
+	Player2      *game.Player `json:"player_2"`
+	PlayerToMove string       `json:"player_to_move"`
+
+	RematchOf          string      `json:"rematch_of,omitempty"`
+	RematchID          string      `json:"rematch_id,omitempty"`
+	RematchRequestedBy string      `json:"rematch_requested_by,omitempty"`
+	Series             game.Series `json:"series"`
+}
+
+// Game returns the view as a game holding only the board of the viewing player, or no board for guests
+func (v *GameView) Game() *game.Game {
+	g := &game.Game{
+		ID:                 v.ID,
+		Name:               v.Name,
+		Boards:             make(map[string]*game.Board),
+		History:            v.History,
+		Status:             v.Status,
+		Visibility:         v.Visibility,
+		InviteCode:         v.InviteCode,
+		Player1:            v.Player1,
+		Player2:            v.Player2,
+		PlayerToMove:       v.PlayerToMove,
+		RematchOf:          v.RematchOf,
+		RematchID:          v.RematchID,
+		RematchRequestedBy: v.RematchRequestedBy,
+	}
+	if g.Player1 == nil {
+		g.Player1 = &game.Player{}
+	}
+	if g.Player2 == nil {
+		g.Player2 = &game.Player{}
+	}
+
+	if v.Board != nil && (v.User == g.Player1.Name || v.User == g.Player2.Name) {
+		g.Boards[v.User] = v.Board
+	}
+
+	// the server sends the score including this game, Game.SeriesScore adds it again
+	series := &game.Series{Wins: make(map[string]int), Games: v.Series.Games}
+	for name, wins := range v.Series.Wins {
+		series.Wins[name] = wins
+	}
+	if winner := g.Winner(); winner != "" {
+		series.Wins[winner]--
+		series.Games--
+	}
+	g.Series = series
+
+	return g
+}
+
+// Client keeps one session per player, so commands of several players can be sent through one client.
+// Players are logged in on their first request.
+type Client struct {
+	baseURL  *url.URL
+	timeout  time.Duration
+	sessions map[string]*http.Client
+	guest    *http.Client
+}
+
+// New returns a client for the server at serverURL, e.g. "http://localhost:8080"
+func New(serverURL string) (*Client, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %q: %w", serverURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q, expected http(s)://host[:port]", serverURL)
+	}
+
+	return &Client{
+		baseURL:  u,
+		timeout:  DefaultTimeout,
+		sessions: make(map[string]*http.Client),
+		guest:    &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+// URL returns the address of the server
+func (c *Client) URL() string {
+	return c.baseURL.String()
+}
+
+// Login starts a session for the player, the server creates unknown players
+func (c *Client) Login(playerName string) (*game.Player, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	session := &http.Client{Timeout: c.timeout, Jar: jar}
+
+	var player game.Player
+	body := map[string]string{"username": playerName}
+	if err := c.do(session, http.MethodPost, "/api/login", body, &player); err != nil {
+		return nil, err
+	}
+
+	c.sessions[playerName] = session
+	return &player, nil
+}
+
+// Logout ends the session of the player
+func (c *Client) Logout(playerName string) error {
+	session, ok := c.sessions[playerName]
+	if !ok {
+		return nil
+	}
+
+	delete(c.sessions, playerName)
+	return c.do(session, http.MethodGet, "/api/logout", nil, nil)
+}
+
+// Games returns a page of game summaries
+func (c *Client) Games(page int, count int) ([]game.Summary, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("items", strconv.Itoa(count))
+
+	var response struct {
+		Games []game.Summary `json:"games"`
+	}
+	if err := c.do(c.guest, http.MethodGet, "/api/games?"+query.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Games, nil
+}
+
+// Game returns a game as the player sees it. Without a player the game is shown to a guest, without boards.
+func (c *Client) Game(playerName string, id string) (*GameView, error) {
+	if playerName == "" {
+		var view GameView
+		if err := c.do(c.guest, http.MethodGet, "/api/games/"+url.PathEscape(id), nil, &view); err != nil {
+			return nil, err
+		}
+		return &view, nil
+	}
+
+	return c.gameRequest(playerName, http.MethodGet, "/api/games/"+url.PathEscape(id), nil)
+}
+
+// CreateGame creates a game with the player as player 1
+func (c *Client) CreateGame(playerName string, name string, visibility game.Visibility) (*GameView, error) {
+	body := map[string]any{"name": name, "visibility": visibility}
+	return c.gameRequest(playerName, http.MethodPost, "/api/games", body)
+}
+
+// JoinGame joins a public game as player 2
+func (c *Client) JoinGame(playerName string, id string) (*GameView, error) {
+	return c.gameRequest(playerName, http.MethodPatch, "/api/games/"+url.PathEscape(id), nil)
+}
+
+// CancelGame deletes a game of the player which nobody has joined yet
+func (c *Client) CancelGame(playerName string, id string) error {
+	return c.playerRequest(playerName, http.MethodDelete, "/api/games/"+url.PathEscape(id), nil, nil)
+}
+
+// JoinGameByCode joins a game with its invite code as player 2
+func (c *Client) JoinGameByCode(playerName string, code string) (*GameView, error) {
+	return c.gameRequest(playerName, http.MethodPost, "/api/invites/"+url.PathEscape(code), nil)
+}
+
+// PlaceShip places a ship with its top left end at x and y
+func (c *Client) PlaceShip(playerName string, id string, shipType game.ShipType, x, y int, orientation game.ShipOrientation) (*GameView, error) {
+	body := map[string]any{"type": shipType, "x": x, "y": y, "orientation": orientation}
+	return c.gameRequest(playerName, http.MethodPost, "/api/games/"+url.PathEscape(id)+"/ships", body)
+}
+
+// StartGame starts a game once both fleets are placed
+func (c *Client) StartGame(playerName string, id string) (*GameView, error) {
+	return c.gameRequest(playerName, http.MethodGet, "/api/games/"+url.PathEscape(id)+"/start", nil)
+}
+
+// Fire shoots at the field x and y of the opponent
+func (c *Client) Fire(playerName string, id string, x, y int) (*GameView, error) {
+	body := game.Move{X: x, Y: y}
+	return c.gameRequest(playerName, http.MethodPost, "/api/games/"+url.PathEscape(id)+"/target", body)
+}
+
+// Ready returns the readiness of the server. A server which is not ready answers with its report as well,
+// so only a server which cannot be reached is an error.
+func (c *Client) Ready() (*health.Report, error) {
+	target, err := c.baseURL.Parse("/readyz")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.guest.Get(target.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not reach server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, &Error{StatusCode: resp.StatusCode, Message: resp.Status}
+	}
+
+	var report health.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("unexpected response from GET /readyz: %w", err)
+	}
+	return &report, nil
+}
+
+func (c *Client) gameRequest(playerName string, method string, path string, body any) (*GameView, error) {
+	var view GameView
+	if err := c.playerRequest(playerName, method, path, body, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// playerRequest sends a request in the session of the player. Sessions expire on the server, so a request
+// rejected for a missing session is sent once more after logging in again.
+func (c *Client) playerRequest(playerName string, method string, path string, body any, result any) error {
+	session, ok := c.sessions[playerName]
+	if !ok {
+		if _, err := c.Login(playerName); err != nil {
+			return err
+		}
+		session = c.sessions[playerName]
+	}
+
+	err := c.do(session, method, path, body, result)
+	if !ok || !sessionExpired(err) {
+		return err
+	}
+
+	if _, err := c.Login(playerName); err != nil {
+		return err
+	}
+	return c.do(c.sessions[playerName], method, path, body, result)
+}
+
+func sessionExpired(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusBadRequest && strings.HasPrefix(e.Message, "invalid player")
+}
+
+func (c *Client) do(session *http.Client, method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	target, err := c.baseURL.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, target.String(), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := session.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		var response struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &response) != nil || response.Error == "" {
+			response.Error = resp.Status
+		}
+		return &Error{StatusCode: resp.StatusCode, Message: response.Error}
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("unexpected response from %s %s: %w", method, path, err)
+	}
+	return nil
+}
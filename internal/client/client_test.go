@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// fakeServer logs players in with a cookie holding their name and answers /api/games/:id for them
+func fakeServer(t *testing.T, logins *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/login", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Username string `json:"username"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*logins++
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: body.Username, Path: "/"})
+		_ = json.NewEncoder(w).Encode(game.Player{Name: body.Username})
+	})
+	mux.HandleFunc("GET /api/games/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "game1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"game not found"}`))
+			return
+		}
+
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid player"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(GameView{ID: "game1", User: cookie.Value})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost:8080")
+	assert.Error(t, err)
+
+	c, err := New("http://localhost:8080")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", c.URL())
+}
+
+func TestSessionPerPlayer(t *testing.T) {
+	logins := 0
+	c, err := New(fakeServer(t, &logins).URL)
+	require.NoError(t, err)
+
+	alice, err := c.Game("alice", "game1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", alice.User)
+
+	bob, err := c.Game("bob", "game1")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", bob.User)
+
+	_, err = c.Game("alice", "game1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, logins)
+}
+
+func TestExpiredSession(t *testing.T) {
+	logins := 0
+	c, err := New(fakeServer(t, &logins).URL)
+	require.NoError(t, err)
+
+	_, err = c.Login("alice")
+	require.NoError(t, err)
+
+	// a session without cookie is rejected like an expired one
+	c.sessions["alice"].Jar = nil
+	view, err := c.Game("alice", "game1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", view.User)
+	assert.Equal(t, 2, logins)
+}
+
+func TestErrorResponse(t *testing.T) {
+	logins := 0
+	c, err := New(fakeServer(t, &logins).URL)
+	require.NoError(t, err)
+
+	_, err = c.Game("", "unknown")
+	assert.ErrorIs(t, err, game.ErrorNotFound)
+	assert.EqualError(t, err, "game not found")
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
@@ -0,0 +1,372 @@
+// Package engine lets bots play battleship. A Strategy decides the fleet and the shots of a player; Engine is
+// a strategy running as a subprocess that speaks the line-based engine protocol described in
+// docs/ENGINE_PROTOCOL.md, so bots can be written in any language. Player plays a strategy in a game of the
+// game.API against humans or other bots.
+package engine
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// ProtocolVersion is the version of the engine protocol spoken by the adapter
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds the time an engine may take to answer a request
+const DefaultTimeout = 10 * time.Second
+
+var (
+	ErrorTimeout  = errors.New("engine timed out")
+	ErrorProtocol = errors.New("engine protocol violation")
+	ErrorStopped  = errors.New("engine stopped")
+	ErrorForfeit  = errors.New("forfeits")
+)
+
+// Placement is the position of one ship of a fleet
+type Placement struct {
+	Ship        game.ShipType
+	X, Y        int
+	Orientation game.ShipOrientation
+}
+
+// Outcome is the result of a shot. Sunk names the type of the ship the shot sank, if any.
+type Outcome struct {
+	Hit  bool
+	Sunk game.ShipType
+}
+
+// Strategy plays one side of a game. The adapter calls NewGame, then Place until the fleet is accepted, then
+// Fire for each of the strategy's turns, and finally GameOver. Error reports why the last answer was rejected
+// before it is asked again.
+type Strategy interface {
+	Name() string
+	NewGame(gameID string, opponent string) error
+	Place() ([]Placement, error)
+	Fire() (x int, y int, err error)
+	Result(x int, y int, outcome Outcome) error
+	OpponentShot(x int, y int, outcome Outcome) error
+	GameOver(won bool) error
+	Error(reason string) error
+	Close() error
+}
+
+// Engine is a strategy played by an external program through the engine protocol
+type Engine struct {
+	name   string
+	author string
+
+	// Timeout bounds the time the engine may take to answer a request
+	Timeout time.Duration
+	// Logger reports the info lines of the engine
+	Logger *slog.Logger
+
+	w     io.WriteCloser
+	lines chan string
+	done  chan struct{}
+	once  sync.Once
+	cmd   *exec.Cmd
+}
+
+// Start runs an engine program and performs the handshake. The engine's standard error is passed through and
+// its info lines are reported to logger, slog.Default() if nil.
+func Start(logger *slog.Logger, command string, args ...string) (*Engine, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to engine %s: %w", command, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to engine %s: %w", command, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting engine %s: %w", command, err)
+	}
+
+	e, err := Connect(logger, stdout, stdin)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	e.cmd = cmd
+	return e, nil
+}
+
+// Connect speaks the engine protocol with an engine reading from w and writing to r, and performs the
+// handshake. The info lines of the engine are reported to logger, slog.Default() if nil.
+func Connect(logger *slog.Logger, r io.Reader, w io.WriteCloser) (*Engine, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	e := &Engine{
+		name:    "engine",
+		Timeout: DefaultTimeout,
+		Logger:  logger,
+		w:       w,
+		lines:   make(chan string),
+		done:    make(chan struct{}),
+	}
+	go e.read(r)
+
+	if err := e.handshake(); err != nil {
+		_ = e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Engine) read(r io.Reader) {
+	defer close(e.lines)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		select {
+		case e.lines <- scanner.Text():
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// handshake announces the protocol version and collects the engine's name until it confirms with ok
+func (e *Engine) handshake() error {
+	if err := e.send("battleship %d", ProtocolVersion); err != nil {
+		return err
+	}
+
+	for {
+		command, args, err := e.receive()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case command == "ok":
+			return nil
+		case command == "id" && len(args) > 1 && args[0] == "name":
+			e.name = strings.Join(args[1:], " ")
+		case command == "id" && len(args) > 1 && args[0] == "author":
+			e.author = strings.Join(args[1:], " ")
+		default:
+			return e.unexpected(command, args, "id or ok")
+		}
+	}
+}
+
+func (e *Engine) Name() string {
+	return e.name
+}
+
+// Author returns the author the engine announced, if any
+func (e *Engine) Author() string {
+	return e.author
+}
+
+// NewGame announces a game and its rules
+func (e *Engine) NewGame(gameID string, opponent string) error {
+	if err := e.send("newgame %s %s", gameID, opponent); err != nil {
+		return err
+	}
+	return e.send("rules %s", Rules())
+}
+
+// Place asks the engine for its fleet, which it answers with a ship line per ship followed by done
+func (e *Engine) Place() ([]Placement, error) {
+	if err := e.send("place"); err != nil {
+		return nil, err
+	}
+
+	var fleet []Placement
+	for {
+		command, args, err := e.receive()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case command == "done" && len(args) == 0:
+			return fleet, nil
+		case command == "ship" && len(args) == 3:
+			p, err := parsePlacement(args)
+			if err != nil {
+				return nil, fmt.Errorf("engine %s sent %q: %v: %w", e.name, "ship "+strings.Join(args, " "), err, ErrorProtocol)
+			}
+			fleet = append(fleet, p)
+		default:
+			return nil, e.unexpected(command, args, "ship or done")
+		}
+	}
+}
+
+// Fire asks the engine for its next shot
+func (e *Engine) Fire() (int, int, error) {
+	if err := e.send("fire"); err != nil {
+		return 0, 0, err
+	}
+
+	command, args, err := e.receive()
+	if err != nil {
+		return 0, 0, err
+	}
+	if command != "shot" || len(args) != 1 {
+		return 0, 0, e.unexpected(command, args, "shot")
+	}
+
+	x, y, err := game.ParseCoordinate(args[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("engine %s sent %q: %v: %w", e.name, "shot "+args[0], err, ErrorProtocol)
+	}
+	return x, y, nil
+}
+
+// Result tells the engine the outcome of its last shot
+func (e *Engine) Result(x int, y int, outcome Outcome) error {
+	return e.send("result %s %s", game.FormatCoordinate(x, y), formatOutcome(outcome))
+}
+
+// OpponentShot tells the engine where the opponent fired
+func (e *Engine) OpponentShot(x int, y int, outcome Outcome) error {
+	return e.send("opponent %s %s", game.FormatCoordinate(x, y), formatOutcome(outcome))
+}
+
+func (e *Engine) GameOver(won bool) error {
+	if won {
+		return e.send("gameover win")
+	}
+	return e.send("gameover loss")
+}
+
+func (e *Engine) Error(reason string) error {
+	return e.send("error %s", reason)
+}
+
+// Close asks the engine to quit and waits for the program to end. An engine that doesn't quit within the
+// timeout is killed.
+func (e *Engine) Close() error {
+	var err error
+	e.once.Do(func() {
+		_ = e.send("quit")
+		_ = e.w.Close()
+		close(e.done)
+
+		if e.cmd == nil {
+			return
+		}
+
+		exited := make(chan error, 1)
+		go func() { exited <- e.cmd.Wait() }()
+		select {
+		case err = <-exited:
+		case <-time.After(e.Timeout):
+			_ = e.cmd.Process.Kill()
+			err = <-exited
+		}
+	})
+	return err
+}
+
+func (e *Engine) send(format string, args ...any) error {
+	if _, err := fmt.Fprintf(e.w, format+"\n", args...); err != nil {
+		return fmt.Errorf("error writing to engine %s: %v: %w", e.name, err, ErrorStopped)
+	}
+	return nil
+}
+
+// receive returns the next command of the engine and its arguments. Empty lines are skipped and info lines
+// are logged.
+func (e *Engine) receive() (string, []string, error) {
+	timer := time.NewTimer(e.Timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", nil, fmt.Errorf("engine %s: %w", e.name, ErrorStopped)
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			if fields[0] == "info" {
+				e.Logger.Info("engine info", "engine", e.name, "info", strings.Join(fields[1:], " "))
+				continue
+			}
+			return fields[0], fields[1:], nil
+
+		case <-timer.C:
+			return "", nil, fmt.Errorf("engine %s did not answer within %s: %w", e.name, e.Timeout, ErrorTimeout)
+		}
+	}
+}
+
+func (e *Engine) unexpected(command string, args []string, expected string) error {
+	line := strings.TrimSpace(command + " " + strings.Join(args, " "))
+	return fmt.Errorf("engine %s sent %q, expected %s: %w", e.name, line, expected, ErrorProtocol)
+}
+
+// Rules describes the board and the fleet, e.g. "board 10 10 touching no ships Battleship:5:1 Cruiser:4:2"
+func Rules() string {
+	toPlace := game.NewBoard("", "").ShipsToPlace()
+
+	ships := make([]string, 0, len(game.ShipTypes))
+	for _, shipType := range game.ShipTypes {
+		ships = append(ships, fmt.Sprintf("%s:%d:%d", shipType, shipType.Length(), toPlace[shipType]))
+	}
+	return "board 10 10 touching no ships " + strings.Join(ships, " ")
+}
+
+// parsePlacement parses the arguments of a ship line, e.g. "Cruiser B2 v"
+func parsePlacement(args []string) (Placement, error) {
+	shipType := game.InvalidShip
+	for _, t := range game.ShipTypes {
+		if strings.EqualFold(args[0], string(t)) {
+			shipType = t
+		}
+	}
+	if shipType == game.InvalidShip {
+		return Placement{}, fmt.Errorf("unknown ship type %q", args[0])
+	}
+
+	x, y, err := game.ParseCoordinate(args[1])
+	if err != nil {
+		return Placement{}, err
+	}
+
+	var orientation game.ShipOrientation
+	switch strings.ToLower(args[2]) {
+	case "h":
+		orientation = game.OrientationHorizontal
+	case "v":
+		orientation = game.OrientationVertical
+	default:
+		return Placement{}, fmt.Errorf("invalid orientation %q, use h or v", args[2])
+	}
+
+	return Placement{Ship: shipType, X: x, Y: y, Orientation: orientation}, nil
+}
+
+func formatOutcome(outcome Outcome) string {
+	switch {
+	case outcome.Sunk != "":
+		return "sunk " + string(outcome.Sunk)
+	case outcome.Hit:
+		return "hit"
+	default:
+		return "miss"
+	}
+}
@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// testEngineEnv makes the test binary run testEngine instead of the tests, so Start can run it as engine
+const testEngineEnv = "BATTLESHIP_TEST_ENGINE"
+
+func TestMain(m *testing.M) {
+	if name := os.Getenv(testEngineEnv); name != "" {
+		testEngine(os.Stdin, os.Stdout, name)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+var testFleet = []string{
+	"Battleship A1 h",
+	"Cruiser A3 h",
+	"Cruiser F3 h",
+	"Destroyer A5 h",
+	"Destroyer E5 h",
+	"Destroyer A7 h",
+	"Submarine E7 h",
+	"Submarine I7 h",
+	"Submarine A9 h",
+	"Submarine D9 h",
+}
+
+// testEngine places testFleet and fires at the fields row by row
+func testEngine(r io.Reader, w io.Writer, name string) {
+	shots := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "battleship":
+			fmt.Fprintf(w, "id name %s\nid author the tests\nok\n", name)
+		case "place":
+			fmt.Fprintln(w, "info placing the fleet")
+			for _, ship := range testFleet {
+				fmt.Fprintf(w, "ship %s\n", ship)
+			}
+			fmt.Fprintln(w, "done")
+		case "fire":
+			fmt.Fprintf(w, "shot %s\n", game.FormatCoordinate(shots%10, shots/10))
+			shots++
+		case "quit":
+			return
+		}
+	}
+}
+
+// transcript records the lines an engine received
+type transcript struct {
+	mu    sync.Mutex
+	lines strings.Builder
+	done  chan struct{}
+}
+
+func (t *transcript) add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines.WriteString(line + "\n")
+}
+
+func (t *transcript) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lines.String()
+}
+
+// connect runs an engine in a goroutine, which answers with the lines of the script for each line it reads
+func connect(t *testing.T, logger *slog.Logger, script map[string]string) (*Engine, *transcript, error) {
+	engineIn, adapterOut := io.Pipe()
+	adapterIn, engineOut := io.Pipe()
+
+	received := &transcript{done: make(chan struct{})}
+	go func() {
+		defer close(received.done)
+		defer engineOut.Close()
+
+		scanner := bufio.NewScanner(engineIn)
+		for scanner.Scan() {
+			received.add(scanner.Text())
+			fields := strings.Fields(scanner.Text())
+			if answer, ok := script[fields[0]]; ok {
+				fmt.Fprint(engineOut, answer)
+			}
+		}
+	}()
+
+	e, err := Connect(logger, adapterIn, adapterOut)
+	if err == nil {
+		e.Timeout = 100 * time.Millisecond
+		t.Cleanup(func() { _ = e.Close() })
+	}
+	return e, received, err
+}
+
+func TestHandshake(t *testing.T) {
+	var log bytes.Buffer
+	e, received, err := connect(t, slog.New(slog.NewTextHandler(&log, nil)), map[string]string{
+		"battleship": "id name Hunter 2\n\nid author Jane Doe\ninfo warming up\nok\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hunter 2", e.Name())
+	assert.Equal(t, "Jane Doe", e.Author())
+	assert.Equal(t, "battleship 1\n", received.String())
+	assert.Contains(t, log.String(), `msg="engine info" engine="Hunter 2" info="warming up"`)
+
+	_, _, err = connect(t, nil, map[string]string{"battleship": "hello\n"})
+	assert.ErrorIs(t, err, ErrorProtocol)
+	assert.EqualError(t, err, `engine engine sent "hello", expected id or ok: engine protocol violation`)
+}
+
+func TestPlaceAndFire(t *testing.T) {
+	var log bytes.Buffer
+	e, received, err := connect(t, slog.New(slog.NewTextHandler(&log, nil)), map[string]string{
+		"battleship": "ok\n",
+		"place":      "ship Battleship A1 h\nship cruiser C3 V\ndone\n",
+		"fire":       "info thinking hard\nshot J10\n",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, e.NewGame("game1", "bob"))
+	fleet, err := e.Place()
+	require.NoError(t, err)
+	assert.Equal(t, []Placement{
+		{Ship: game.Battleship, X: 0, Y: 0, Orientation: game.OrientationHorizontal},
+		{Ship: game.Cruiser, X: 2, Y: 2, Orientation: game.OrientationVertical},
+	}, fleet)
+
+	x, y, err := e.Fire()
+	require.NoError(t, err)
+	assert.Equal(t, []int{9, 9}, []int{x, y})
+	assert.Contains(t, log.String(), `msg="engine info" engine=engine info="thinking hard"`)
+
+	require.NoError(t, e.Result(9, 9, Outcome{Hit: true, Sunk: game.Submarine}))
+	require.NoError(t, e.OpponentShot(0, 0, Outcome{Hit: false}))
+	require.NoError(t, e.Error("shot is off board"))
+	require.NoError(t, e.GameOver(true))
+	require.NoError(t, e.Close())
+	<-received.done
+
+	assert.Equal(t, strings.Join([]string{
+		"battleship 1",
+		"newgame game1 bob",
+		"rules board 10 10 touching no ships Battleship:5:1 Cruiser:4:2 Destroyer:3:3 Submarine:2:4",
+		"place",
+		"fire",
+		"result J10 sunk Submarine",
+		"opponent A1 miss",
+		"error shot is off board",
+		"gameover win",
+		"quit",
+	}, "\n")+"\n", received.String())
+}
+
+func TestProtocolErrors(t *testing.T) {
+	e, _, err := connect(t, nil, map[string]string{
+		"battleship": "ok\n",
+		"place":      "ship Frigate A1 h\n",
+		"fire":       "shot K1\n",
+	})
+	require.NoError(t, err)
+
+	_, err = e.Place()
+	assert.ErrorIs(t, err, ErrorProtocol)
+	assert.Contains(t, err.Error(), `unknown ship type "Frigate"`)
+
+	_, _, err = e.Fire()
+	assert.ErrorIs(t, err, ErrorProtocol)
+
+	e, _, err = connect(t, nil, map[string]string{"battleship": "ok\n"})
+	require.NoError(t, err)
+	_, _, err = e.Fire()
+	assert.ErrorIs(t, err, ErrorTimeout)
+}
+
+func TestStart(t *testing.T) {
+	t.Setenv(testEngineEnv, "row by row")
+
+	e, err := Start(nil, os.Args[0])
+	require.NoError(t, err)
+	assert.Equal(t, "row by row", e.Name())
+
+	fleet, err := e.Place()
+	require.NoError(t, err)
+	assert.Len(t, fleet, 10)
+	assert.NoError(t, e.Close())
+
+	_, err = Start(nil, "./no-such-engine")
+	assert.Error(t, err)
+}
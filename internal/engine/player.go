@@ -0,0 +1,261 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+const (
+	DefaultMaxAttempts  = 3
+	DefaultPollInterval = time.Second
+)
+
+// Player plays a strategy as a player in a game of the game.API. The game is reloaded periodically and on
+// every event of the API, so the opponent may play in this or in another process.
+type Player struct {
+	api      *game.API
+	name     string
+	strategy Strategy
+
+	// MaxAttempts is the number of answers the strategy may give for a placement or a shot before it forfeits
+	MaxAttempts int
+	// PollInterval is the interval in which the game is reloaded
+	PollInterval time.Duration
+}
+
+func NewPlayer(api *game.API, name string, strategy Strategy) *Player {
+	return &Player{
+		api:          api,
+		name:         name,
+		strategy:     strategy,
+		MaxAttempts:  DefaultMaxAttempts,
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// Join creates the player and joins the game with the given ID or name, unless the player already joined
+// it. It returns the ID of the game.
+func (p *Player) Join(gameIDOrName string) (string, error) {
+	player, err := p.api.NewPlayer(p.name)
+	if err != nil {
+		return "", fmt.Errorf("error getting player: %w", err)
+	}
+
+	g, err := p.api.GetGame(gameIDOrName)
+	if errors.Is(err, game.ErrorNotFound) || (err != nil && strings.Contains(err.Error(), "invalid game ID")) {
+		g, err = p.api.GetGameByName(gameIDOrName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error getting game: %w", err)
+	}
+	if _, ok := g.Boards[p.name]; ok {
+		return g.ID, nil
+	}
+
+	if err := g.Join(player); err != nil {
+		return "", fmt.Errorf("error joining game: %w", err)
+	}
+	if _, err := p.api.UpdateGame(g); err != nil {
+		return "", fmt.Errorf("error updating game: %w", err)
+	}
+	return g.ID, nil
+}
+
+// Play plays the game until it is over or the context is cancelled. The player must have joined the game;
+// Play waits for the opponent to join, places the fleet and fires on the player's turns. A strategy that
+// keeps giving invalid answers forfeits the game by returning an error.
+func (p *Player) Play(ctx context.Context, gameID string) error {
+	events, unsubscribe := p.api.Subscribe(gameID)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(p.PollInterval)
+	defer ticker.Stop()
+
+	announced := false
+	seen := 0
+	for {
+		g, err := p.api.GetGame(gameID)
+		if err != nil {
+			return fmt.Errorf("error getting game: %w", err)
+		}
+		board, ok := g.Boards[p.name]
+		if !ok {
+			return fmt.Errorf("player %s did not join game %s: %w", p.name, gameID, game.ErrorIllegal)
+		}
+
+		if len(g.Boards) == 2 {
+			if !announced {
+				if err := p.strategy.NewGame(g.ID, opponent(g, p.name)); err != nil {
+					return err
+				}
+				announced = true
+			}
+
+			if seen, err = p.reportOpponentShots(g, seen); err != nil {
+				return err
+			}
+
+			switch {
+			case g.Finished():
+				return p.strategy.GameOver(g.Winner() == p.name)
+			case g.Status == game.StatusSetup && board.PinsAvailable > 0:
+				if err := p.place(g); err != nil {
+					return err
+				}
+				continue
+			case g.Status == game.StatusSetup && g.CanStart(p.name) == nil:
+				// the opponent completed the last fleet without starting the game
+				if err := g.Start(p.name); err != nil {
+					return err
+				}
+				if _, err := p.api.UpdateGame(g); err != nil {
+					return fmt.Errorf("error updating game: %w", err)
+				}
+				continue
+			case g.Status == game.StatusPlaying && g.PlayerToMove == p.name:
+				if seen, err = p.fire(g); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-events:
+		case <-ticker.C:
+		}
+	}
+}
+
+// place places the strategy's fleet. Like the CLI, the game starts with the player completing the last
+// fleet.
+func (p *Player) place(g *game.Game) error {
+	if pins := g.Boards[p.name].PinsAvailable; pins != game.NewBoard("", "").PinsAvailable {
+		return fmt.Errorf("fleet is partly placed already: %w", game.ErrorInvalid)
+	}
+
+	if err := PlaceFleet(g, p.name, p.strategy, p.MaxAttempts); err != nil {
+		return err
+	}
+
+	_ = g.Start(p.name)
+	if _, err := p.api.UpdateGame(g); err != nil {
+		return fmt.Errorf("error updating game: %w", err)
+	}
+	return nil
+}
+
+// fire plays the player's turn and returns the number of moves seen afterwards
+func (p *Player) fire(g *game.Game) (int, error) {
+	if err := Fire(g, p.name, p.strategy, p.MaxAttempts); err != nil {
+		return 0, err
+	}
+
+	if _, err := p.api.UpdateGame(g); err != nil {
+		return 0, fmt.Errorf("error updating game: %w", err)
+	}
+	return len(g.History), nil
+}
+
+// PlaceFleet asks the strategy for its fleet until it is valid and places it in the game. A strategy that
+// gives maxAttempts invalid fleets forfeits.
+func PlaceFleet(g *game.Game, player string, s Strategy, maxAttempts int) error {
+	for attempt := 1; ; attempt++ {
+		fleet, err := s.Place()
+		if err != nil {
+			return err
+		}
+
+		err = placeFleet(g, player, fleet)
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("%s %w after %d invalid fleets: %w", s.Name(), ErrorForfeit, attempt, err)
+		}
+		if err := s.Error(err.Error()); err != nil {
+			return err
+		}
+	}
+}
+
+// placeFleet places a complete fleet, or nothing if any ship is invalid or the fleet is incomplete
+func placeFleet(g *game.Game, player string, fleet []Placement) error {
+	board := game.NewBoard(player, "")
+	for _, ship := range fleet {
+		if err := board.PlaceShip(ship.Ship, ship.X, ship.Y, ship.Orientation); err != nil {
+			return fmt.Errorf("%s at %s: %w", ship.Ship, game.FormatCoordinate(ship.X, ship.Y), err)
+		}
+	}
+	if board.PinsAvailable > 0 {
+		return fmt.Errorf("fleet is incomplete: %w", game.ErrorInvalid)
+	}
+
+	for _, ship := range fleet {
+		if err := g.PlaceShip(player, ship.Ship, ship.X, ship.Y, ship.Orientation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fire asks the strategy for a shot until it is valid, makes the move and tells the strategy the outcome.
+// A strategy that gives maxAttempts invalid shots forfeits.
+func Fire(g *game.Game, player string, s Strategy, maxAttempts int) error {
+	opponentBoard := g.Boards[opponent(g, player)]
+
+	for attempt := 1; ; attempt++ {
+		x, y, err := s.Fire()
+		if err != nil {
+			return err
+		}
+
+		// the ship is removed from the fleet when it is sunk, so it has to be looked up before the shot
+		target, _ := opponentBoard.ShipAtPosition(x, y)
+		afloat := len(opponentBoard.Fleet)
+
+		err = g.MakeMove(game.Move{Player: player, X: x, Y: y})
+		if err != nil {
+			if attempt >= maxAttempts {
+				return fmt.Errorf("%s %w after %d invalid shots: %w", s.Name(), ErrorForfeit, attempt, err)
+			}
+			if err := s.Error(err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		outcome := Outcome{Hit: g.History[len(g.History)-1].Hit}
+		if target != nil && len(opponentBoard.Fleet) < afloat {
+			outcome.Sunk = target.ShipType
+		}
+		return s.Result(x, y, outcome)
+	}
+}
+
+// reportOpponentShots tells the strategy about the opponent's moves after the first seen moves
+func (p *Player) reportOpponentShots(g *game.Game, seen int) (int, error) {
+	for _, move := range g.History[min(seen, len(g.History)):] {
+		if move.Player == p.name {
+			continue
+		}
+		if err := p.strategy.OpponentShot(move.X, move.Y, Outcome{Hit: move.Hit}); err != nil {
+			return seen, err
+		}
+	}
+	return len(g.History), nil
+}
+
+func opponent(g *game.Game, player string) string {
+	if g.Player1.Name == player {
+		return g.Player2.Name
+	}
+	return g.Player1.Name
+}
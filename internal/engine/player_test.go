@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+// scriptedStrategy answers with the given fleets and shots in order and records what it is told
+type scriptedStrategy struct {
+	fleets [][]Placement
+	shots  [][2]int
+
+	results       []Outcome
+	opponentShots []game.Move
+	errors        []string
+	won           *bool
+}
+
+func (s *scriptedStrategy) Name() string { return "scripted" }
+
+func (s *scriptedStrategy) NewGame(string, string) error { return nil }
+
+func (s *scriptedStrategy) Place() ([]Placement, error) {
+	fleet := s.fleets[0]
+	s.fleets = s.fleets[1:]
+	return fleet, nil
+}
+
+func (s *scriptedStrategy) Fire() (int, int, error) {
+	shot := s.shots[0]
+	s.shots = s.shots[1:]
+	return shot[0], shot[1], nil
+}
+
+func (s *scriptedStrategy) Result(x int, y int, outcome Outcome) error {
+	s.results = append(s.results, outcome)
+	return nil
+}
+
+func (s *scriptedStrategy) OpponentShot(x int, y int, outcome Outcome) error {
+	s.opponentShots = append(s.opponentShots, game.Move{X: x, Y: y, Hit: outcome.Hit})
+	return nil
+}
+
+func (s *scriptedStrategy) GameOver(won bool) error {
+	s.won = &won
+	return nil
+}
+
+func (s *scriptedStrategy) Error(reason string) error {
+	s.errors = append(s.errors, reason)
+	return nil
+}
+
+func (s *scriptedStrategy) Close() error { return nil }
+
+func fleetOf(t *testing.T, lines []string) []Placement {
+	var fleet []Placement
+	for _, line := range lines {
+		p, err := parsePlacement(strings.Fields(line))
+		require.NoError(t, err)
+		fleet = append(fleet, p)
+	}
+	return fleet
+}
+
+func TestPlayerRetriesInvalidAnswers(t *testing.T) {
+	api, db, created := gametest.NewGame(t)
+	gameID := created.ID
+
+	strategy := &scriptedStrategy{
+		fleets: [][]Placement{
+			fleetOf(t, testFleet[:9]),
+			fleetOf(t, testFleet),
+		},
+		// K1 is off the board, A1 hits the battleship
+		shots: [][2]int{{10, 0}, {0, 0}},
+	}
+	player := NewPlayer(api, "alice", strategy)
+	player.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err := player.Play(ctx, gameID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "alice waits for bob to move")
+
+	assert.Equal(t, []string{
+		"fleet is incomplete: invalid",
+		"you can't attack: shot (10, 0) is off board: invalid",
+	}, strategy.errors)
+	assert.Equal(t, []Outcome{{Hit: true}}, strategy.results)
+
+	g, err := db.FindGameByID(gameID)
+	require.NoError(t, err)
+	assert.Equal(t, game.StatusPlaying, g.Status)
+	require.Len(t, g.History, 1)
+	assert.Equal(t, "bob", g.PlayerToMove)
+}
+
+func TestPlayerJoin(t *testing.T) {
+	db := gametest.NewDatabase()
+	api := game.NewApi(db)
+	_, err := api.NewPlayer("alice")
+	require.NoError(t, err)
+	g, err := api.NewGame("alice", "")
+	require.NoError(t, err)
+
+	gameID, err := NewPlayer(api, "bot", &scriptedStrategy{}).Join(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, g.ID, gameID)
+
+	g, err = db.FindGameByID(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bot", g.Player2.Name)
+
+	_, err = NewPlayer(api, "bot", &scriptedStrategy{}).Join(g.ID)
+	assert.NoError(t, err, "joining again continues the game")
+
+	_, err = NewPlayer(api, "carol", &scriptedStrategy{}).Join(g.ID)
+	assert.ErrorIs(t, err, game.ErrorIllegal)
+}
+
+func TestPlayerForfeits(t *testing.T) {
+	api, _, g := gametest.NewGame(t)
+	gameID := g.ID
+
+	strategy := &scriptedStrategy{fleets: [][]Placement{nil, nil, nil}}
+	err := NewPlayer(api, "alice", strategy).Play(context.Background(), gameID)
+	assert.ErrorIs(t, err, game.ErrorInvalid)
+	assert.ErrorIs(t, err, ErrorForfeit)
+	assert.EqualError(t, err, "scripted forfeits after 3 invalid fleets: fleet is incomplete: invalid")
+}
+
+func TestPlayerReportsSunkShips(t *testing.T) {
+	api, _, g := gametest.NewGame(t)
+	gameID := g.ID
+
+	strategy := &scriptedStrategy{
+		fleets: [][]Placement{fleetOf(t, testFleet)},
+		shots:  [][2]int{{8, 6}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = NewPlayer(api, "alice", strategy).Play(ctx, gameID)
+	assert.Equal(t, []Outcome{{Hit: true}}, strategy.results)
+
+	bob := &scriptedStrategy{shots: [][2]int{{9, 9}}}
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = NewPlayer(api, "bob", bob).Play(ctx, gameID)
+	assert.Equal(t, []game.Move{{X: 8, Y: 6, Hit: true}}, bob.opponentShots)
+	assert.Equal(t, []Outcome{{Hit: false}}, bob.results)
+
+	strategy.shots = [][2]int{{9, 6}}
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = NewPlayer(api, "alice", strategy).Play(ctx, gameID)
+	assert.Equal(t, []Outcome{{Hit: true}, {Hit: true, Sunk: game.Submarine}}, strategy.results)
+}
+
+func TestEnginesPlayAGame(t *testing.T) {
+	api, db, created := gametest.NewGame(t)
+	gameID := created.ID
+
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	for _, name := range []string{"alice", "bob"} {
+		t.Setenv(testEngineEnv, name+"'s engine")
+		e, err := Start(nil, os.Args[0])
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = e.Close() })
+
+		player := NewPlayer(api, name, e)
+		player.PollInterval = 10 * time.Millisecond
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := player.Play(ctx, gameID)
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, errs["alice"])
+	assert.NoError(t, errs["bob"])
+
+	g, err := db.FindGameByID(gameID)
+	require.NoError(t, err)
+	assert.True(t, g.Finished())
+	assert.Equal(t, "alice", g.Winner(), "alice completed the last fleet, moved first and fires at the same fields")
+}
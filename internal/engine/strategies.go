@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// Builtins are the names of the strategies built into the adapter
+var Builtins = []string{"random", "hunter"}
+
+// NewBuiltin returns the built-in strategy with the given name, seeded for reproducible games
+func NewBuiltin(name string, seed1, seed2 uint64) (Strategy, error) {
+	rng := rand.New(rand.NewPCG(seed1, seed2))
+	switch name {
+	case "random":
+		return &randomStrategy{rng: rng}, nil
+	case "hunter":
+		return &hunterStrategy{randomStrategy{rng: rng}, nil}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q, the built-in strategies are %v: %w", name, Builtins, game.ErrorInvalidInput)
+	}
+}
+
+// RandomFleet returns a valid fleet with the ships at random positions
+func RandomFleet(rng *rand.Rand) []Placement {
+	for {
+		if fleet, ok := tryRandomFleet(rng); ok {
+			return fleet
+		}
+	}
+}
+
+// maxPlacementTries bounds the tries to place a ship before the fleet is started over, as the ships placed
+// first may leave no room for it
+const maxPlacementTries = 100
+
+func tryRandomFleet(rng *rand.Rand) ([]Placement, bool) {
+	board := game.NewBoard("", "")
+	toPlace := board.ShipsToPlace()
+
+	var fleet []Placement
+	for _, shipType := range game.ShipTypes {
+		for range toPlace[shipType] {
+			placed := false
+			for range maxPlacementTries {
+				p := Placement{Ship: shipType, X: rng.IntN(10), Y: rng.IntN(10), Orientation: game.OrientationHorizontal}
+				if rng.IntN(2) == 0 {
+					p.Orientation = game.OrientationVertical
+				}
+				if board.PlaceShip(p.Ship, p.X, p.Y, p.Orientation) == nil {
+					fleet = append(fleet, p)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				return nil, false
+			}
+		}
+	}
+	return fleet, true
+}
+
+type field struct{ x, y int }
+
+// randomStrategy places a random fleet and fires at random fields it didn't try yet
+type randomStrategy struct {
+	rng   *rand.Rand
+	tried [10][10]bool
+}
+
+func (s *randomStrategy) Name() string { return "random" }
+
+func (s *randomStrategy) NewGame(string, string) error {
+	s.tried = [10][10]bool{}
+	return nil
+}
+
+func (s *randomStrategy) Place() ([]Placement, error) {
+	return RandomFleet(s.rng), nil
+}
+
+func (s *randomStrategy) Fire() (int, int, error) {
+	f, ok := s.pick(func(f field) bool { return true })
+	if !ok {
+		return 0, 0, fmt.Errorf("no field left to fire at: %w", game.ErrorIllegal)
+	}
+	return f.x, f.y, nil
+}
+
+// pick returns a random field that wasn't tried yet and matches the filter
+func (s *randomStrategy) pick(filter func(field) bool) (field, bool) {
+	var fields []field
+	for y := range 10 {
+		for x := range 10 {
+			if f := (field{x, y}); !s.tried[y][x] && filter(f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return field{}, false
+	}
+	return fields[s.rng.IntN(len(fields))], true
+}
+
+func (s *randomStrategy) Result(x int, y int, _ Outcome) error {
+	s.tried[y][x] = true
+	return nil
+}
+
+func (s *randomStrategy) OpponentShot(int, int, Outcome) error { return nil }
+func (s *randomStrategy) GameOver(bool) error                  { return nil }
+func (s *randomStrategy) Error(string) error                   { return nil }
+func (s *randomStrategy) Close() error                         { return nil }
+
+// hunterStrategy hunts on a checkerboard pattern, as every ship covers at least two fields, and targets the
+// fields next to a hit until the ship is sunk. As ships may not touch, the fields around a sunk ship are
+// skipped.
+type hunterStrategy struct {
+	randomStrategy
+	// hits are the fields of the ship which is hit but not sunk yet
+	hits []field
+}
+
+func (s *hunterStrategy) Name() string { return "hunter" }
+
+func (s *hunterStrategy) NewGame(gameID string, opponent string) error {
+	s.hits = nil
+	return s.randomStrategy.NewGame(gameID, opponent)
+}
+
+func (s *hunterStrategy) Fire() (int, int, error) {
+	if f, ok := s.pick(s.target); ok {
+		return f.x, f.y, nil
+	}
+	if f, ok := s.pick(func(f field) bool { return (f.x+f.y)%2 == 0 }); ok {
+		return f.x, f.y, nil
+	}
+	return s.randomStrategy.Fire()
+}
+
+// target reports whether a field may continue the ship that was hit: next to a hit, and in line with the
+// hits once there are two of them
+func (s *hunterStrategy) target(f field) bool {
+	if len(s.hits) == 0 {
+		return false
+	}
+
+	horizontal := len(s.hits) > 1 && s.hits[0].y == s.hits[1].y
+	vertical := len(s.hits) > 1 && s.hits[0].x == s.hits[1].x
+	for _, hit := range s.hits {
+		dx, dy := abs(f.x-hit.x), abs(f.y-hit.y)
+		switch {
+		case dx+dy != 1:
+		case horizontal && dy == 0, vertical && dx == 0, !horizontal && !vertical:
+			return true
+		}
+	}
+	return false
+}
+
+func (s *hunterStrategy) Result(x int, y int, outcome Outcome) error {
+	s.tried[y][x] = true
+	if !outcome.Hit {
+		return nil
+	}
+
+	s.hits = append(s.hits, field{x, y})
+	if outcome.Sunk == "" {
+		return nil
+	}
+
+	for _, hit := range s.hits {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if nx, ny := hit.x+dx, hit.y+dy; nx >= 0 && nx < 10 && ny >= 0 && ny < 10 {
+					s.tried[ny][nx] = true
+				}
+			}
+		}
+	}
+	s.hits = slices.Delete(s.hits, 0, len(s.hits))
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
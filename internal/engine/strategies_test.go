@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+func TestRandomFleet(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		g := game.NewGame(&game.Player{Name: "alice"})
+		require.NoError(t, placeFleet(g, "alice", RandomFleet(rng)))
+	}
+}
+
+func TestBuiltinsPlayValidGames(t *testing.T) {
+	for _, name := range Builtins {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewBuiltin(name, 1, 2)
+			require.NoError(t, err)
+			assert.Equal(t, name, s.Name())
+
+			g := game.NewGame(&game.Player{Name: "alice"})
+			require.NoError(t, g.Join(&game.Player{Name: "bob"}))
+			require.NoError(t, s.NewGame("game1", "bob"))
+			require.NoError(t, PlaceFleet(g, "alice", s, 1))
+			require.NoError(t, placeFleet(g, "bob", fleetOf(t, testFleet)))
+			require.NoError(t, g.Start("alice"))
+
+			// bob fires row by row, so the game ends once either fleet is sunk
+			for shots := 0; !g.Finished(); shots++ {
+				require.NoError(t, Fire(g, "alice", s, 1), "a strategy never fires at a field twice")
+				if !g.Finished() {
+					require.NoError(t, g.MakeMove(game.Move{Player: "bob", X: shots % 10, Y: shots / 10}))
+				}
+			}
+			assert.True(t, g.Finished())
+		})
+	}
+
+	_, err := NewBuiltin("cheater", 1, 2)
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+}
+
+func TestHunterSkipsTheFieldsAroundSunkShips(t *testing.T) {
+	s, err := NewBuiltin("hunter", 1, 2)
+	require.NoError(t, err)
+	hunter := s.(*hunterStrategy)
+
+	require.NoError(t, hunter.Result(4, 4, Outcome{Hit: true}))
+	for range 10 {
+		x, y, err := hunter.Fire()
+		require.NoError(t, err)
+		assert.Equal(t, 1, abs(x-4)+abs(y-4), "the hunter targets the fields next to the hit")
+	}
+
+	require.NoError(t, hunter.Result(5, 4, Outcome{Hit: true, Sunk: game.Submarine}))
+	for y := 3; y <= 5; y++ {
+		for x := 3; x <= 6; x++ {
+			assert.True(t, hunter.tried[y][x], "field %s", game.FormatCoordinate(x, y))
+		}
+	}
+	assert.Empty(t, hunter.hits)
+}
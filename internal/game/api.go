@@ -1,17 +1,49 @@
 package game
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
 
 type API struct {
-	db Database
+	// MaxOpenGames limits the games in setup or in progress a player may have when creating or joining
+	// another one, 0 means no limit
+	MaxOpenGames int
+
+	db     Database
+	events *Broker
 }
 
 func NewApi(db Database) *API {
 	return &API{
-		db: db,
+		db:     db,
+		events: NewBroker(),
+	}
+}
+
+// WithContext returns the API with its database operations bound to the context, if the database supports
+// it. The returned API shares the events of A.
+func (A *API) WithContext(ctx context.Context) *API {
+	db, ok := A.db.(ContextDatabase)
+	if !ok {
+		return A
+	}
+	return &API{
+		MaxOpenGames: A.MaxOpenGames,
+		db:           db.WithContext(ctx),
+		events:       A.events,
 	}
 }
 
+// Subscribe returns the events of a game, or of all games if gameID is empty, until the returned function
+// is called
+func (A *API) Subscribe(gameID string) (<-chan Event, func()) {
+	return A.events.Subscribe(gameID)
+}
+
 func (A *API) GetPlayer(playerName string) (*Player, error) {
 	player, err := A.db.FindPlayerByName(playerName)
 	if err != nil {
@@ -33,21 +65,138 @@ func (A *API) NewPlayer(playerName string) (*Player, error) {
 }
 
 func (A *API) NewGame(player string, name string) (*Game, error) {
+	return A.createGame(player, name, VisibilityPublic)
+}
+
+// NewPrivateGame creates a game that is not listed and can only be joined with its invite code
+func (A *API) NewPrivateGame(player string, name string) (*Game, error) {
+	return A.createGame(player, name, VisibilityPrivate)
+}
+
+// maxInviteCodeAttempts bounds the retries when a generated invite code is already taken
+const maxInviteCodeAttempts = 5
+
+func (A *API) createGame(player string, name string, visibility Visibility) (*Game, error) {
 	p, err := A.db.FindPlayerByName(player)
 	if err != nil {
 		return nil, err
 	}
+	if err := A.checkOpenGames(player); err != nil {
+		return nil, err
+	}
 
-	game, err := A.db.CreateGame(NewGame(p, name))
+	g := NewGame(p, name)
+	g.Visibility = visibility
+	g.InviteCode, err = A.uniqueInviteCode()
 	if err != nil {
 		return nil, err
 	}
 
-	return game, nil
+	return A.storeNewGame(g)
+}
+
+func (A *API) uniqueInviteCode() (string, error) {
+	for range maxInviteCodeAttempts {
+		code, err := NewInviteCode()
+		if err != nil {
+			return "", err
+		}
+
+		_, err = A.db.FindGameByInviteCode(code)
+		if errors.Is(err, ErrorNotFound) {
+			return code, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("could not find an unused invite code: %w", ErrorAmbiguous)
+}
+
+// JoinGameByCode lets a player join the game with the given invite code
+func (A *API) JoinGameByCode(code string, playerName string) (*Game, error) {
+	g, err := A.db.FindGameByInviteCode(NormalizeInviteCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := A.db.FindPlayerByName(playerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := A.checkOpenGames(playerName); err != nil {
+		return nil, err
+	}
+
+	if err := g.JoinWithCode(player, code); err != nil {
+		return nil, err
+	}
+
+	return A.UpdateGame(g)
+}
+
+// JoinGame lets a player join a public game as player 2
+func (A *API) JoinGame(id string, playerName string) (*Game, error) {
+	g, err := A.db.FindGameByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := A.db.FindPlayerByName(playerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := A.checkOpenGames(playerName); err != nil {
+		return nil, err
+	}
+
+	if err := g.Join(player); err != nil {
+		return nil, err
+	}
+
+	return A.UpdateGame(g)
+}
+
+// checkOpenGames fails with ErrorLimitExceeded if one of the players has MaxOpenGames open games already
+func (A *API) checkOpenGames(playerNames ...string) error {
+	if A.MaxOpenGames <= 0 {
+		return nil
+	}
+
+	for _, playerName := range playerNames {
+		open, err := A.db.CountOpenGames(playerName)
+		if err != nil {
+			return err
+		}
+		if open >= A.MaxOpenGames {
+			return fmt.Errorf("%s already has %d open games, the maximum is %d: %w", playerName, open,
+				A.MaxOpenGames, ErrorLimitExceeded)
+		}
+	}
+	return nil
 }
 
 func (A *API) UpdateGame(g *Game) (*Game, error) {
-	return A.db.UpdateGame(g)
+	g.UpdatedAt = time.Now().UTC()
+	g, err := A.db.UpdateGame(g)
+	if err != nil {
+		return nil, err
+	}
+
+	A.events.Publish(newGameEvent(EventGameUpdated, g))
+	return g, nil
+}
+
+// storeNewGame creates the game in the database and announces it
+func (A *API) storeNewGame(g *Game) (*Game, error) {
+	g, err := A.db.CreateGame(g)
+	if err != nil {
+		return nil, err
+	}
+
+	A.events.Publish(newGameEvent(EventGameCreated, g))
+	return g, nil
 }
 
 func (A *API) Games(page int, count int) ([]*Game, error) {
@@ -59,6 +208,21 @@ func (A *API) Games(page int, count int) ([]*Game, error) {
 	return games, nil
 }
 
+// GameSummaries returns a page of redacted game summaries for listings
+func (A *API) GameSummaries(page int, count int) ([]Summary, error) {
+	games, err := A.Games(page, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return Summarize(games), nil
+}
+
+// CountGames returns the number of all games by status, private games included
+func (A *API) CountGames() (map[Status]int, error) {
+	return A.db.CountGamesByStatus()
+}
+
 func (A *API) GetGame(id string) (*Game, error) {
 	return A.db.FindGameByID(id)
 }
@@ -76,7 +240,338 @@ func (A *API) DeleteGame(id string) error {
 	return A.db.DeleteGame(id)
 }
 
+// CancelGame deletes a game nobody has joined yet, e.g. to make room for another one under MaxOpenGames.
+// Only the player who created the game may cancel it.
+func (A *API) CancelGame(id string, playerName string) error {
+	g, err := A.db.FindGameByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := g.CanWatch(playerName); err != nil {
+		return err
+	}
+	if err := g.CanCancel(playerName); err != nil {
+		return err
+	}
+
+	return A.db.DeleteGame(id)
+}
+
 // DeleteAllGames deletes all games
 func (A *API) DeleteAllGames() (int, error) {
 	return A.db.DeleteAllGames()
 }
+
+// FindMatch puts the player into the matchmaking queue. If a waiting player with a compatible request is
+// found, a game is created for both of them and the returned ticket refers to it. Otherwise the returned
+// ticket is waiting until another player is paired with it.
+func (A *API) FindMatch(playerName string, request MatchRequest) (*MatchTicket, error) {
+	player, err := A.db.FindPlayerByName(playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Preset, err = ParseRulePreset(string(request.Preset))
+	if err != nil {
+		return nil, err
+	}
+	if request.MinRating < 0 || (request.MaxRating != 0 && request.MaxRating < request.MinRating) {
+		return nil, fmt.Errorf("invalid rating range %d-%d: %w", request.MinRating, request.MaxRating, ErrorInvalidInput)
+	}
+
+	existing, err := A.db.FindMatchTicket(playerName)
+	switch {
+	case err == nil && (existing.Status == MatchStatusWaiting || existing.GameID == ""):
+		// the player waits, or a concurrent request of the player is starting the match
+		return existing, nil
+	case err == nil:
+		// the previous match has been reported already, a new request replaces it
+		if err := A.db.DeleteMatchTicket(playerName); err != nil && !errors.Is(err, ErrorNotFound) {
+			return nil, err
+		}
+	case !errors.Is(err, ErrorNotFound):
+		return nil, err
+	}
+	if err := A.checkOpenGames(playerName); err != nil {
+		return nil, err
+	}
+
+	ticket := newMatchTicket(player, request)
+	waiting, err := A.db.QueryMatchTickets(ticket.Preset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range waiting {
+		if !ticket.pairsWith(candidate) {
+			continue
+		}
+
+		claimed, err := A.db.ClaimMatchTicket(candidate.Player)
+		if errors.Is(err, ErrorNotFound) {
+			// somebody else was paired with this candidate in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// the candidate may have opened other games while waiting, then the ticket waits until one is over
+		if err := A.checkOpenGames(claimed.Player); err != nil {
+			if releaseErr := A.releaseMatchTicket(claimed); releaseErr != nil {
+				return nil, errors.Join(err, releaseErr)
+			}
+			if errors.Is(err, ErrorLimitExceeded) {
+				continue
+			}
+			return nil, err
+		}
+
+		return A.startMatch(claimed, player, ticket)
+	}
+
+	created, err := A.db.CreateMatchTicket(ticket)
+	if errors.Is(err, ErrorAmbiguous) {
+		// a concurrent request of the player queued a ticket first
+		return A.db.FindMatchTicket(playerName)
+	}
+	return created, err
+}
+
+// MatchStatus returns the player's current matchmaking ticket
+func (A *API) MatchStatus(playerName string) (*MatchTicket, error) {
+	return A.db.FindMatchTicket(playerName)
+}
+
+// CancelMatch removes the player's ticket from the matchmaking queue
+func (A *API) CancelMatch(playerName string) error {
+	return A.db.DeleteMatchTicket(playerName)
+}
+
+// startMatch creates the game for a claimed waiting ticket and the requesting player. The waiting player
+// becomes player 1. The ticket of the requesting player is stored first, so a concurrent request of the
+// player ends up with this match instead of a game nobody refers to. If the match cannot be started, the
+// claimed ticket is put back into the queue.
+func (A *API) startMatch(claimed *MatchTicket, player *Player, ticket *MatchTicket) (*MatchTicket, error) {
+	ticket.Status = MatchStatusMatched
+	ticket.Opponent = claimed.Player
+	created, err := A.db.CreateMatchTicket(ticket)
+	if err != nil {
+		if releaseErr := A.releaseMatchTicket(claimed); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		if errors.Is(err, ErrorAmbiguous) {
+			// a concurrent request of the player queued a ticket first
+			return A.db.FindMatchTicket(player.Name)
+		}
+		return nil, err
+	}
+
+	g, err := A.createMatchGame(claimed, player)
+	if err != nil {
+		releaseErr := A.releaseMatchTicket(claimed)
+		if deleteErr := A.db.DeleteMatchTicket(player.Name); deleteErr != nil && !errors.Is(deleteErr, ErrorNotFound) {
+			releaseErr = errors.Join(releaseErr, deleteErr)
+		}
+		if releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	claimed.GameID = g.ID
+	claimed.Opponent = player.Name
+	if _, err := A.db.UpdateMatchTicket(claimed); err != nil {
+		return nil, err
+	}
+
+	created.GameID = g.ID
+	return A.db.UpdateMatchTicket(created)
+}
+
+// releaseMatchTicket puts a claimed ticket back into the queue
+func (A *API) releaseMatchTicket(claimed *MatchTicket) error {
+	claimed.Status = MatchStatusWaiting
+	_, err := A.db.UpdateMatchTicket(claimed)
+	return err
+}
+
+func (A *API) createMatchGame(claimed *MatchTicket, player *Player) (*Game, error) {
+	opponent, err := A.db.FindPlayerByName(claimed.Player)
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGame(opponent)
+	g.Rules = claimed.Preset
+	if err := g.Join(player); err != nil {
+		return nil, err
+	}
+
+	return A.storeNewGame(g)
+}
+
+// RequestRematch records the player's wish to play a finished game again
+func (A *API) RequestRematch(gameID string, playerName string) (*Game, error) {
+	g, err := A.db.FindGameByID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.RequestRematch(playerName); err != nil {
+		return nil, err
+	}
+
+	return A.UpdateGame(g)
+}
+
+// AcceptRematch accepts the opponent's rematch request, creates the new game and links both games
+func (A *API) AcceptRematch(gameID string, playerName string) (*Game, error) {
+	g, err := A.db.FindGameByID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	rematch, err := g.Rematch(playerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := A.checkOpenGames(rematch.Player1.Name, rematch.Player2.Name); err != nil {
+		return nil, err
+	}
+
+	rematch.InviteCode, err = A.uniqueInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the rematch before creating its game, so that of two accepts at the same time only one starts it
+	if err := A.db.ClaimRematch(g.ID, rematchPending); err != nil {
+		if errors.Is(err, ErrorNotFound) {
+			return nil, fmt.Errorf("the rematch has already been started: %w", ErrorIllegal)
+		}
+		return nil, err
+	}
+
+	rematch, err = A.storeNewGame(rematch)
+	if err != nil {
+		// g still has no rematch ID, storing it releases the claim
+		if _, releaseErr := A.db.UpdateGame(g); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	g.RematchID = rematch.ID
+	if _, err := A.UpdateGame(g); err != nil {
+		return nil, err
+	}
+
+	return rematch, nil
+}
+
+// NewGameBetween creates a game that both players have already joined, e.g. for a tournament pairing
+func (A *API) NewGameBetween(player1 string, player2 string, name string) (*Game, error) {
+	p1, err := A.db.FindPlayerByName(player1)
+	if err != nil {
+		return nil, err
+	}
+
+	p2, err := A.db.FindPlayerByName(player2)
+	if err != nil {
+		return nil, err
+	}
+	if err := A.checkOpenGames(player1, player2); err != nil {
+		return nil, err
+	}
+
+	g := NewGame(p1, name)
+	if err := g.Join(p2); err != nil {
+		return nil, err
+	}
+
+	g.InviteCode, err = A.uniqueInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return A.storeNewGame(g)
+}
+
+// ExportGame returns the portable form of a game, see Export
+func (A *API) ExportGame(id string) (*Export, error) {
+	g, err := A.db.FindGameByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Export()
+}
+
+// ImportGame replays an exported game and stores the result as a new public game. Players missing in this
+// environment are created.
+func (A *API) ImportGame(e *Export) (*Game, error) {
+	g, err := e.Replay()
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Player1, err = A.NewPlayer(g.Player1.Name); err != nil {
+		return nil, err
+	}
+	if len(g.Boards) > 1 {
+		if g.Player2, err = A.NewPlayer(g.Player2.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	g.InviteCode, err = A.uniqueInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return A.storeNewGame(g)
+}
+
+// SendChatMessage posts a message to the chat of a game. Only the players of the game may chat.
+func (A *API) SendChatMessage(gameID string, playerName string, text string) (*ChatMessage, error) {
+	g, err := A.db.FindGameByID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := g.Boards[playerName]; !ok {
+		return nil, fmt.Errorf("only players can chat in this game, %s: %w", playerName, ErrorIllegal)
+	}
+
+	message, err := NewChatMessage(g.ID, playerName, text)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err = A.db.CreateChatMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	A.events.Publish(Event{
+		Type:    EventChatMessage,
+		GameID:  g.ID,
+		Time:    message.CreatedAt,
+		Message: message,
+	})
+	return message, nil
+}
+
+// ChatMessages returns a page of the chat of a game. Page 0 holds the latest messages, the messages of a
+// page are in the order they were written.
+func (A *API) ChatMessages(gameID string, page int, count int) ([]*ChatMessage, error) {
+	messages, err := A.db.QueryChatMessages(gameID, page, count)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(messages)
+	return messages, nil
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxOpenGames(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	api.MaxOpenGames = 2
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	first, err := api.NewGame("alice", "first")
+	require.NoError(t, err)
+	_, err = api.NewPrivateGame("alice", "second")
+	require.NoError(t, err)
+
+	_, err = api.NewGame("alice", "third")
+	assert.ErrorIs(t, err, ErrorLimitExceeded)
+
+	bobs, err := api.NewGame("bob", "bobs")
+	require.NoError(t, err)
+	_, err = api.JoinGame(bobs.ID, "alice")
+	assert.ErrorIs(t, err, ErrorLimitExceeded)
+	_, err = api.JoinGameByCode(bobs.InviteCode, "alice")
+	assert.ErrorIs(t, err, ErrorLimitExceeded)
+	_, err = api.FindMatch("alice", MatchRequest{})
+	assert.ErrorIs(t, err, ErrorLimitExceeded)
+
+	// finished games do not count
+	first.Status = StatusWon
+	joined, err := api.JoinGame(bobs.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", joined.Player2.Name)
+
+	// without a limit there is none
+	api.MaxOpenGames = 0
+	_, err = api.NewGame("alice", "fourth")
+	assert.NoError(t, err)
+}
+
+func TestMaxOpenGamesOfBothPlayers(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	api.MaxOpenGames = 1
+	_, _ = db.CreatePlayer("player1")
+	_, _ = db.CreatePlayer("player2")
+
+	finished, err := createFinishedGame()
+	require.NoError(t, err)
+	finished.ID = "finished"
+	db.games[finished.ID] = finished
+	_, err = api.RequestRematch(finished.ID, "player2")
+	require.NoError(t, err)
+
+	open, err := api.NewGame("player2", "open")
+	require.NoError(t, err)
+
+	_, err = api.NewGameBetween("player1", "player2", "pairing")
+	assert.ErrorIs(t, err, ErrorLimitExceeded, "player 2 has an open game")
+	_, err = api.AcceptRematch(finished.ID, "player1")
+	assert.ErrorIs(t, err, ErrorLimitExceeded, "player 2 has an open game")
+
+	require.NoError(t, api.CancelGame(open.ID, "player2"))
+	_, err = api.AcceptRematch(finished.ID, "player1")
+	assert.NoError(t, err)
+}
+
+func TestFindMatchSkipsOpponentOverTheLimit(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	api.MaxOpenGames = 1
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	_, err := api.FindMatch("bob", MatchRequest{})
+	require.NoError(t, err)
+	_, err = api.NewGame("bob", "while waiting")
+	require.NoError(t, err)
+
+	ticket, err := api.FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, ticket.Status, "bob has to finish his game first")
+
+	bobs, err := api.MatchStatus("bob")
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, bobs.Status, "bob's ticket is back in the queue")
+}
+
+func TestCancelGame(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	private, err := api.NewPrivateGame("alice", "private")
+	require.NoError(t, err)
+	err = api.CancelGame(private.ID, "bob")
+	assert.ErrorIs(t, err, ErrorNotFound, "private games don't exist for other players")
+
+	public, err := api.NewGame("alice", "public")
+	require.NoError(t, err)
+	err = api.CancelGame(public.ID, "bob")
+	assert.ErrorIs(t, err, ErrorIllegal)
+
+	_, err = api.JoinGame(public.ID, "bob")
+	require.NoError(t, err)
+	err = api.CancelGame(public.ID, "alice")
+	assert.ErrorIs(t, err, ErrorInvalid, "bob joined already")
+
+	require.NoError(t, api.CancelGame(private.ID, "alice"))
+	_, err = api.GetGame(private.ID)
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+func TestCountGames(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+
+	_, err := api.NewGame("alice", "public")
+	require.NoError(t, err)
+	private, err := api.NewPrivateGame("alice", "private")
+	require.NoError(t, err)
+	private.Status = StatusPlaying
+
+	counts, err := api.CountGames()
+	require.NoError(t, err)
+	assert.Equal(t, map[Status]int{StatusSetup: 1, StatusPlaying: 1}, counts)
+}
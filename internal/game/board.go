@@ -2,6 +2,8 @@ package game
 
 import (
 	"fmt"
+	"io"
+	"os"
 )
 
 type BoardMap struct {
@@ -24,11 +26,23 @@ func (m *BoardMap) Set(x int, y int, fieldState FieldState) {
 
 func (m *BoardMap) Print() {
 	fmt.Println(m.Title)
-	for _, row := range m.Map {
+	m.WriteGrid(os.Stdout)
+}
+
+// WriteGrid writes the map with the column letters A–J as header and the row numbers 1–10 in front of each row
+func (m *BoardMap) WriteGrid(w io.Writer) {
+	fmt.Fprint(w, "  ")
+	for x := range m.Map[0] {
+		fmt.Fprintf(w, " %s", ColumnLabel(x))
+	}
+	fmt.Fprintln(w)
+
+	for y, row := range m.Map {
+		fmt.Fprintf(w, "%2s", RowLabel(y))
 		for _, field := range row {
-			fmt.Printf("%c", field)
+			fmt.Fprintf(w, " %c", field)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 }
 
@@ -271,6 +285,17 @@ func (b *Board) validateShipPlacement(p ShipPlacement) error {
 	return nil
 }
 
+// ShipsToPlace returns how many ships of each type can still be placed
+func (b *Board) ShipsToPlace() map[ShipType]int {
+	toPlace := make(map[ShipType]int, len(shipsAllowed))
+	for shipType, allowed := range shipsAllowed {
+		if left := allowed - len(b.Fleet.Filter(byShipType(shipType))); left > 0 {
+			toPlace[shipType] = left
+		}
+	}
+	return toPlace
+}
+
 func (b *Board) hasAvailableShipSlot(shipType ShipType) bool {
 	currentCount := len(b.Fleet.Filter(byShipType(shipType)))
 	allowedCount, exists := shipsAllowed[shipType]
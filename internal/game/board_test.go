@@ -261,3 +261,12 @@ func TestBoardRemoveShip(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrorNotFound)
 }
+
+func TestBoardShipsToPlace(t *testing.T) {
+	board := NewBoard("player", "opponent")
+	assert.Equal(t, map[ShipType]int{Battleship: 1, Cruiser: 2, Destroyer: 3, Submarine: 4}, board.ShipsToPlace())
+
+	assert.NoError(t, board.PlaceShip(Battleship, 0, 0, OrientationHorizontal))
+	assert.NoError(t, board.PlaceShip(Cruiser, 0, 2, OrientationHorizontal))
+	assert.Equal(t, map[ShipType]int{Cruiser: 1, Destroyer: 3, Submarine: 4}, board.ShipsToPlace())
+}
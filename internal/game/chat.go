@@ -0,0 +1,60 @@
+package game
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxChatMessageLength is the maximum number of characters of a chat message
+const MaxChatMessageLength = 280
+
+type ChatMessage struct {
+	ID        string    `json:"id,omitempty" bson:"id,omitempty"`
+	GameID    string    `json:"game_id" bson:"game_id"`
+	Player    string    `json:"player" bson:"player"`
+	Text      string    `json:"text" bson:"text"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// blockedWords are masked in chat messages
+var blockedWords = []string{
+	"asshole",
+	"bastard",
+	"bitch",
+	"bollocks",
+	"crap",
+	"damn",
+	"fuck",
+	"shit",
+}
+
+var blockedWordsPattern = regexp.MustCompile(`(?i)\b(` + strings.Join(blockedWords, "|") + `)\w*`)
+
+// NewChatMessage validates the text of a message and masks blocked words
+func NewChatMessage(gameID string, playerName string, text string) (*ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("chat message cannot be empty: %w", ErrorInvalidInput)
+	}
+
+	if length := utf8.RuneCountInString(text); length > MaxChatMessageLength {
+		return nil, fmt.Errorf("chat message is too long (%d > %d characters): %w", length, MaxChatMessageLength, ErrorInvalidInput)
+	}
+
+	return &ChatMessage{
+		GameID:    gameID,
+		Player:    playerName,
+		Text:      FilterChatText(text),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// FilterChatText replaces blocked words, including words starting with them, by asterisks
+func FilterChatText(text string) string {
+	return blockedWordsPattern.ReplaceAllStringFunc(text, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+}
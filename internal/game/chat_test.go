@@ -0,0 +1,99 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterChatText(t *testing.T) {
+	assert.Equal(t, "good game", FilterChatText("good game"))
+	assert.Equal(t, "oh **** that was close", FilterChatText("oh damn that was close"))
+	assert.Equal(t, "****** miss", FilterChatText("SHITTY miss"))
+	assert.Equal(t, "scrappy move", FilterChatText("scrappy move"))
+}
+
+func TestNewChatMessage(t *testing.T) {
+	_, err := NewChatMessage("game1", "alice", "   ")
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	_, err = NewChatMessage("game1", "alice", strings.Repeat("a", MaxChatMessageLength+1))
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	message, err := NewChatMessage("game1", "alice", strings.Repeat("ä", MaxChatMessageLength))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", message.Player)
+
+	message, err = NewChatMessage("game1", "alice", "  hello  ")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", message.Text)
+	assert.False(t, message.CreatedAt.IsZero())
+}
+
+func TestSendChatMessage(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+	_, _ = db.CreatePlayer("carol")
+
+	g, err := api.NewGameBetween("alice", "bob", "chatty")
+	require.NoError(t, err)
+
+	events, unsubscribe := api.Subscribe(g.ID)
+	defer unsubscribe()
+
+	_, err = api.SendChatMessage(g.ID, "carol", "hi")
+	assert.ErrorIs(t, err, ErrorIllegal)
+
+	_, err = api.SendChatMessage("unknown", "alice", "hi")
+	assert.ErrorIs(t, err, ErrorNotFound)
+
+	for _, text := range []string{"first", "second", "third"} {
+		_, err = api.SendChatMessage(g.ID, "alice", text)
+		require.NoError(t, err)
+	}
+
+	e := <-events
+	assert.Equal(t, EventChatMessage, e.Type)
+	assert.Equal(t, g.ID, e.GameID)
+	assert.Equal(t, "first", e.Message.Text)
+
+	messages, err := api.ChatMessages(g.ID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "second", messages[0].Text)
+	assert.Equal(t, "third", messages[1].Text)
+
+	messages, err = api.ChatMessages(g.ID, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "first", messages[0].Text)
+}
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker()
+	game1, unsubscribe1 := broker.Subscribe("game1")
+	all, unsubscribeAll := broker.Subscribe("")
+	defer unsubscribeAll()
+
+	broker.Publish(Event{Type: EventGameUpdated, GameID: "game1"})
+	broker.Publish(Event{Type: EventGameUpdated, GameID: "game2"})
+
+	assert.Equal(t, "game1", (<-game1).GameID)
+	assert.Len(t, game1, 0)
+	assert.Equal(t, "game1", (<-all).GameID)
+	assert.Equal(t, "game2", (<-all).GameID)
+
+	unsubscribe1()
+	unsubscribe1()
+	_, open := <-game1
+	assert.False(t, open)
+
+	for i := 0; i < eventBufferSize+10; i++ {
+		broker.Publish(Event{Type: EventGameUpdated, GameID: "game1"})
+	}
+	assert.Len(t, all, eventBufferSize)
+}
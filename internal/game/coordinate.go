@@ -0,0 +1,86 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// boardSize is the number of columns and rows of a board
+const boardSize = 10
+
+// ParseCoordinate reads a field in letter-number notation like "B7". The letter names the column A–J (x) and
+// the number the row 1–10 (y), so "A1" is (0,0) and "J10" is (9,9). Letters are case-insensitive.
+func ParseCoordinate(s string) (x int, y int, err error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 || s[0] < 'A' || s[0] >= 'A'+boardSize {
+		return 0, 0, fmt.Errorf("invalid coordinate %q, expected A1 to J10: %w", s, ErrorInvalidInput)
+	}
+
+	row, err := strconv.Atoi(s[1:])
+	if err != nil || row < 1 || row > boardSize || s[1] == '+' || s[1] == '0' {
+		return 0, 0, fmt.Errorf("invalid coordinate %q, expected A1 to J10: %w", s, ErrorInvalidInput)
+	}
+
+	return int(s[0] - 'A'), row - 1, nil
+}
+
+// FormatCoordinate returns the letter-number notation of a field, e.g. "B7" for (1,6). Fields off the board
+// are formatted as "(x,y)".
+func FormatCoordinate(x int, y int) string {
+	if x < 0 || y < 0 || x >= boardSize || y >= boardSize {
+		return fmt.Sprintf("(%d,%d)", x, y)
+	}
+	return ColumnLabel(x) + RowLabel(y)
+}
+
+// ColumnLabel returns the letter of a column, e.g. "A" for x = 0
+func ColumnLabel(x int) string {
+	return string(rune('A' + x))
+}
+
+// RowLabel returns the number of a row, e.g. "1" for y = 0
+func RowLabel(y int) string {
+	return strconv.Itoa(y + 1)
+}
+
+// moveJSON is the wire form of a Move. Clients may name the field either by x and y or by its coordinate.
+type moveJSON struct {
+	Player     string `json:"player"`
+	Hit        bool   `json:"hit"`
+	X          int    `json:"x"`
+	Y          int    `json:"y"`
+	Coordinate string `json:"coordinate,omitempty"`
+}
+
+// MarshalJSON adds the coordinate in letter-number notation to x and y
+func (m Move) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moveJSON{
+		Player:     m.Player,
+		Hit:        m.Hit,
+		X:          m.X,
+		Y:          m.Y,
+		Coordinate: FormatCoordinate(m.X, m.Y),
+	})
+}
+
+// UnmarshalJSON accepts a coordinate like "B7" instead of x and y. If both are given, the coordinate is used.
+func (m *Move) UnmarshalJSON(data []byte) error {
+	var raw moveJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = Move{Player: raw.Player, Hit: raw.Hit, X: raw.X, Y: raw.Y}
+	if raw.Coordinate == "" {
+		return nil
+	}
+
+	x, y, err := ParseCoordinate(raw.Coordinate)
+	if err != nil {
+		return err
+	}
+	m.X, m.Y = x, y
+	return nil
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		x, y  int
+	}{
+		{"A1", 0, 0},
+		{"b7", 1, 6},
+		{" J10 ", 9, 9},
+		{"E5", 4, 4},
+	}
+	for _, tc := range tests {
+		x, y, err := ParseCoordinate(tc.input)
+		require.NoError(t, err, tc.input)
+		assert.Equal(t, tc.x, x, tc.input)
+		assert.Equal(t, tc.y, y, tc.input)
+		assert.Equal(t, strings.ToUpper(strings.TrimSpace(tc.input)), FormatCoordinate(x, y))
+	}
+
+	for _, input := range []string{"", "A", "K1", "A0", "A11", "A01", "A+1", "A-1", "7B", "1"} {
+		_, _, err := ParseCoordinate(input)
+		assert.ErrorIs(t, err, ErrorInvalidInput, input)
+	}
+
+	assert.Equal(t, "(10,3)", FormatCoordinate(10, 3))
+}
+
+func TestMoveJSON(t *testing.T) {
+	data, err := json.Marshal(Move{Player: "alice", X: 1, Y: 6})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"player": "alice", "hit": false, "x": 1, "y": 6, "coordinate": "B7"}`, string(data))
+
+	var move Move
+	require.NoError(t, json.Unmarshal([]byte(`{"coordinate": "C3"}`), &move))
+	assert.Equal(t, Move{X: 2, Y: 2}, move)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"x": 4, "y": 5}`), &move))
+	assert.Equal(t, Move{X: 4, Y: 5}, move)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"coordinate": "Z9"}`), &move))
+}
+
+func TestBoardMapWriteGrid(t *testing.T) {
+	board := NewBoard("alice", "bob")
+	require.NoError(t, board.PlaceShip(Submarine, 1, 9, OrientationHorizontal))
+
+	var out bytes.Buffer
+	board.ShipsMap().WriteGrid(&out)
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	require.Len(t, lines, 11)
+	assert.Equal(t, "   A B C D E F G H I J", lines[0])
+	assert.Equal(t, " 1                    ", lines[1])
+	assert.Equal(t, "10   O O              ", lines[10])
+}
@@ -9,4 +9,6 @@ var (
 	ErrorInvalid      = errors.New("invalid")
 	ErrorAmbiguous    = errors.New("duplicate")
 	ErrorInvalidInput = errors.New("invalid input")
+	// ErrorLimitExceeded rejects an action which would exceed a limit, e.g. API.MaxOpenGames
+	ErrorLimitExceeded = errors.New("limit exceeded")
 )
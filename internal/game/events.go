@@ -0,0 +1,94 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventGameCreated EventType = "game.created"
+	EventGameUpdated EventType = "game.updated"
+	EventChatMessage EventType = "chat.message"
+)
+
+// Event notifies subscribers about something that happened in a game. Game events only carry the redacted
+// Summary, subscribers interested in a player's board fetch the game from the point of view of that player.
+type Event struct {
+	Type    EventType    `json:"type"`
+	GameID  string       `json:"game_id"`
+	Time    time.Time    `json:"time"`
+	Game    *Summary     `json:"game,omitempty"`
+	Message *ChatMessage `json:"message,omitempty"`
+}
+
+func newGameEvent(eventType EventType, g *Game) Event {
+	summary := g.Summary()
+	return Event{
+		Type:   eventType,
+		GameID: g.ID,
+		Time:   time.Now().UTC(),
+		Game:   &summary,
+	}
+}
+
+// eventBufferSize is the number of events a subscriber may fall behind before events are dropped for it
+const eventBufferSize = 64
+
+type subscription struct {
+	gameID string
+	events chan Event
+}
+
+// Broker distributes events to subscribers within the process. Publishing never blocks: a subscriber
+// that does not keep up misses events.
+type Broker struct {
+	mu            sync.RWMutex
+	subscriptions map[*subscription]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscriptions: make(map[*subscription]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the events of a game, or of all games if gameID is empty. The
+// returned function ends the subscription and closes the channel.
+func (b *Broker) Subscribe(gameID string) (<-chan Event, func()) {
+	s := &subscription{
+		gameID: gameID,
+		events: make(chan Event, eventBufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscriptions[s] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return s.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscriptions, s)
+			b.mu.Unlock()
+			close(s.events)
+		})
+	}
+}
+
+func (b *Broker) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for s := range b.subscriptions {
+		if s.gameID != "" && s.gameID != e.GameID {
+			continue
+		}
+
+		select {
+		case s.events <- e:
+		default:
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// ExportFormat identifies exported games, see docs/GAME_FORMAT.md
+	ExportFormat = "battleship-game"
+	// ExportVersion is the version of the export format written by Export
+	ExportVersion = 1
+)
+
+// ExportedShip is the position of a ship as it was placed, regardless of hits
+type ExportedShip struct {
+	Type        ShipType        `json:"type"`
+	X           int             `json:"x"`
+	Y           int             `json:"y"`
+	Orientation ShipOrientation `json:"orientation"`
+}
+
+// Export is the portable form of a game: its rules, players, fleet layouts and moves in order. Everything else,
+// like the boards and the status, follows from replaying it.
+type Export struct {
+	Format  string     `json:"format"`
+	Version int        `json:"version"`
+	Name    string     `json:"name,omitempty"`
+	Rules   RulePreset `json:"rules"`
+	// Players lists player 1 and, once joined, player 2
+	Players []string                  `json:"players"`
+	Fleets  map[string][]ExportedShip `json:"fleets"`
+	// FirstToMove is set once the game was started
+	FirstToMove string    `json:"first_to_move,omitempty"`
+	Moves       []Move    `json:"moves"`
+	ExportedAt  time.Time `json:"exported_at"`
+}
+
+// Export returns the portable form of the game
+func (g *Game) Export() (*Export, error) {
+	e := &Export{
+		Format:     ExportFormat,
+		Version:    ExportVersion,
+		Name:       g.Name,
+		Rules:      g.Rules,
+		Players:    []string{g.Player1.Name},
+		Fleets:     make(map[string][]ExportedShip, len(g.Boards)),
+		Moves:      append([]Move{}, g.History...),
+		ExportedAt: time.Now().UTC(),
+	}
+
+	if len(g.Boards) > 1 {
+		e.Players = append(e.Players, g.Player2.Name)
+	}
+
+	for _, player := range e.Players {
+		layout, err := fleetLayout(g.Boards[player])
+		if err != nil {
+			return nil, fmt.Errorf("could not export fleet of %s: %w", player, err)
+		}
+		e.Fleets[player] = layout
+	}
+
+	switch {
+	case len(g.History) > 0:
+		e.FirstToMove = g.History[0].Player
+	case g.Status == StatusPlaying:
+		e.FirstToMove = g.PlayerToMove
+	}
+
+	return e, nil
+}
+
+// ParseExport decodes an exported game and checks its format and version
+func ParseExport(data []byte) (*Export, error) {
+	var e Export
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("could not read exported game: %v: %w", err, ErrorInvalidInput)
+	}
+
+	if e.Format != ExportFormat {
+		return nil, fmt.Errorf("not an exported game, format is %q: %w", e.Format, ErrorInvalidInput)
+	}
+
+	if e.Version < 1 || e.Version > ExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d, supported up to %d: %w", e.Version, ExportVersion, ErrorInvalidInput)
+	}
+
+	return &e, nil
+}
+
+// Replay builds a new game from the export by placing the fleets, starting the game and making every move
+// through MakeMove, so an export with illegal placements or moves is rejected.
+func (e *Export) Replay() (*Game, error) {
+	rules, err := ParseRulePreset(string(e.Rules))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e.Players) < 1 || len(e.Players) > 2 {
+		return nil, fmt.Errorf("a game has one or two players, got %d: %w", len(e.Players), ErrorInvalidInput)
+	}
+
+	for _, player := range e.Players {
+		if player == "" {
+			return nil, fmt.Errorf("player names cannot be empty: %w", ErrorInvalidInput)
+		}
+	}
+
+	g := NewGame(&Player{Name: e.Players[0]}, e.Name)
+	g.Rules = rules
+	if len(e.Players) == 2 {
+		if err := g.join(&Player{Name: e.Players[1]}); err != nil {
+			return nil, fmt.Errorf("could not join %s: %v: %w", e.Players[1], err, ErrorInvalidInput)
+		}
+	}
+
+	for player, fleet := range e.Fleets {
+		if _, ok := g.Boards[player]; !ok {
+			return nil, fmt.Errorf("fleet of unknown player %s: %w", player, ErrorInvalidInput)
+		}
+
+		for _, ship := range fleet {
+			if err := g.PlaceShip(player, ship.Type, ship.X, ship.Y, ship.Orientation); err != nil {
+				return nil, fmt.Errorf("could not place %s of %s at (%d,%d): %v: %w", ship.Type, player, ship.X, ship.Y, err, ErrorInvalidInput)
+			}
+		}
+	}
+
+	if e.FirstToMove == "" {
+		if len(e.Moves) > 0 {
+			return nil, fmt.Errorf("moves of a game that was not started: %w", ErrorInvalidInput)
+		}
+		return g, nil
+	}
+
+	if _, ok := g.Boards[e.FirstToMove]; !ok || len(g.Boards) < 2 {
+		return nil, fmt.Errorf("%s cannot move first: %w", e.FirstToMove, ErrorInvalidInput)
+	}
+
+	g.FirstToMove = e.FirstToMove
+	if err := g.Start(e.FirstToMove); err != nil {
+		return nil, fmt.Errorf("could not start the game: %v: %w", err, ErrorInvalidInput)
+	}
+
+	for i, move := range e.Moves {
+		if err := g.MakeMove(Move{Player: move.Player, X: move.X, Y: move.Y}); err != nil {
+			return nil, fmt.Errorf("move %d by %s at (%d,%d): %v: %w", i+1, move.Player, move.X, move.Y, err, ErrorInvalidInput)
+		}
+
+		if g.History[i].Hit != move.Hit {
+			return nil, fmt.Errorf("move %d by %s at (%d,%d) is recorded as hit=%t but replays as hit=%t: %w",
+				i+1, move.Player, move.X, move.Y, move.Hit, g.History[i].Hit, ErrorInvalidInput)
+		}
+	}
+
+	return g, nil
+}
+
+// fleetLayout recovers the placement of all ships, including sunk ones which are no longer part of the fleet,
+// from the ships map. Ships never touch each other, so every run of pins and hits is exactly one ship.
+func fleetLayout(b *Board) ([]ExportedShip, error) {
+	ships := b.ShipsMap()
+	occupied := func(x, y int) bool {
+		if b.offBoard(x, y) {
+			return false
+		}
+		state := ships.FieldState(x, y)
+		return state == FieldStatePin || state == FieldStateHit
+	}
+
+	layout := make([]ExportedShip, 0, FleetSizeAllowed)
+	for y := range 10 {
+		for x := range 10 {
+			// only start at the top left end of a ship
+			if !occupied(x, y) || occupied(x-1, y) || occupied(x, y-1) {
+				continue
+			}
+
+			ship := ExportedShip{X: x, Y: y, Orientation: OrientationHorizontal}
+			length := 1
+			for occupied(x+length, y) {
+				length++
+			}
+			if length == 1 {
+				ship.Orientation = OrientationVertical
+				for occupied(x, y+length) {
+					length++
+				}
+			}
+
+			ship.Type = shipTypeOfLength(length)
+			if ship.Type == InvalidShip {
+				return nil, fmt.Errorf("no ship has length %d at (%d,%d): %w", length, x, y, ErrorInvalid)
+			}
+			layout = append(layout, ship)
+		}
+	}
+
+	return layout, nil
+}
+
+func shipTypeOfLength(length int) ShipType {
+	for shipType := range shipsAllowed {
+		if shipLength(shipType) == length {
+			return shipType
+		}
+	}
+	return InvalidShip
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPlayedGame(t *testing.T) *Game {
+	game, err := createReadyGame()
+	require.NoError(t, err)
+	require.NoError(t, game.Start("player1"))
+
+	// player1 sinks the submarine of player2 at (0,8)
+	for _, move := range []Move{
+		{Player: "player1", X: 0, Y: 8},
+		{Player: "player2", X: 9, Y: 9},
+		{Player: "player1", X: 1, Y: 8},
+	} {
+		require.NoError(t, game.MakeMove(move))
+	}
+	return game
+}
+
+func TestGameExport(t *testing.T) {
+	game := createPlayedGame(t)
+	assert.True(t, game.History[0].Hit)
+	assert.False(t, game.History[1].Hit)
+	assert.Len(t, game.Boards["player2"].Fleet, FleetSizeAllowed-1)
+
+	export, err := game.Export()
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormat, export.Format)
+	assert.Equal(t, ExportVersion, export.Version)
+	assert.Equal(t, []string{"player1", "player2"}, export.Players)
+	assert.Equal(t, "player1", export.FirstToMove)
+	assert.Len(t, export.Moves, 3)
+
+	// the sunk submarine is part of the layout
+	require.Len(t, export.Fleets["player2"], FleetSizeAllowed)
+	assert.Contains(t, export.Fleets["player2"], ExportedShip{Type: Submarine, X: 0, Y: 8, Orientation: OrientationHorizontal})
+	assert.Contains(t, export.Fleets["player1"], ExportedShip{Type: Battleship, X: 0, Y: 0, Orientation: OrientationHorizontal})
+}
+
+func TestGameImportRoundTrip(t *testing.T) {
+	game := createPlayedGame(t)
+	export, err := game.Export()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	parsed, err := ParseExport(data)
+	require.NoError(t, err)
+
+	replayed, err := parsed.Replay()
+	require.NoError(t, err)
+	assert.Equal(t, game.Status, replayed.Status)
+	assert.Equal(t, game.PlayerToMove, replayed.PlayerToMove)
+	assert.Equal(t, game.History, replayed.History)
+	for _, player := range []string{"player1", "player2"} {
+		assert.Equal(t, game.Boards[player].Maps, replayed.Boards[player].Maps)
+		assert.Len(t, replayed.Boards[player].Fleet, len(game.Boards[player].Fleet))
+	}
+}
+
+func TestGameImportValidation(t *testing.T) {
+	_, err := ParseExport([]byte(`{"format": "chess"}`))
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	_, err = ParseExport([]byte(`{"format": "battleship-game", "version": 99}`))
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	_, err = ParseExport([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	tests := []struct {
+		name   string
+		modify func(e *Export)
+	}{
+		{"wrong hit", func(e *Export) { e.Moves[1].Hit = true }},
+		{"wrong turn", func(e *Export) { e.Moves[1].Player = "player1" }},
+		{"not started", func(e *Export) { e.FirstToMove = "" }},
+		{"overlapping ships", func(e *Export) { e.Fleets["player1"][1].Y = 1 }},
+		{"unknown player", func(e *Export) { e.Fleets["player3"] = e.Fleets["player1"] }},
+		{"unknown rules", func(e *Export) { e.Rules = "salvo" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			export, err := createPlayedGame(t).Export()
+			require.NoError(t, err)
+			tc.modify(export)
+
+			_, err = export.Replay()
+			assert.ErrorIs(t, err, ErrorInvalidInput)
+		})
+	}
+}
+
+func TestImportGame(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+
+	export, err := createPlayedGame(t).Export()
+	require.NoError(t, err)
+
+	g, err := api.ImportGame(export)
+	require.NoError(t, err)
+	assert.NotEmpty(t, g.ID)
+	assert.NotEmpty(t, g.InviteCode)
+	assert.Len(t, g.History, 3)
+
+	// the players did not exist in this environment
+	_, err = api.GetPlayer("player2")
+	assert.NoError(t, err)
+
+	exported, err := api.ExportGame(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, export.Fleets, exported.Fleets)
+	assert.Equal(t, export.Moves, exported.Moves)
+}
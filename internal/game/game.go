@@ -1,7 +1,9 @@
 package game
 
 import (
+	"context"
 	"fmt"
+	"time"
 )
 
 type Database interface {
@@ -12,9 +14,34 @@ type Database interface {
 	CreateGame(game *Game) (*Game, error)
 	FindGameByID(id string) (*Game, error)
 	FindGameByName(name string) (*Game, error)
+	FindGameByInviteCode(code string) (*Game, error)
 	UpdateGame(g *Game) (*Game, error)
+	// ClaimRematch sets the rematch ID of a game which has none yet. It returns ErrorNotFound if there is no
+	// such game, e.g. because another request claimed the rematch first.
+	ClaimRematch(gameID string, rematchID string) error
 	DeleteGame(id string) error
 	DeleteAllGames() (int, error)
+	// CountOpenGames counts the games in setup or in progress the player takes part in
+	CountOpenGames(playerName string) (int, error)
+	// CountGamesByStatus counts all games, private ones included, by status
+	CountGamesByStatus() (map[Status]int, error)
+
+	CreateMatchTicket(ticket *MatchTicket) (*MatchTicket, error)
+	FindMatchTicket(playerName string) (*MatchTicket, error)
+	QueryMatchTickets(preset RulePreset) ([]*MatchTicket, error)
+	ClaimMatchTicket(playerName string) (*MatchTicket, error)
+	UpdateMatchTicket(ticket *MatchTicket) (*MatchTicket, error)
+	DeleteMatchTicket(playerName string) error
+
+	CreateChatMessage(message *ChatMessage) (*ChatMessage, error)
+	QueryChatMessages(gameID string, page int, count int) ([]*ChatMessage, error)
+}
+
+// ContextDatabase is a Database whose operations can be bound to a context, e.g. to trace them as part of
+// a request
+type ContextDatabase interface {
+	Database
+	WithContext(ctx context.Context) Database
 }
 
 type Move struct {
@@ -33,6 +60,21 @@ const (
 	StatusLost
 )
 
+func (s Status) String() string {
+	switch s {
+	case StatusSetup:
+		return "Setup"
+	case StatusPlaying:
+		return "Playing"
+	case StatusWon:
+		return "Won"
+	case StatusLost:
+		return "Lost"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
 type (
 	FieldState byte
 	FieldRow   [10]FieldState
@@ -52,16 +94,34 @@ type Game struct {
 	Boards  map[string]*Board `json:"boards" bson:"boards"`
 	History []Move            `json:"history" bson:"history"`
 	Status  Status            `json:"status" bson:"status"`
+	Rules   RulePreset        `json:"rules" bson:"rules"`
+
+	Visibility Visibility `json:"visibility" bson:"visibility"`
+	InviteCode string     `json:"invite_code,omitempty" bson:"invite_code,omitempty"`
 
 	Player1      *Player `json:"player_1" bson:"player1"`
 	Player2      *Player `json:"player_2" bson:"player2"`
 	PlayerToMove string  `json:"player_to_move" bson:"player_to_move"`
+	FirstToMove  string  `json:"first_to_move,omitempty" bson:"first_to_move,omitempty"`
+
+	RematchOf          string  `json:"rematch_of,omitempty" bson:"rematch_of,omitempty"`
+	RematchID          string  `json:"rematch_id,omitempty" bson:"rematch_id,omitempty"`
+	RematchRequestedBy string  `json:"rematch_requested_by,omitempty" bson:"rematch_requested_by,omitempty"`
+	Series             *Series `json:"series,omitempty" bson:"series,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 }
 
 func NewGame(player1 *Player, name ...string) *Game {
+	now := time.Now().UTC()
 	g := Game{
-		Status:  StatusSetup,
-		Player1: player1,
+		Status:     StatusSetup,
+		Rules:      RulesClassic,
+		Visibility: VisibilityPublic,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Player1:    player1,
 		Player2: &Player{
 			Name: "nobody",
 		},
@@ -88,6 +148,23 @@ func (g *Game) InitHistory() {
 }
 
 func (g *Game) Join(player2 *Player) error {
+	if g.Visibility == VisibilityPrivate {
+		return fmt.Errorf("this game is private, you need an invite code to join: %w", ErrorIllegal)
+	}
+
+	return g.join(player2)
+}
+
+// JoinWithCode joins the game with its invite code. This is the only way to join a private game.
+func (g *Game) JoinWithCode(player2 *Player, code string) error {
+	if g.InviteCode == "" || NormalizeInviteCode(code) != g.InviteCode {
+		return fmt.Errorf("invalid invite code %q: %w", code, ErrorIllegal)
+	}
+
+	return g.join(player2)
+}
+
+func (g *Game) join(player2 *Player) error {
 	if len(g.Boards) > 1 {
 		return fmt.Errorf("you are not allowed to join the game: %w", ErrorIllegal)
 	}
@@ -137,7 +214,11 @@ func (g *Game) Start(playerName string) error {
 		return err
 	}
 
+	// a rematch decides who moves first, otherwise it is the player starting the game
 	g.PlayerToMove = playerName
+	if g.FirstToMove != "" {
+		g.PlayerToMove = g.FirstToMove
+	}
 	g.Status = StatusPlaying
 	return nil
 }
@@ -163,6 +244,20 @@ func (g *Game) CanStart(playerName string) error {
 	return nil
 }
 
+// CanCancel checks that the player may cancel the game: only the player who created it, as long as nobody
+// has joined it
+func (g *Game) CanCancel(playerName string) error {
+	if g.Player1 == nil || g.Player1.Name != playerName {
+		return fmt.Errorf("you are not allowed to cancel the game: %w", ErrorIllegal)
+	}
+
+	if g.Status != StatusSetup || len(g.Boards) > 1 {
+		return fmt.Errorf("the game can't be cancelled after an opponent joined: %w", ErrorInvalid)
+	}
+
+	return nil
+}
+
 // ValidSetup checks if all placed pins are valid
 func (g *Game) ValidSetup() error {
 	if g.Status != StatusSetup {
@@ -197,6 +292,7 @@ func (g *Game) MakeMove(move Move) error {
 		return fmt.Errorf("you can't attack: %w", err)
 	} else {
 		playerBoard.Track(result, move.X, move.Y)
+		move.Hit = result == FieldStateHit
 	}
 
 	g.History = append(g.History, move)
@@ -273,6 +369,11 @@ func (g *Game) checkGameStatusForPlayer(playerName string) error {
 	return nil
 }
 
+// HasPlayer reports whether the player takes part in the game
+func (g *Game) HasPlayer(playerName string) bool {
+	return (g.Player1 != nil && g.Player1.Name == playerName) || (g.Player2 != nil && g.Player2.Name == playerName)
+}
+
 func (g *Game) opponent(playerName string) string {
 	for name := range g.Boards {
 		if name != playerName {
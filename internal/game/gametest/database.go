@@ -0,0 +1,335 @@
+// Package gametest provides an in-memory game.Database for the tests of the packages built on game.API.
+package gametest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// Database is an in-memory implementation of the game.Database interface. Games and tickets are stored as
+// copies, like a real database does, so calls in different goroutines don't share them.
+type Database struct {
+	mu      sync.Mutex
+	players map[string]*game.Player
+	games   map[string][]byte
+	tickets map[string]game.MatchTicket
+	chat    []*game.ChatMessage
+	nextID  int
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		players: make(map[string]*game.Player),
+		games:   make(map[string][]byte),
+		tickets: make(map[string]game.MatchTicket),
+	}
+}
+
+func (m *Database) CreatePlayer(playerName string) (*game.Player, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	player := &game.Player{Name: playerName, ID: "player-" + playerName}
+	m.players[playerName] = player
+	return player, nil
+}
+
+func (m *Database) FindPlayerByName(username string) (*game.Player, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	player, ok := m.players[username]
+	if !ok {
+		return nil, fmt.Errorf("player %q: %w", username, game.ErrorNotFound)
+	}
+	return player, nil
+}
+
+// QueryGames returns a page of the public games, ordered by ID
+func (m *Database) QueryGames(page int, count int) ([]*game.Game, error) {
+	all, err := m.allGames()
+	if err != nil {
+		return nil, err
+	}
+
+	var games []*game.Game
+	for _, g := range all {
+		if g.Visibility != game.VisibilityPrivate {
+			games = append(games, g)
+		}
+	}
+
+	start := page * count
+	if start >= len(games) {
+		return []*game.Game{}, nil
+	}
+	end := min(start+count, len(games))
+	return games[start:end], nil
+}
+
+func (m *Database) CreateGame(g *game.Game) (*game.Game, error) {
+	m.mu.Lock()
+	m.nextID++
+	g.ID = fmt.Sprintf("game%d", m.nextID)
+	m.mu.Unlock()
+
+	return m.store(g)
+}
+
+func (m *Database) FindGameByID(id string) (*game.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.games[id]
+	if !ok {
+		return nil, game.ErrorNotFound
+	}
+	return decode(data)
+}
+
+func (m *Database) FindGameByName(name string) (*game.Game, error) {
+	return m.findGame(func(g *game.Game) bool { return g.Name == name })
+}
+
+func (m *Database) FindGameByInviteCode(code string) (*game.Game, error) {
+	return m.findGame(func(g *game.Game) bool { return g.InviteCode != "" && g.InviteCode == code })
+}
+
+func (m *Database) UpdateGame(g *game.Game) (*game.Game, error) {
+	m.mu.Lock()
+	_, ok := m.games[g.ID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, game.ErrorNotFound
+	}
+	return m.store(g)
+}
+
+func (m *Database) ClaimRematch(gameID string, rematchID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.games[gameID]
+	if !ok {
+		return game.ErrorNotFound
+	}
+	g, err := decode(data)
+	if err != nil {
+		return err
+	}
+	if g.RematchID != "" {
+		return game.ErrorNotFound
+	}
+
+	g.RematchID = rematchID
+	data, err = json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	m.games[gameID] = data
+	return nil
+}
+
+func (m *Database) DeleteGame(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[id]; !ok {
+		return game.ErrorNotFound
+	}
+	delete(m.games, id)
+	return nil
+}
+
+func (m *Database) DeleteAllGames() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := len(m.games)
+	m.games = make(map[string][]byte)
+	return count, nil
+}
+
+func (m *Database) CountOpenGames(playerName string) (int, error) {
+	games, err := m.allGames()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, g := range games {
+		open := g.Status == game.StatusSetup || g.Status == game.StatusPlaying
+		if open && g.HasPlayer(playerName) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Database) CountGamesByStatus() (map[game.Status]int, error) {
+	games, err := m.allGames()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[game.Status]int)
+	for _, g := range games {
+		counts[g.Status]++
+	}
+	return counts, nil
+}
+
+func (m *Database) CreateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tickets[ticket.Player]; ok {
+		return nil, game.ErrorAmbiguous
+	}
+	m.tickets[ticket.Player] = *ticket
+	return ticket, nil
+}
+
+func (m *Database) FindMatchTicket(playerName string) (*game.MatchTicket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ticket, ok := m.tickets[playerName]
+	if !ok {
+		return nil, game.ErrorNotFound
+	}
+	return &ticket, nil
+}
+
+// QueryMatchTickets returns the waiting tickets of a preset, the oldest first
+func (m *Database) QueryMatchTickets(preset game.RulePreset) ([]*game.MatchTicket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tickets []*game.MatchTicket
+	for _, t := range m.tickets {
+		if t.Preset == preset && t.Status == game.MatchStatusWaiting {
+			tickets = append(tickets, &t)
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].CreatedAt.Before(tickets[j].CreatedAt) })
+	return tickets, nil
+}
+
+func (m *Database) ClaimMatchTicket(playerName string) (*game.MatchTicket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ticket, ok := m.tickets[playerName]
+	if !ok || ticket.Status != game.MatchStatusWaiting {
+		return nil, game.ErrorNotFound
+	}
+	ticket.Status = game.MatchStatusMatched
+	m.tickets[playerName] = ticket
+	return &ticket, nil
+}
+
+func (m *Database) UpdateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tickets[ticket.Player]; !ok {
+		return nil, game.ErrorNotFound
+	}
+	m.tickets[ticket.Player] = *ticket
+	return ticket, nil
+}
+
+func (m *Database) DeleteMatchTicket(playerName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tickets[playerName]; !ok {
+		return game.ErrorNotFound
+	}
+	delete(m.tickets, playerName)
+	return nil
+}
+
+func (m *Database) CreateChatMessage(message *game.ChatMessage) (*game.ChatMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	message.ID = fmt.Sprintf("message%d", len(m.chat)+1)
+	m.chat = append(m.chat, message)
+	return message, nil
+}
+
+// QueryChatMessages returns a page of the messages of a game, the latest first
+func (m *Database) QueryChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var messages []*game.ChatMessage
+	for i := len(m.chat) - 1; i >= 0; i-- {
+		if m.chat[i].GameID == gameID {
+			messages = append(messages, m.chat[i])
+		}
+	}
+
+	start := page * count
+	if start >= len(messages) {
+		return []*game.ChatMessage{}, nil
+	}
+	end := min(start+count, len(messages))
+	return messages[start:end], nil
+}
+
+func (m *Database) store(g *game.Game) (*game.Game, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.games[g.ID] = data
+	return g, nil
+}
+
+// allGames returns copies of all games, ordered by ID
+func (m *Database) allGames() ([]*game.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	games := make([]*game.Game, 0, len(m.games))
+	for _, data := range m.games {
+		g, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+	return games, nil
+}
+
+func (m *Database) findGame(match func(g *game.Game) bool) (*game.Game, error) {
+	games, err := m.allGames()
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range games {
+		if match(g) {
+			return g, nil
+		}
+	}
+	return nil, game.ErrorNotFound
+}
+
+func decode(data []byte) (*game.Game, error) {
+	var g game.Game
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
@@ -0,0 +1,59 @@
+package gametest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// Placement is the position of a ship on a board
+type Placement struct {
+	Ship        game.ShipType
+	X, Y        int
+	Orientation game.ShipOrientation
+}
+
+// Fleet is a complete fleet, placed in every second row from the top left
+var Fleet = []Placement{
+	{game.Battleship, 0, 0, game.OrientationHorizontal},
+	{game.Cruiser, 0, 2, game.OrientationHorizontal},
+	{game.Cruiser, 5, 2, game.OrientationHorizontal},
+	{game.Destroyer, 0, 4, game.OrientationHorizontal},
+	{game.Destroyer, 4, 4, game.OrientationHorizontal},
+	{game.Destroyer, 0, 6, game.OrientationHorizontal},
+	{game.Submarine, 4, 6, game.OrientationHorizontal},
+	{game.Submarine, 8, 6, game.OrientationHorizontal},
+	{game.Submarine, 0, 8, game.OrientationHorizontal},
+	{game.Submarine, 3, 8, game.OrientationHorizontal},
+}
+
+// NewAPI returns a game API on a new in-memory database in which the given players exist
+func NewAPI(t testing.TB, players ...string) (*game.API, *Database) {
+	t.Helper()
+
+	db := NewDatabase()
+	api := game.NewApi(db)
+	for _, player := range players {
+		_, err := api.NewPlayer(player)
+		require.NoError(t, err)
+	}
+	return api, db
+}
+
+// NewGame creates a game between alice and bob in which bob already placed the Fleet
+func NewGame(t testing.TB) (*game.API, *Database, *game.Game) {
+	t.Helper()
+
+	api, db := NewAPI(t, "alice", "bob")
+	g, err := api.NewGameBetween("alice", "bob", "")
+	require.NoError(t, err)
+
+	for _, p := range Fleet {
+		require.NoError(t, g.PlaceShip("bob", p.Ship, p.X, p.Y, p.Orientation))
+	}
+	g, err = api.UpdateGame(g)
+	require.NoError(t, err)
+	return api, db, g
+}
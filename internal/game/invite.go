@@ -0,0 +1,64 @@
+package game
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type Visibility string
+
+const (
+	// VisibilityPublic games are listed and can be joined by anybody
+	VisibilityPublic Visibility = "public"
+	// VisibilityPrivate games are not listed and can only be joined with their invite code
+	VisibilityPrivate Visibility = "private"
+)
+
+// CanWatch returns an error unless the player may see the game, its events and its chat. Public games can be
+// watched by anybody, private games only by their players; to everybody else they do not exist.
+func (g *Game) CanWatch(playerName string) error {
+	if g.Visibility == VisibilityPrivate && !g.HasPlayer(playerName) {
+		return fmt.Errorf("game %s: %w", g.ID, ErrorNotFound)
+	}
+	return nil
+}
+
+// inviteCodeAlphabet leaves out characters that are easily confused when read aloud or typed: 0/O, 1/I/L
+const inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+const inviteCodeLength = 6
+
+// NewInviteCode generates a short, human-friendly code to share a game with
+func NewInviteCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(inviteCodeAlphabet)))
+	var code strings.Builder
+	for range inviteCodeLength {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("could not generate invite code: %w", err)
+		}
+		code.WriteByte(inviteCodeAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// NormalizeInviteCode turns user input like "abc-def" into the stored form "ABCDEF"
+func NormalizeInviteCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
+
+// FormatInviteCode splits a code into two groups for display, e.g. "ABC-DEF"
+func FormatInviteCode(code string) string {
+	if len(code) != inviteCodeLength {
+		return code
+	}
+	return code[:inviteCodeLength/2] + "-" + code[inviteCodeLength/2:]
+}
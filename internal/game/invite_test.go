@@ -0,0 +1,74 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInviteCode(t *testing.T) {
+	code, err := NewInviteCode()
+	require.NoError(t, err)
+	assert.Len(t, code, inviteCodeLength)
+	for _, r := range code {
+		assert.True(t, strings.ContainsRune(inviteCodeAlphabet, r), "unexpected character %q", r)
+	}
+}
+
+func TestInviteCodeFormatting(t *testing.T) {
+	assert.Equal(t, "ABC-DEF", FormatInviteCode("ABCDEF"))
+	assert.Equal(t, "ABCDEF", NormalizeInviteCode("abc-def"))
+	assert.Equal(t, "ABCDEF", NormalizeInviteCode(" Abc Def "))
+	assert.Equal(t, "ABCDEF", NormalizeInviteCode(FormatInviteCode("ABCDEF")))
+}
+
+func TestGameJoinPrivate(t *testing.T) {
+	game := NewGame(&Player{Name: "player1"})
+	game.Visibility = VisibilityPrivate
+	game.InviteCode = "ABCDEF"
+
+	err := game.Join(&Player{Name: "player2"})
+	assert.ErrorIs(t, err, ErrorIllegal)
+
+	err = game.JoinWithCode(&Player{Name: "player2"}, "XYZ-XYZ")
+	assert.ErrorIs(t, err, ErrorIllegal)
+	assert.Len(t, game.Boards, 1)
+
+	err = game.JoinWithCode(&Player{Name: "player2"}, "abc-def")
+	assert.NoError(t, err)
+	assert.Equal(t, "player2", game.Player2.Name)
+	assert.Len(t, game.Boards, 2)
+}
+
+func TestPrivateGames(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	public, err := api.NewGame("alice", "public")
+	require.NoError(t, err)
+	assert.Equal(t, VisibilityPublic, public.Visibility)
+	assert.NotEmpty(t, public.InviteCode)
+
+	private, err := api.NewPrivateGame("alice", "private")
+	require.NoError(t, err)
+	assert.Equal(t, VisibilityPrivate, private.Visibility)
+	assert.NotEqual(t, public.InviteCode, private.InviteCode)
+
+	// Private games are not listed
+	summaries, err := api.GameSummaries(0, 10)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, public.ID, summaries[0].ID)
+
+	_, err = api.JoinGameByCode("nope", "bob")
+	assert.ErrorIs(t, err, ErrorNotFound)
+
+	joined, err := api.JoinGameByCode(strings.ToLower(FormatInviteCode(private.InviteCode)), "bob")
+	require.NoError(t, err)
+	assert.Equal(t, private.ID, joined.ID)
+	assert.Equal(t, "bob", joined.Player2.Name)
+}
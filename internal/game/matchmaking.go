@@ -0,0 +1,60 @@
+package game
+
+import "time"
+
+type MatchStatus string
+
+const (
+	MatchStatusWaiting MatchStatus = "waiting"
+	MatchStatusMatched MatchStatus = "matched"
+)
+
+// MatchRequest is what a player asks the lobby for. MinRating and MaxRating bound the score of acceptable
+// opponents, a MaxRating of 0 means there is no upper bound.
+type MatchRequest struct {
+	Preset    RulePreset `json:"preset"`
+	MinRating int        `json:"min_rating"`
+	MaxRating int        `json:"max_rating"`
+}
+
+// MatchTicket is a player's place in the matchmaking queue. Once the player has been paired, Status is
+// MatchStatusMatched and GameID refers to the game created for both players.
+type MatchTicket struct {
+	Player    string      `json:"player" bson:"player"`
+	Rating    int         `json:"rating" bson:"rating"`
+	Preset    RulePreset  `json:"preset" bson:"preset"`
+	MinRating int         `json:"min_rating" bson:"min_rating"`
+	MaxRating int         `json:"max_rating" bson:"max_rating"`
+	Status    MatchStatus `json:"status" bson:"status"`
+	GameID    string      `json:"game_id,omitempty" bson:"game_id,omitempty"`
+	Opponent  string      `json:"opponent,omitempty" bson:"opponent,omitempty"`
+	CreatedAt time.Time   `json:"created_at" bson:"created_at"`
+}
+
+func newMatchTicket(player *Player, request MatchRequest) *MatchTicket {
+	return &MatchTicket{
+		Player:    player.Name,
+		Rating:    player.Score,
+		Preset:    request.Preset,
+		MinRating: request.MinRating,
+		MaxRating: request.MaxRating,
+		Status:    MatchStatusWaiting,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// accepts reports whether the ticket's rating range admits an opponent with the given rating
+func (t *MatchTicket) accepts(rating int) bool {
+	if rating < t.MinRating {
+		return false
+	}
+	return t.MaxRating == 0 || rating <= t.MaxRating
+}
+
+// pairsWith reports whether two tickets can be matched against each other
+func (t *MatchTicket) pairsWith(other *MatchTicket) bool {
+	return t.Player != other.Player &&
+		t.Preset == other.Preset &&
+		t.accepts(other.Rating) &&
+		other.accepts(t.Rating)
+}
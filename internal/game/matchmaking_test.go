@@ -0,0 +1,165 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMatch(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	// The first player has to wait for an opponent
+	ticket, err := api.FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, ticket.Status)
+	assert.Equal(t, RulesClassic, ticket.Preset)
+	assert.Empty(t, ticket.GameID)
+
+	// Asking again while waiting keeps the place in the queue
+	again, err := api.FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+	assert.Same(t, ticket, again)
+
+	// The second player is paired with the waiting one
+	ticket, err = api.FindMatch("bob", MatchRequest{Preset: RulesClassic})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusMatched, ticket.Status)
+	assert.Equal(t, "alice", ticket.Opponent)
+	require.NotEmpty(t, ticket.GameID)
+
+	g, err := api.GetGame(ticket.GameID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", g.Player1.Name)
+	assert.Equal(t, "bob", g.Player2.Name)
+	assert.Len(t, g.Boards, 2)
+	assert.Equal(t, RulesClassic, g.Rules)
+
+	// The waiting player learns about the game through the status
+	status, err := api.MatchStatus("alice")
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusMatched, status.Status)
+	assert.Equal(t, ticket.GameID, status.GameID)
+	assert.Equal(t, "bob", status.Opponent)
+}
+
+func TestFindMatchRatingRange(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	novice, _ := db.CreatePlayer("novice")
+	expert, _ := db.CreatePlayer("expert")
+	novice.Score = 10
+	expert.Score = 500
+
+	// The expert only wants strong opponents
+	ticket, err := api.FindMatch("expert", MatchRequest{MinRating: 400})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, ticket.Status)
+
+	ticket, err = api.FindMatch("novice", MatchRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, ticket.Status)
+	assert.Empty(t, db.games)
+}
+
+func TestFindMatchInvalidRequest(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+
+	_, err := api.FindMatch("alice", MatchRequest{Preset: "salvo"})
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	_, err = api.FindMatch("alice", MatchRequest{MinRating: 50, MaxRating: 20})
+	assert.ErrorIs(t, err, ErrorInvalidInput)
+
+	_, err = api.FindMatch("nobody", MatchRequest{})
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+func TestCancelMatch(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+
+	_, err := api.FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+
+	err = api.CancelMatch("alice")
+	require.NoError(t, err)
+
+	_, err = api.MatchStatus("alice")
+	assert.ErrorIs(t, err, ErrorNotFound)
+
+	// Nobody is left to be paired with
+	ticket, err := api.FindMatch("bob", MatchRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, ticket.Status)
+
+	err = api.CancelMatch("alice")
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+// racingDatabase hides the ticket of a player from the first lookup, like a concurrent request of the
+// player which queued its ticket in between
+type racingDatabase struct {
+	*mockDatabase
+	raced bool
+}
+
+func (r *racingDatabase) FindMatchTicket(playerName string) (*MatchTicket, error) {
+	if !r.raced {
+		r.raced = true
+		return nil, ErrorNotFound
+	}
+	return r.mockDatabase.FindMatchTicket(playerName)
+}
+
+func TestFindMatchKeepsOneTicketPerPlayer(t *testing.T) {
+	db := newMockDatabase()
+	_, _ = db.CreatePlayer("alice")
+	queued, err := NewApi(db).FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+
+	ticket, err := NewApi(&racingDatabase{mockDatabase: db}).FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+	assert.Same(t, queued, ticket)
+}
+
+// queuingDatabase queues a ticket of the player right before the player's own ticket is created, like a
+// concurrent request of the player
+type queuingDatabase struct {
+	*mockDatabase
+	queued *MatchTicket
+}
+
+func (q *queuingDatabase) CreateMatchTicket(ticket *MatchTicket) (*MatchTicket, error) {
+	if q.queued == nil {
+		q.queued = &MatchTicket{Player: ticket.Player, Status: MatchStatusWaiting}
+		_, _ = q.mockDatabase.CreateMatchTicket(q.queued)
+	}
+	return q.mockDatabase.CreateMatchTicket(ticket)
+}
+
+func TestFindMatchLeavesNoGameBehind(t *testing.T) {
+	db := newMockDatabase()
+	_, _ = db.CreatePlayer("alice")
+	_, _ = db.CreatePlayer("bob")
+	_, err := NewApi(db).FindMatch("alice", MatchRequest{})
+	require.NoError(t, err)
+
+	racing := &queuingDatabase{mockDatabase: db}
+	ticket, err := NewApi(racing).FindMatch("bob", MatchRequest{})
+	require.NoError(t, err)
+	assert.Same(t, racing.queued, ticket, "the ticket of the concurrent request is kept")
+	assert.Empty(t, db.games, "no game is created for the lost race")
+
+	alice, err := db.FindMatchTicket("alice")
+	require.NoError(t, err)
+	assert.Equal(t, MatchStatusWaiting, alice.Status, "alice is back in the queue")
+}
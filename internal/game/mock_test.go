@@ -0,0 +1,213 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// mockDatabase is an in-memory implementation of the Database interface for testing
+type mockDatabase struct {
+	players map[string]*Player
+	games   map[string]*Game
+	tickets map[string]*MatchTicket
+	chat    []*ChatMessage
+	nextID  int
+}
+
+// newMockDatabase creates a new mock database for testing
+func newMockDatabase() *mockDatabase {
+	return &mockDatabase{
+		players: make(map[string]*Player),
+		games:   make(map[string]*Game),
+		tickets: make(map[string]*MatchTicket),
+	}
+}
+
+func (m *mockDatabase) CreatePlayer(playerName string) (*Player, error) {
+	player := &Player{Name: playerName, ID: "player-" + playerName}
+	m.players[playerName] = player
+	return player, nil
+}
+
+func (m *mockDatabase) FindPlayerByName(username string) (*Player, error) {
+	player, ok := m.players[username]
+	if !ok {
+		return nil, fmt.Errorf("player %q: %w", username, ErrorNotFound)
+	}
+	return player, nil
+}
+
+func (m *mockDatabase) QueryGames(page int, count int) ([]*Game, error) {
+	var games []*Game
+	for _, g := range m.games {
+		if g.Visibility == VisibilityPrivate {
+			continue
+		}
+		games = append(games, g)
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+
+	start := page * count
+	if start >= len(games) {
+		return []*Game{}, nil
+	}
+	end := min(start+count, len(games))
+	return games[start:end], nil
+}
+
+func (m *mockDatabase) CreateGame(game *Game) (*Game, error) {
+	m.nextID++
+	game.ID = fmt.Sprintf("game%d", m.nextID)
+	m.games[game.ID] = game
+	return game, nil
+}
+
+func (m *mockDatabase) FindGameByID(id string) (*Game, error) {
+	g, ok := m.games[id]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+	return g, nil
+}
+
+func (m *mockDatabase) FindGameByName(name string) (*Game, error) {
+	for _, g := range m.games {
+		if g.Name == name {
+			return g, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (m *mockDatabase) FindGameByInviteCode(code string) (*Game, error) {
+	for _, g := range m.games {
+		if g.InviteCode != "" && g.InviteCode == code {
+			return g, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (m *mockDatabase) UpdateGame(g *Game) (*Game, error) {
+	if _, ok := m.games[g.ID]; !ok {
+		return nil, ErrorNotFound
+	}
+	m.games[g.ID] = g
+	return g, nil
+}
+
+func (m *mockDatabase) DeleteGame(id string) error {
+	if _, ok := m.games[id]; !ok {
+		return ErrorNotFound
+	}
+	delete(m.games, id)
+	return nil
+}
+
+func (m *mockDatabase) DeleteAllGames() (int, error) {
+	count := len(m.games)
+	m.games = make(map[string]*Game)
+	return count, nil
+}
+
+func (m *mockDatabase) CountOpenGames(playerName string) (int, error) {
+	count := 0
+	for _, g := range m.games {
+		open := g.Status == StatusSetup || g.Status == StatusPlaying
+		if open && ((g.Player1 != nil && g.Player1.Name == playerName) || (g.Player2 != nil && g.Player2.Name == playerName)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockDatabase) CountGamesByStatus() (map[Status]int, error) {
+	counts := make(map[Status]int)
+	for _, g := range m.games {
+		counts[g.Status]++
+	}
+	return counts, nil
+}
+
+func (m *mockDatabase) CreateMatchTicket(ticket *MatchTicket) (*MatchTicket, error) {
+	if _, ok := m.tickets[ticket.Player]; ok {
+		return nil, ErrorAmbiguous
+	}
+	m.tickets[ticket.Player] = ticket
+	return ticket, nil
+}
+
+func (m *mockDatabase) FindMatchTicket(playerName string) (*MatchTicket, error) {
+	ticket, ok := m.tickets[playerName]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+	return ticket, nil
+}
+
+func (m *mockDatabase) QueryMatchTickets(preset RulePreset) ([]*MatchTicket, error) {
+	var tickets []*MatchTicket
+	for _, t := range m.tickets {
+		if t.Preset == preset && t.Status == MatchStatusWaiting {
+			tickets = append(tickets, t)
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].CreatedAt.Before(tickets[j].CreatedAt) })
+	return tickets, nil
+}
+
+func (m *mockDatabase) ClaimRematch(gameID string, rematchID string) error {
+	g, ok := m.games[gameID]
+	if !ok || g.RematchID != "" {
+		return ErrorNotFound
+	}
+	g.RematchID = rematchID
+	return nil
+}
+
+func (m *mockDatabase) ClaimMatchTicket(playerName string) (*MatchTicket, error) {
+	ticket, ok := m.tickets[playerName]
+	if !ok || ticket.Status != MatchStatusWaiting {
+		return nil, ErrorNotFound
+	}
+	ticket.Status = MatchStatusMatched
+	return ticket, nil
+}
+
+func (m *mockDatabase) UpdateMatchTicket(ticket *MatchTicket) (*MatchTicket, error) {
+	if _, ok := m.tickets[ticket.Player]; !ok {
+		return nil, ErrorNotFound
+	}
+	m.tickets[ticket.Player] = ticket
+	return ticket, nil
+}
+
+func (m *mockDatabase) DeleteMatchTicket(playerName string) error {
+	if _, ok := m.tickets[playerName]; !ok {
+		return ErrorNotFound
+	}
+	delete(m.tickets, playerName)
+	return nil
+}
+
+func (m *mockDatabase) CreateChatMessage(message *ChatMessage) (*ChatMessage, error) {
+	message.ID = fmt.Sprintf("message%d", len(m.chat)+1)
+	m.chat = append(m.chat, message)
+	return message, nil
+}
+
+func (m *mockDatabase) QueryChatMessages(gameID string, page int, count int) ([]*ChatMessage, error) {
+	var messages []*ChatMessage
+	for i := len(m.chat) - 1; i >= 0; i-- {
+		if m.chat[i].GameID == gameID {
+			messages = append(messages, m.chat[i])
+		}
+	}
+
+	start := page * count
+	if start >= len(messages) {
+		return []*ChatMessage{}, nil
+	}
+	end := min(start+count, len(messages))
+	return messages[start:end], nil
+}
@@ -0,0 +1,113 @@
+package game
+
+import "fmt"
+
+// rematchPending is the rematch ID of a game whose rematch is claimed but not created yet
+const rematchPending = "pending"
+
+// Series tracks the score between the two players of a game and its rematches
+type Series struct {
+	Wins  map[string]int `json:"wins" bson:"wins"`
+	Games int            `json:"games" bson:"games"`
+}
+
+// Winner returns the name of the player who won the game, or an empty string while it is not over
+func (g *Game) Winner() string {
+	switch g.Status {
+	case StatusWon:
+		return g.Player1.Name
+	case StatusLost:
+		return g.Player2.Name
+	default:
+		return ""
+	}
+}
+
+// Finished reports whether the game is over
+func (g *Game) Finished() bool {
+	return g.Status == StatusWon || g.Status == StatusLost
+}
+
+// SeriesScore returns the score of the series including this game, if it is finished
+func (g *Game) SeriesScore() Series {
+	score := Series{Wins: make(map[string]int)}
+	if g.Series != nil {
+		for name, wins := range g.Series.Wins {
+			score.Wins[name] = wins
+		}
+		score.Games = g.Series.Games
+	}
+
+	if winner := g.Winner(); winner != "" {
+		score.Wins[winner]++
+		score.Games++
+	}
+
+	return score
+}
+
+// RequestRematch records that a player wants to play again. The opponent accepts with Rematch.
+func (g *Game) RequestRematch(playerName string) error {
+	if err := g.checkRematch(playerName); err != nil {
+		return err
+	}
+
+	g.RematchRequestedBy = playerName
+	return nil
+}
+
+// Rematch accepts the opponent's rematch request. It returns a new game with the same rules, visibility
+// and players, in which the player who moved second in this game moves first. The new game is not stored.
+func (g *Game) Rematch(playerName string) (*Game, error) {
+	if err := g.checkRematch(playerName); err != nil {
+		return nil, err
+	}
+
+	if g.RematchRequestedBy == "" {
+		return nil, fmt.Errorf("no rematch has been requested: %w", ErrorNotReady)
+	}
+	if g.RematchRequestedBy == playerName {
+		return nil, fmt.Errorf("you can't accept your own rematch request, %s: %w", playerName, ErrorIllegal)
+	}
+
+	rematch := NewGame(g.Player1, g.Name)
+	rematch.Rules = g.Rules
+	rematch.Visibility = g.Visibility
+	if err := rematch.join(g.Player2); err != nil {
+		return nil, err
+	}
+
+	rematch.FirstToMove = g.opponent(g.firstMover())
+	rematch.RematchOf = g.ID
+	series := g.SeriesScore()
+	rematch.Series = &series
+
+	return rematch, nil
+}
+
+func (g *Game) checkRematch(playerName string) error {
+	if !g.Finished() {
+		return fmt.Errorf("the game is not over yet: %w", ErrorNotReady)
+	}
+
+	if _, ok := g.Boards[playerName]; !ok {
+		return fmt.Errorf("you did not play this game, %s: %w", playerName, ErrorIllegal)
+	}
+
+	if g.RematchID != "" {
+		return fmt.Errorf("the rematch has already been started: %w", ErrorIllegal)
+	}
+
+	return nil
+}
+
+// firstMover returns the name of the player who made the first move
+func (g *Game) firstMover() string {
+	if len(g.History) > 0 {
+		return g.History[0].Player
+	}
+	if g.FirstToMove != "" {
+		return g.FirstToMove
+	}
+	return g.Player1.Name
+}
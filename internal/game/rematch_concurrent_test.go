@@ -0,0 +1,82 @@
+package game_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+// barrierDatabase holds back the reads of a game until all accepts have read it, so that each of them
+// sees the game without a rematch
+type barrierDatabase struct {
+	*gametest.Database
+	barrier sync.WaitGroup
+}
+
+func (b *barrierDatabase) FindGameByID(id string) (*game.Game, error) {
+	g, err := b.Database.FindGameByID(id)
+	b.barrier.Done()
+	b.barrier.Wait()
+	return g, err
+}
+
+func TestConcurrentAcceptRematch(t *testing.T) {
+	db := gametest.NewDatabase()
+	api := game.NewApi(db)
+
+	for _, name := range []string{"alice", "bob"} {
+		_, err := api.NewPlayer(name)
+		require.NoError(t, err)
+	}
+	g, err := api.NewGameBetween("alice", "bob", "final")
+	require.NoError(t, err)
+	g.Status = game.StatusWon
+	_, err = db.UpdateGame(g)
+	require.NoError(t, err)
+
+	_, err = api.RequestRematch(g.ID, "bob")
+	require.NoError(t, err)
+
+	const accepts = 8
+	racing := &barrierDatabase{Database: db}
+	racing.barrier.Add(accepts)
+	api = game.NewApi(racing)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, accepts)
+	for range accepts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.AcceptRematch(g.ID, "alice")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, game.ErrorIllegal)
+	}
+	assert.Equal(t, 1, succeeded, "only one accept may start the rematch")
+
+	counts, err := db.CountGamesByStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[game.StatusSetup], "the series must not fork")
+
+	stored, err := db.FindGameByID(g.ID)
+	require.NoError(t, err)
+	rematch, err := db.FindGameByID(stored.RematchID)
+	require.NoError(t, err)
+	assert.Equal(t, g.ID, rematch.RematchOf)
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createFinishedGame returns a game player1 started and won
+func createFinishedGame() (*Game, error) {
+	game, err := createReadyGame()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := game.Start(game.Player1.Name); err != nil {
+		return nil, err
+	}
+	if err := game.MakeMove(Move{Player: game.Player1.Name, X: 0, Y: 0}); err != nil {
+		return nil, err
+	}
+
+	game.ID = "game1"
+	game.Status = StatusWon
+	return game, nil
+}
+
+func TestGameRematch(t *testing.T) {
+	game, err := createFinishedGame()
+	require.NoError(t, err)
+
+	_, err = game.Rematch("player2")
+	assert.ErrorIs(t, err, ErrorNotReady, "nobody asked for a rematch yet")
+
+	err = game.RequestRematch("player3")
+	assert.ErrorIs(t, err, ErrorIllegal)
+
+	err = game.RequestRematch("player1")
+	require.NoError(t, err)
+	assert.Equal(t, "player1", game.RematchRequestedBy)
+
+	_, err = game.Rematch("player1")
+	assert.ErrorIs(t, err, ErrorIllegal, "the requesting player can't accept")
+
+	rematch, err := game.Rematch("player2")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSetup, rematch.Status)
+	assert.Equal(t, game.Rules, rematch.Rules)
+	assert.Equal(t, "game1", rematch.RematchOf)
+	assert.Equal(t, "player2", rematch.FirstToMove)
+	assert.Contains(t, rematch.Boards, "player1")
+	assert.Contains(t, rematch.Boards, "player2")
+	assert.Empty(t, rematch.History)
+
+	require.NotNil(t, rematch.Series)
+	assert.Equal(t, 1, rematch.Series.Games)
+	assert.Equal(t, 1, rematch.Series.Wins["player1"])
+	assert.Equal(t, 0, rematch.Series.Wins["player2"])
+}
+
+func TestGameRematchNotFinished(t *testing.T) {
+	game, err := createReadyGame()
+	require.NoError(t, err)
+
+	err = game.RequestRematch("player1")
+	assert.ErrorIs(t, err, ErrorNotReady)
+}
+
+func TestGameStartFirstToMove(t *testing.T) {
+	game, err := createReadyGame()
+	require.NoError(t, err)
+	game.FirstToMove = "player2"
+
+	err = game.Start("player1")
+	require.NoError(t, err)
+	assert.Equal(t, "player2", game.PlayerToMove)
+}
+
+func TestSeriesScore(t *testing.T) {
+	game, err := createFinishedGame()
+	require.NoError(t, err)
+	game.Series = &Series{Wins: map[string]int{"player2": 2}, Games: 2}
+
+	score := game.SeriesScore()
+	assert.Equal(t, 3, score.Games)
+	assert.Equal(t, 1, score.Wins["player1"])
+	assert.Equal(t, 2, score.Wins["player2"])
+
+	// the stored score of the previous games is not modified
+	assert.Equal(t, 2, game.Series.Games)
+}
+
+func TestAcceptRematch(t *testing.T) {
+	db := newMockDatabase()
+	api := NewApi(db)
+
+	game, err := createFinishedGame()
+	require.NoError(t, err)
+	db.games[game.ID] = game
+
+	_, err = api.RequestRematch(game.ID, "player2")
+	require.NoError(t, err)
+
+	rematch, err := api.AcceptRematch(game.ID, "player1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, rematch.ID)
+	assert.NotEmpty(t, rematch.InviteCode)
+	assert.Equal(t, game.ID, rematch.RematchOf)
+	assert.Equal(t, rematch.ID, db.games[game.ID].RematchID)
+
+	_, err = api.AcceptRematch(game.ID, "player1")
+	assert.ErrorIs(t, err, ErrorIllegal, "a game can only be rematched once")
+}
@@ -0,0 +1,28 @@
+package game
+
+import "fmt"
+
+// RulePreset names a set of game rules. Players are only matched with opponents asking for the same preset.
+type RulePreset string
+
+const (
+	// RulesClassic is the 10x10 board with the fleet defined in shipsAllowed
+	RulesClassic RulePreset = "classic"
+)
+
+var rulePresets = map[RulePreset]bool{
+	RulesClassic: true,
+}
+
+// ParseRulePreset validates a preset name. An empty name selects the classic rules.
+func ParseRulePreset(name string) (RulePreset, error) {
+	if name == "" {
+		return RulesClassic, nil
+	}
+
+	preset := RulePreset(name)
+	if !rulePresets[preset] {
+		return "", fmt.Errorf("unknown rule preset %q: %w", name, ErrorInvalidInput)
+	}
+	return preset, nil
+}
@@ -110,6 +110,11 @@ func (s *Ship) IsSunk() bool {
 	return true
 }
 
+// Length returns the number of fields a ship of the type covers
+func (t ShipType) Length() int {
+	return shipLength(t)
+}
+
 func shipLength(shipType ShipType) int {
 	switch shipType {
 	case Battleship:
@@ -9,6 +9,9 @@ var shipsAllowed = map[ShipType]int{
 
 const FleetSizeAllowed = 10
 
+// ShipTypes lists the types of the fleet from the longest to the shortest ship
+var ShipTypes = []ShipType{Battleship, Cruiser, Destroyer, Submarine}
+
 type Ships []*Ship
 
 func (f Ships) Filter(predicate func(*Ship) bool) Ships {
@@ -0,0 +1,44 @@
+package game
+
+import "time"
+
+// Summary is a redacted projection of a Game for listings. It carries no boards, so neither
+// fleet positions nor shot maps of any player are exposed.
+type Summary struct {
+	ID        string    `json:"_id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Status    Status    `json:"status"`
+	Player1   *Player   `json:"player_1"`
+	Player2   *Player   `json:"player_2"`
+	Moves     int       `json:"moves"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Summary returns the redacted listing projection of the game
+func (g *Game) Summary() Summary {
+	return Summary{
+		ID:        g.ID,
+		Name:      g.Name,
+		Status:    g.Status,
+		Player1:   g.Player1,
+		Player2:   g.Player2,
+		Moves:     len(g.History),
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+}
+
+// HasPlayer reports whether the player takes part in the summarized game
+func (s Summary) HasPlayer(playerName string) bool {
+	return (s.Player1 != nil && s.Player1.Name == playerName) || (s.Player2 != nil && s.Player2.Name == playerName)
+}
+
+// Summarize projects a list of games to their summaries
+func Summarize(games []*Game) []Summary {
+	summaries := make([]Summary, len(games))
+	for i, g := range games {
+		summaries[i] = g.Summary()
+	}
+	return summaries
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSummary(t *testing.T) {
+	game, err := createReadyGame()
+	require.NoError(t, err)
+	game.ID = "game1"
+	game.Name = "summary"
+
+	err = game.Start(game.Player1.Name)
+	require.NoError(t, err)
+	err = game.MakeMove(Move{Player: game.Player1.Name, X: 0, Y: 0})
+	require.NoError(t, err)
+
+	summary := game.Summary()
+	assert.Equal(t, "game1", summary.ID)
+	assert.Equal(t, "summary", summary.Name)
+	assert.Equal(t, StatusPlaying, summary.Status)
+	assert.Equal(t, game.Player1, summary.Player1)
+	assert.Equal(t, game.Player2, summary.Player2)
+	assert.Equal(t, 1, summary.Moves)
+	assert.Equal(t, game.CreatedAt, summary.CreatedAt)
+
+	// The serialized summary must never carry fleets or maps
+	data, err := json.Marshal(summary)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "boards")
+	assert.NotContains(t, string(data), "fleet")
+	assert.NotContains(t, string(data), "maps")
+}
+
+func TestSummarize(t *testing.T) {
+	games := []*Game{
+		NewGame(&Player{Name: "player1"}, "first"),
+		NewGame(&Player{Name: "player2"}, "second"),
+	}
+
+	summaries := Summarize(games)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "first", summaries[0].Name)
+	assert.Equal(t, "second", summaries[1].Name)
+	assert.Equal(t, StatusSetup, summaries[1].Status)
+}
@@ -0,0 +1,282 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+
+	gographql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// GameAPI is the part of game.API the resolvers need
+type GameAPI interface {
+	ScoreBoard(playerName string) (*game.ScoreBoard, error)
+	Games(page int, count int) ([]*game.Game, error)
+	GetGame(id string) (*game.Game, error)
+	NewGame(player string, name string) (*game.Game, error)
+	NewPrivateGame(player string, name string) (*game.Game, error)
+	JoinGame(id string, playerName string) (*game.Game, error)
+	JoinGameByCode(code string, playerName string) (*game.Game, error)
+	CancelGame(id string, playerName string) error
+	UpdateGame(g *game.Game) (*game.Game, error)
+	GetPlayer(playerName string) (*game.Player, error)
+}
+
+// NewHandler returns the handler of the GraphQL endpoint. It acts as the player put into the request context
+// with WithPlayer.
+func NewHandler(api GameAPI) http.Handler {
+	return &relay.Handler{
+		Schema: gographql.MustParseSchema(schema, &resolver{api: api}),
+	}
+}
+
+// DefaultGamesPerPage is the number of games returned for a count of 0
+const DefaultGamesPerPage = 10
+
+type playerKey struct{}
+
+// WithPlayer returns the context of a request by the logged in player
+func WithPlayer(ctx context.Context, playerName string) context.Context {
+	return context.WithValue(ctx, playerKey{}, playerName)
+}
+
+// playerFromContext returns the logged in player, or "" for a viewer
+func playerFromContext(ctx context.Context) string {
+	player, _ := ctx.Value(playerKey{}).(string)
+	return player
+}
+
+func requirePlayer(ctx context.Context) (string, error) {
+	player := playerFromContext(ctx)
+	if player == "" {
+		return "", fmt.Errorf("invalid player - you must be logged in: %w", game.ErrorIllegal)
+	}
+	return player, nil
+}
+
+// resolver resolves the queries and mutations
+type resolver struct {
+	api GameAPI
+}
+
+// games returns the API with its storage operations bound to the context of the request, so they are
+// traced as part of it
+func (r *resolver) games(ctx context.Context) GameAPI {
+	if api, ok := r.api.(interface {
+		WithContext(ctx context.Context) *game.API
+	}); ok {
+		return api.WithContext(ctx)
+	}
+	return r.api
+}
+
+func (r *resolver) Me(ctx context.Context) (*playerResolver, error) {
+	player := playerFromContext(ctx)
+	if player == "" {
+		return nil, nil
+	}
+	return r.Player(ctx, struct{ Name string }{Name: player})
+}
+
+func (r *resolver) Player(ctx context.Context, args struct{ Name string }) (*playerResolver, error) {
+	player, err := r.games(ctx).GetPlayer(args.Name)
+	if errors.Is(err, game.ErrorNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &playerResolver{player}, nil
+}
+
+func (r *resolver) Games(ctx context.Context, args struct{ Page, Count int32 }) ([]*gameResolver, error) {
+	page, count := max(int(args.Page), 0), int(args.Count)
+	if count <= 0 {
+		count = DefaultGamesPerPage
+	}
+
+	games, err := r.games(ctx).Games(page, count)
+	if err != nil {
+		return nil, err
+	}
+
+	user := playerFromContext(ctx)
+	resolvers := make([]*gameResolver, 0, len(games))
+	for _, g := range games {
+		resolvers = append(resolvers, &gameResolver{g: g, user: user})
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Game(ctx context.Context, args struct{ ID gographql.ID }) (*gameResolver, error) {
+	user := playerFromContext(ctx)
+	g, err := r.games(ctx).GetGame(string(args.ID))
+	if err == nil {
+		err = g.CanWatch(user)
+	}
+	if errors.Is(err, game.ErrorNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gameResolver{g: g, user: user}, nil
+}
+
+func (r *resolver) Scoreboard(ctx context.Context) ([]*playerResolver, error) {
+	scoreboard, err := r.games(ctx).ScoreBoard(playerFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*playerResolver, 0, len(scoreboard.Scores))
+	for _, player := range scoreboard.Scores {
+		resolvers = append(resolvers, &playerResolver{&player})
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) CreateGame(ctx context.Context, args struct {
+	Name    *string
+	Private bool
+}) (*gameResolver, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ""
+	if args.Name != nil {
+		name = *args.Name
+	}
+
+	var g *game.Game
+	if args.Private {
+		g, err = r.games(ctx).NewPrivateGame(player, name)
+	} else {
+		g, err = r.games(ctx).NewGame(player, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gameResolver{g: g, user: player}, nil
+}
+
+func (r *resolver) JoinGame(ctx context.Context, args struct {
+	ID         *gographql.ID
+	InviteCode *string
+}) (*gameResolver, error) {
+	playerName, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case args.InviteCode != nil:
+		g, err := r.games(ctx).JoinGameByCode(*args.InviteCode, playerName)
+		if err != nil {
+			return nil, err
+		}
+		return &gameResolver{g: g, user: playerName}, nil
+
+	case args.ID != nil:
+		g, err := r.games(ctx).JoinGame(string(*args.ID), playerName)
+		if err != nil {
+			return nil, err
+		}
+		return &gameResolver{g: g, user: playerName}, nil
+
+	default:
+		return nil, fmt.Errorf("id or invite code is required: %w", game.ErrorInvalidInput)
+	}
+}
+
+func (r *resolver) PlaceShip(ctx context.Context, args struct {
+	GameID      gographql.ID
+	Type        string
+	Coordinate  string
+	Orientation string
+}) (*gameResolver, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, err := game.ParseCoordinate(args.Coordinate)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.update(ctx, player, string(args.GameID), func(g *game.Game) error {
+		return g.PlaceShip(player, fromShipType(args.Type), x, y, fromOrientation(args.Orientation))
+	})
+}
+
+// CancelGame deletes a game of the player nobody has joined yet and returns its ID
+func (r *resolver) CancelGame(ctx context.Context, args struct{ GameID gographql.ID }) (gographql.ID, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.games(ctx).CancelGame(string(args.GameID), player); err != nil {
+		return "", err
+	}
+	return args.GameID, nil
+}
+
+func (r *resolver) StartGame(ctx context.Context, args struct{ GameID gographql.ID }) (*gameResolver, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.update(ctx, player, string(args.GameID), func(g *game.Game) error {
+		return g.Start(player)
+	})
+}
+
+func (r *resolver) Fire(ctx context.Context, args struct {
+	GameID     gographql.ID
+	Coordinate string
+}) (*gameResolver, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, err := game.ParseCoordinate(args.Coordinate)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.update(ctx, player, string(args.GameID), func(g *game.Game) error {
+		return g.MakeMove(game.Move{Player: player, X: x, Y: y})
+	})
+}
+
+// update loads the game, applies the action of the player and stores the game
+func (r *resolver) update(ctx context.Context, player string, gameID string, action func(g *game.Game) error) (*gameResolver, error) {
+	g, err := r.games(ctx).GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := action(g); err != nil {
+		return nil, err
+	}
+
+	g, err = r.games(ctx).UpdateGame(g)
+	if err != nil {
+		return nil, err
+	}
+	return &gameResolver{g: g, user: player}, nil
+}
@@ -0,0 +1,265 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+var testFleet = []string{
+	"BATTLESHIP A1",
+	"CRUISER A3",
+	"CRUISER F3",
+	"DESTROYER A5",
+	"DESTROYER E5",
+	"DESTROYER A7",
+	"SUBMARINE E7",
+	"SUBMARINE I7",
+	"SUBMARINE A9",
+	"SUBMARINE D9",
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type testGame struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Private      bool   `json:"private"`
+	InviteCode   *string
+	Player2      *struct{ Name string }
+	PlayerToMove *string
+	Moves        int
+	History      []struct {
+		Player     string
+		Coordinate string
+		Hit        bool
+	}
+	Board *struct {
+		PinsAvailable int
+		Ships         []string
+		Shots         []string
+		Fleet         []struct {
+			Type        string
+			Coordinate  string
+			Orientation string
+			Length      int
+			Hits        int
+		}
+	}
+}
+
+const gameFields = `id status private inviteCode player2 { name } playerToMove moves
+	history { player coordinate hit }
+	board { pinsAvailable ships shots fleet { type coordinate orientation length hits } }`
+
+// query sends the query as the player, an empty player is a viewer
+func query(t *testing.T, handler http.Handler, player string, q string, variables map[string]any) response {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": q, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	if player != "" {
+		req = req.WithContext(WithPlayer(context.Background(), player))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+// mustQuery sends the query and decodes the field of the result into v
+func mustQuery(t *testing.T, handler http.Handler, player string, q string, variables map[string]any, field string, v any) {
+	t.Helper()
+	resp := query(t, handler, player, q, variables)
+	require.Empty(t, resp.Errors, q)
+	require.NoError(t, json.Unmarshal(resp.Data[field], v))
+}
+
+func newTestHandler(t *testing.T, players ...string) http.Handler {
+	api := game.NewApi(gametest.NewDatabase())
+	for _, player := range players {
+		_, err := api.NewPlayer(player)
+		require.NoError(t, err)
+	}
+	return NewHandler(api)
+}
+
+func placeFleet(t *testing.T, handler http.Handler, player string, gameID string) testGame {
+	var g testGame
+	for _, ship := range testFleet {
+		fields := strings.Fields(ship)
+		mustQuery(t, handler, player, `mutation($id: ID!, $type: ShipType!, $at: String!) {
+			placeShip(gameId: $id, type: $type, coordinate: $at, orientation: HORIZONTAL) { `+gameFields+` }
+		}`, map[string]any{"id": gameID, "type": fields[0], "at": fields[1]}, "placeShip", &g)
+	}
+	return g
+}
+
+func TestPlayAGame(t *testing.T) {
+	handler := newTestHandler(t, "alice", "bob")
+
+	var g testGame
+	mustQuery(t, handler, "alice", `mutation { createGame(name: "graphql") { `+gameFields+` } }`, nil, "createGame", &g)
+	assert.Equal(t, "SETUP", g.Status)
+	assert.Nil(t, g.Player2)
+	require.NotNil(t, g.Board)
+	assert.Equal(t, 30, g.Board.PinsAvailable)
+	assert.Equal(t, strings.Repeat(" ", 10), g.Board.Ships[0])
+
+	mustQuery(t, handler, "bob", `mutation($id: ID) { joinGame(id: $id) { `+gameFields+` } }`,
+		map[string]any{"id": g.ID}, "joinGame", &g)
+	require.NotNil(t, g.Player2)
+	assert.Equal(t, "bob", g.Player2.Name)
+
+	placeFleet(t, handler, "bob", g.ID)
+	g = placeFleet(t, handler, "alice", g.ID)
+	assert.Zero(t, g.Board.PinsAvailable)
+	require.Len(t, g.Board.Fleet, 10)
+	assert.Equal(t, "BATTLESHIP", g.Board.Fleet[0].Type)
+	assert.Equal(t, "A1", g.Board.Fleet[0].Coordinate)
+	assert.Equal(t, "HORIZONTAL", g.Board.Fleet[0].Orientation)
+	assert.Equal(t, 5, g.Board.Fleet[0].Length)
+	assert.Equal(t, "OOOOO     ", g.Board.Ships[0])
+
+	mustQuery(t, handler, "alice", `mutation($id: ID!) { startGame(gameId: $id) { id } }`,
+		map[string]any{"id": g.ID}, "startGame", &g)
+	mustQuery(t, handler, "alice", `mutation($id: ID!) { fire(gameId: $id, coordinate: "A1") { `+gameFields+` } }`,
+		map[string]any{"id": g.ID}, "fire", &g)
+	assert.Equal(t, "PLAYING", g.Status)
+	assert.Equal(t, 1, g.Moves)
+	require.Len(t, g.History, 1)
+	assert.Equal(t, "A1", g.History[0].Coordinate)
+	assert.True(t, g.History[0].Hit)
+	assert.Equal(t, "X         ", g.Board.Shots[0])
+	require.NotNil(t, g.PlayerToMove)
+	assert.Equal(t, "bob", *g.PlayerToMove)
+
+	// bob sees the hit on his own fleet, but not alice's fleet
+	mustQuery(t, handler, "bob", `query($id: ID!) { game(id: $id) { `+gameFields+` } }`,
+		map[string]any{"id": g.ID}, "game", &g)
+	require.NotNil(t, g.Board)
+	assert.Equal(t, "XOOOO     ", g.Board.Ships[0])
+	assert.Equal(t, 1, g.Board.Fleet[0].Hits)
+	assert.Equal(t, strings.Repeat(" ", 10), g.Board.Shots[0])
+
+	var games []testGame
+	mustQuery(t, handler, "", `{ games { `+gameFields+` } }`, nil, "games", &games)
+	require.Len(t, games, 1)
+	assert.Nil(t, games[0].Board, "viewers don't see the fleets")
+	assert.Equal(t, 1, games[0].Moves)
+}
+
+func TestPrivateGames(t *testing.T) {
+	handler := newTestHandler(t, "alice", "bob", "carol")
+
+	var g testGame
+	mustQuery(t, handler, "alice", `mutation { createGame(private: true) { `+gameFields+` } }`, nil, "createGame", &g)
+	assert.True(t, g.Private)
+	require.NotNil(t, g.InviteCode)
+	code := *g.InviteCode
+
+	for _, player := range []string{"carol", ""} {
+		var hidden *testGame
+		mustQuery(t, handler, player, `query($id: ID!) { game(id: $id) { id } }`,
+			map[string]any{"id": g.ID}, "game", &hidden)
+		assert.Nil(t, hidden, "private games don't exist for %q", player)
+	}
+
+	var viewed testGame
+	mustQuery(t, handler, "alice", `query($id: ID!) { game(id: $id) { `+gameFields+` } }`,
+		map[string]any{"id": g.ID}, "game", &viewed)
+	assert.Equal(t, code, *viewed.InviteCode)
+
+	mustQuery(t, handler, "bob", `mutation($code: String) { joinGame(inviteCode: $code) { `+gameFields+` } }`,
+		map[string]any{"code": code}, "joinGame", &g)
+	require.NotNil(t, g.Player2)
+	assert.Equal(t, "bob", g.Player2.Name)
+	assert.NotNil(t, g.InviteCode)
+}
+
+func TestCancelGame(t *testing.T) {
+	handler := newTestHandler(t, "alice", "bob")
+
+	var g testGame
+	mustQuery(t, handler, "alice", `mutation { createGame { `+gameFields+` } }`, nil, "createGame", &g)
+
+	resp := query(t, handler, "bob", `mutation($id: ID!) { cancelGame(gameId: $id) }`, map[string]any{"id": g.ID})
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "not allowed to cancel")
+
+	var cancelled string
+	mustQuery(t, handler, "alice", `mutation($id: ID!) { cancelGame(gameId: $id) }`,
+		map[string]any{"id": g.ID}, "cancelGame", &cancelled)
+	assert.Equal(t, g.ID, cancelled)
+
+	var found *testGame
+	mustQuery(t, handler, "alice", `query($id: ID!) { game(id: $id) { id } }`, map[string]any{"id": g.ID}, "game", &found)
+	assert.Nil(t, found)
+}
+
+func TestPlayers(t *testing.T) {
+	handler := newTestHandler(t, "alice")
+
+	var me *struct{ Name string }
+	mustQuery(t, handler, "alice", `{ me { name } }`, nil, "me", &me)
+	require.NotNil(t, me)
+	assert.Equal(t, "alice", me.Name)
+
+	mustQuery(t, handler, "", `{ me { name } }`, nil, "me", &me)
+	assert.Nil(t, me)
+
+	var player *struct{ ID, Name string }
+	mustQuery(t, handler, "", `{ player(name: "alice") { id name } }`, nil, "player", &player)
+	require.NotNil(t, player)
+	assert.Equal(t, "player-alice", player.ID)
+
+	mustQuery(t, handler, "", `{ player(name: "bob") { id name } }`, nil, "player", &player)
+	assert.Nil(t, player)
+}
+
+func TestErrors(t *testing.T) {
+	handler := newTestHandler(t, "alice")
+
+	resp := query(t, handler, "", `mutation { createGame { id } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "you must be logged in")
+
+	var g testGame
+	mustQuery(t, handler, "alice", `mutation { createGame { id } }`, nil, "createGame", &g)
+
+	resp = query(t, handler, "alice", `mutation($id: ID!) {
+		placeShip(gameId: $id, type: BATTLESHIP, coordinate: "K1", orientation: VERTICAL) { id }
+	}`, map[string]any{"id": g.ID})
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, game.ErrorInvalidInput.Error())
+
+	resp = query(t, handler, "alice", `mutation($id: ID!) { startGame(gameId: $id) { id } }`, map[string]any{"id": g.ID})
+	require.Len(t, resp.Errors, 1)
+
+	resp = query(t, handler, "alice", `mutation { joinGame { id } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "id or invite code is required")
+
+	resp = query(t, handler, "alice", `{ game(id: "no-such-game") { id } }`, nil)
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, "null", string(resp.Data["game"]))
+}
@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"strings"
+
+	gographql "github.com/graph-gophers/graphql-go"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// The enums of the schema are the upper case names of the game's types
+func fromShipType(t string) game.ShipType {
+	for _, shipType := range game.ShipTypes {
+		if strings.EqualFold(string(shipType), t) {
+			return shipType
+		}
+	}
+	return game.ShipType(t)
+}
+
+func fromOrientation(o string) game.ShipOrientation {
+	if o == "VERTICAL" {
+		return game.OrientationVertical
+	}
+	return game.OrientationHorizontal
+}
+
+type playerResolver struct {
+	p *game.Player
+}
+
+func (r *playerResolver) ID() gographql.ID { return gographql.ID(r.p.ID) }
+func (r *playerResolver) Name() string     { return r.p.Name }
+func (r *playerResolver) Score() int32     { return int32(r.p.Score) }
+
+// gameResolver resolves a game for the user, who is a player of the game or a viewer. The fleets stay in the
+// fog of war: a player only sees their own board, a viewer none.
+type gameResolver struct {
+	g    *game.Game
+	user string
+}
+
+func (r *gameResolver) ID() gographql.ID { return gographql.ID(r.g.ID) }
+
+func (r *gameResolver) Name() *string {
+	if r.g.Name == "" {
+		return nil
+	}
+	return &r.g.Name
+}
+
+func (r *gameResolver) Status() string {
+	return strings.ToUpper(r.g.Status.String())
+}
+
+func (r *gameResolver) Private() bool {
+	return r.g.Visibility == game.VisibilityPrivate
+}
+
+func (r *gameResolver) InviteCode() *string {
+	if !r.isPlayer() || r.g.InviteCode == "" {
+		return nil
+	}
+	code := game.FormatInviteCode(r.g.InviteCode)
+	return &code
+}
+
+func (r *gameResolver) Player1() *playerResolver {
+	return &playerResolver{r.g.Player1}
+}
+
+func (r *gameResolver) Player2() *playerResolver {
+	if len(r.g.Boards) < 2 {
+		return nil
+	}
+	return &playerResolver{r.g.Player2}
+}
+
+func (r *gameResolver) PlayerToMove() *string {
+	if r.g.PlayerToMove == "" {
+		return nil
+	}
+	return &r.g.PlayerToMove
+}
+
+func (r *gameResolver) Winner() *string {
+	winner := r.g.Winner()
+	if winner == "" {
+		return nil
+	}
+	return &winner
+}
+
+func (r *gameResolver) Moves() int32 {
+	return int32(len(r.g.History))
+}
+
+func (r *gameResolver) History() []*moveResolver {
+	moves := make([]*moveResolver, 0, len(r.g.History))
+	for _, move := range r.g.History {
+		moves = append(moves, &moveResolver{move})
+	}
+	return moves
+}
+
+func (r *gameResolver) Board() *boardResolver {
+	if !r.isPlayer() {
+		return nil
+	}
+	return &boardResolver{r.g.Boards[r.user]}
+}
+
+func (r *gameResolver) CreatedAt() gographql.Time { return gographql.Time{Time: r.g.CreatedAt} }
+func (r *gameResolver) UpdatedAt() gographql.Time { return gographql.Time{Time: r.g.UpdatedAt} }
+
+func (r *gameResolver) isPlayer() bool {
+	_, ok := r.g.Boards[r.user]
+	return r.user != "" && ok
+}
+
+type moveResolver struct {
+	m game.Move
+}
+
+func (r *moveResolver) Player() string     { return r.m.Player }
+func (r *moveResolver) Coordinate() string { return game.FormatCoordinate(r.m.X, r.m.Y) }
+func (r *moveResolver) X() int32           { return int32(r.m.X) }
+func (r *moveResolver) Y() int32           { return int32(r.m.Y) }
+func (r *moveResolver) Hit() bool          { return r.m.Hit }
+
+type boardResolver struct {
+	b *game.Board
+}
+
+func (r *boardResolver) PinsAvailable() int32 { return int32(r.b.PinsAvailable) }
+func (r *boardResolver) Ships() []string      { return rows(r.b.ShipsMap()) }
+func (r *boardResolver) Shots() []string      { return rows(r.b.ShotsMap()) }
+
+func (r *boardResolver) Fleet() []*shipResolver {
+	ships := make([]*shipResolver, 0, len(r.b.Fleet))
+	for _, ship := range r.b.Fleet {
+		ships = append(ships, &shipResolver{ship})
+	}
+	return ships
+}
+
+func rows(m *game.BoardMap) []string {
+	rows := make([]string, 0, len(m.Map))
+	for _, row := range m.Map {
+		fields := make([]byte, len(row))
+		for x, field := range row {
+			fields[x] = byte(field)
+		}
+		rows = append(rows, string(fields))
+	}
+	return rows
+}
+
+type shipResolver struct {
+	s *game.Ship
+}
+
+func (r *shipResolver) Type() string {
+	return strings.ToUpper(string(r.s.ShipType))
+}
+
+func (r *shipResolver) Coordinate() string {
+	return game.FormatCoordinate(r.s.Position.X, r.s.Position.Y)
+}
+
+func (r *shipResolver) Orientation() string {
+	return strings.ToUpper(string(r.s.Orientation))
+}
+
+func (r *shipResolver) Length() int32 { return int32(r.s.Length) }
+
+func (r *shipResolver) Hits() int32 {
+	hits := int32(0)
+	for _, hit := range r.s.Hits {
+		if hit {
+			hits++
+		}
+	}
+	return hits
+}
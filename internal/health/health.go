@@ -0,0 +1,159 @@
+// Package health reports whether the server is ready to serve: its dependencies answer and its background
+// workers are running.
+package health
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the time a check has to answer before it counts as failed
+const DefaultTimeout = 2 * time.Second
+
+// Statuses of the checks and workers
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusRunning = "running"
+	StatusStopped = "stopped"
+)
+
+// Check is the result of one check
+type Check struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Worker is the state of a background worker
+type Worker struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Report is the readiness of the server. Error sums up what is not ready.
+type Report struct {
+	Ready   bool     `json:"ready"`
+	Error   string   `json:"error,omitempty"`
+	Checks  []Check  `json:"checks"`
+	Workers []Worker `json:"workers"`
+}
+
+type check struct {
+	name string
+	run  func() error
+}
+
+type worker struct {
+	name    string
+	running bool
+}
+
+// Checker runs the checks of the dependencies and watches the background workers
+type Checker struct {
+	// Timeout bounds every check
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	checks  []check
+	workers []*worker
+}
+
+func New() *Checker {
+	return &Checker{
+		Timeout: DefaultTimeout,
+	}
+}
+
+// AddCheck adds a check of a dependency, e.g. the Ping of the storage
+func (c *Checker) AddCheck(name string, run func() error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, run: run})
+}
+
+// Go runs a background worker in a goroutine. The server is not ready once the worker returned.
+func (c *Checker) Go(name string, run func()) {
+	w := &worker{name: name, running: true}
+	c.mu.Lock()
+	c.workers = append(c.workers, w)
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			w.running = false
+			c.mu.Unlock()
+		}()
+		run()
+	}()
+}
+
+// Ready runs all checks at the same time and reports the state of the workers
+func (c *Checker) Ready() Report {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	workers := make([]Worker, len(c.workers))
+	for i, w := range c.workers {
+		workers[i] = Worker{Name: w.name, Status: StatusStopped}
+		if w.running {
+			workers[i].Status = StatusRunning
+		}
+	}
+	c.mu.Unlock()
+
+	results := make([]Check, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ch)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Ready: true, Checks: results, Workers: workers}
+	var problems []string
+	for _, r := range results {
+		if r.Status != StatusOK {
+			problems = append(problems, fmt.Sprintf("%s: %s", r.Name, r.Error))
+		}
+	}
+	for _, w := range workers {
+		if w.Status != StatusRunning {
+			problems = append(problems, fmt.Sprintf("%s: %s", w.Name, w.Status))
+		}
+	}
+	if len(problems) > 0 {
+		report.Ready = false
+		report.Error = "not ready: " + strings.Join(problems, ", ")
+	}
+	return report
+}
+
+// run runs a check with the timeout. A check which does not answer in time keeps running in the background.
+func (c *Checker) run(ch check) Check {
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- ch.run()
+	}()
+
+	result := Check{Name: ch.name, Status: StatusOK}
+	select {
+	case err := <-done:
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err.Error()
+		}
+	case <-time.After(c.Timeout):
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("no answer within %s", c.Timeout)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
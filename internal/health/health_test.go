@@ -0,0 +1,55 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReady(t *testing.T) {
+	c := New()
+	report := c.Ready()
+	assert.True(t, report.Ready, "nothing to check")
+	assert.Empty(t, report.Checks)
+
+	c.AddCheck("storage", func() error { return nil })
+	stop := make(chan struct{})
+	c.Go("webhooks", func() { <-stop })
+
+	report = c.Ready()
+	assert.True(t, report.Ready)
+	assert.Empty(t, report.Error)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, StatusOK, report.Checks[0].Status)
+	assert.Equal(t, []Worker{{Name: "webhooks", Status: StatusRunning}}, report.Workers)
+
+	close(stop)
+	assert.Eventually(t, func() bool {
+		return !c.Ready().Ready
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "not ready: webhooks: stopped", c.Ready().Error)
+}
+
+func TestFailedChecks(t *testing.T) {
+	c := New()
+	c.Timeout = 10 * time.Millisecond
+	c.AddCheck("storage", func() error { return errors.New("connection refused") })
+	block := make(chan struct{})
+	defer close(block)
+	c.AddCheck("slow", func() error {
+		<-block
+		return nil
+	})
+
+	report := c.Ready()
+	assert.False(t, report.Ready)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, Check{Name: "storage", Status: StatusFailed, Error: "connection refused",
+		Duration: report.Checks[0].Duration}, report.Checks[0])
+	assert.Equal(t, StatusFailed, report.Checks[1].Status)
+	assert.Equal(t, "no answer within 10ms", report.Checks[1].Error)
+	assert.Equal(t, "not ready: storage: connection refused, slow: no answer within 10ms", report.Error)
+}
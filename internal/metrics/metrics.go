@@ -0,0 +1,278 @@
+// Package metrics collects the Prometheus metrics of the server: HTTP requests, games, moves, sessions and
+// storage operations.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+const namespace = "battleship"
+
+// DefaultSessionWindow is how long a player counts as active after their last request, the lifetime of a
+// session
+const DefaultSessionWindow = 15 * time.Minute
+
+// DefaultMoveCountWindow is how long the move count of a game without updates is kept. Games left before they
+// are finished are forgotten after it; if one is played on later, all its moves are counted again.
+const DefaultMoveCountWindow = 24 * time.Hour
+
+// moveCountPruneInterval is how often the move counts are checked for games without updates
+const moveCountPruneInterval = time.Minute
+
+// Games counts the games by status
+type Games interface {
+	CountGames() (map[game.Status]int, error)
+}
+
+// Metrics holds the metrics of one server. Each instance has its own registry, so tests can create as many
+// as they like.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	moves           prometheus.Counter
+	storageDuration *prometheus.HistogramVec
+	storageErrors   *prometheus.CounterVec
+
+	// SessionWindow is how long a player counts as active after their last request
+	SessionWindow time.Duration
+	// MoveCountWindow is how long the move count of a game without updates is kept
+	MoveCountWindow time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]time.Time
+	// moveCounts holds the number of moves of every game in progress, to count the new ones of an update
+	moveCounts map[string]moveCount
+	prunedAt   time.Time
+}
+
+type moveCount struct {
+	moves   int
+	updated time.Time
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		moves: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "moves_total",
+			Help:      "Moves played in all games, rate() gives the moves per second.",
+		}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "storage_operation_duration_seconds",
+			Help:      "Latency of the storage operations by operation.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"}),
+		storageErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "storage_operation_errors_total",
+			Help:      "Failed storage operations by operation.",
+		}, []string{"operation"}),
+		SessionWindow:   DefaultSessionWindow,
+		MoveCountWindow: DefaultMoveCountWindow,
+		sessions:        make(map[string]time.Time),
+		moveCounts:      make(map[string]moveCount),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.moves,
+		m.storageDuration,
+		m.storageErrors,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_sessions",
+			Help:      "Players with a session who sent a request within the session window.",
+		}, m.activeSessions),
+	)
+	return m
+}
+
+// CountGames adds the number of games by status, which are counted when the metrics are scraped
+func (m *Metrics) CountGames(games Games) {
+	m.registry.MustRegister(&gamesCollector{games: games})
+}
+
+// Handler serves the metrics in the Prometheus exposition format. Metrics which cannot be collected, like the
+// games while the database is down, are left out.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		Registry:      m.registry,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Middleware counts the requests and their latency by route. Requests not matching a route share the route
+// "unmatched", so scanners cannot create a series per path.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.requests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.requestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveStorage records a storage operation, see storage.Instrument
+func (m *Metrics) ObserveStorage(operation string, duration time.Duration, err error) {
+	m.storageDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		m.storageErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// SessionSeen marks the player as active
+func (m *Metrics) SessionSeen(player string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[player] = time.Now()
+}
+
+// SessionEnded removes the player from the active sessions, e.g. on logout
+func (m *Metrics) SessionEnded(player string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, player)
+}
+
+// activeSessions counts the players seen within the window and forgets the others
+func (m *Metrics) activeSessions() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := 0
+	for player, seen := range m.sessions {
+		if time.Since(seen) > m.SessionWindow {
+			delete(m.sessions, player)
+			continue
+		}
+		active++
+	}
+	return float64(active)
+}
+
+// Watch counts the moves of the game events until the channel is closed or the context is cancelled. Pass
+// the events of all games, see game.API.Subscribe.
+func (m *Metrics) Watch(ctx context.Context, events <-chan game.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			m.countMoves(e)
+		}
+	}
+}
+
+// countMoves adds the moves a game gained since its last event. A game only seen once it is in progress
+// adds all its moves; a missed event is caught up with the next one. Finished games are forgotten, and so
+// are games without updates within the move count window.
+func (m *Metrics) countMoves(e game.Event) {
+	if e.Game == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.pruneMoveCounts(now)
+
+	if moves := e.Game.Moves - m.moveCounts[e.GameID].moves; moves > 0 {
+		m.moves.Add(float64(moves))
+	}
+	if e.Game.Status == game.StatusWon || e.Game.Status == game.StatusLost {
+		delete(m.moveCounts, e.GameID)
+		return
+	}
+	m.moveCounts[e.GameID] = moveCount{moves: e.Game.Moves, updated: now}
+}
+
+// pruneMoveCounts forgets the games without updates within the move count window, e.g. games the players
+// left. It only checks once per prune interval.
+func (m *Metrics) pruneMoveCounts(now time.Time) {
+	if now.Sub(m.prunedAt) < moveCountPruneInterval {
+		return
+	}
+	m.prunedAt = now
+
+	for gameID, count := range m.moveCounts {
+		if now.Sub(count.updated) > m.MoveCountWindow {
+			delete(m.moveCounts, gameID)
+		}
+	}
+}
+
+var gamesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "games"),
+	"Games by status.",
+	[]string{"status"}, nil,
+)
+
+// gamesCollector counts the games by status on every scrape, the database does the counting
+type gamesCollector struct {
+	games Games
+}
+
+func (c *gamesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gamesDesc
+}
+
+func (c *gamesCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := map[game.Status]int{
+		game.StatusSetup:   0,
+		game.StatusPlaying: 0,
+		game.StatusWon:     0,
+		game.StatusLost:    0,
+	}
+	byStatus, err := c.games.CountGames()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(gamesDesc, err)
+		return
+	}
+	for status, count := range byStatus {
+		counts[status] = count
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(gamesDesc, prometheus.GaugeValue, float64(count),
+			strings.ToLower(status.String()))
+	}
+}
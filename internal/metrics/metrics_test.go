@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// stubGames returns fixed game counts
+type stubGames struct {
+	counts map[game.Status]int
+	err    error
+}
+
+func (s *stubGames) CountGames() (map[game.Status]int, error) {
+	return s.counts, s.err
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := New()
+	engine := gin.New()
+	engine.Use(m.Middleware())
+	engine.GET("/api/games/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/api/games/1", "/api/games/2", "/wp-admin"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/api/games/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "unmatched", "404")))
+	assert.Contains(t, scrape(t, m), `battleship_http_request_duration_seconds_count{method="GET",route="/api/games/:id"} 2`)
+}
+
+func TestObserveStorage(t *testing.T) {
+	m := New()
+	m.ObserveStorage("FindGameByID", time.Millisecond, nil)
+	m.ObserveStorage("FindGameByID", time.Millisecond, errors.New("timeout"))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.storageErrors.WithLabelValues("FindGameByID")))
+	assert.Contains(t, scrape(t, m), `battleship_storage_operation_duration_seconds_count{operation="FindGameByID"} 2`)
+}
+
+func TestActiveSessions(t *testing.T) {
+	m := New()
+	m.SessionSeen("alice")
+	m.SessionSeen("bob")
+	m.SessionSeen("alice")
+	assert.Equal(t, 2.0, m.activeSessions())
+
+	m.SessionEnded("bob")
+	assert.Equal(t, 1.0, m.activeSessions())
+
+	m.SessionWindow = 0
+	assert.Equal(t, 0.0, m.activeSessions(), "sessions expire after the window")
+	assert.Empty(t, m.sessions)
+}
+
+func TestWatchCountsMoves(t *testing.T) {
+	m := New()
+	events := make(chan game.Event, 8)
+	update := func(gameID string, moves int, status game.Status) {
+		events <- game.Event{Type: game.EventGameUpdated, GameID: gameID, Game: &game.Summary{Moves: moves, Status: status}}
+	}
+	update("game1", 1, game.StatusPlaying)
+	update("game1", 2, game.StatusPlaying)
+	update("game1", 2, game.StatusPlaying)
+	update("game2", 3, game.StatusPlaying)
+	update("game1", 5, game.StatusWon)
+	events <- game.Event{Type: game.EventChatMessage, GameID: "game2"}
+	close(events)
+
+	m.Watch(context.Background(), events)
+	assert.Equal(t, 8.0, testutil.ToFloat64(m.moves))
+	require.Len(t, m.moveCounts, 1, "finished games are forgotten")
+	assert.Equal(t, 3, m.moveCounts["game2"].moves)
+}
+
+func TestWatchForgetsLeftGames(t *testing.T) {
+	m := New()
+	m.MoveCountWindow = time.Hour
+	m.moveCounts["left"] = moveCount{moves: 12, updated: time.Now().Add(-2 * time.Hour)}
+	m.moveCounts["played"] = moveCount{moves: 4, updated: time.Now().Add(-time.Minute)}
+
+	events := make(chan game.Event, 1)
+	events <- game.Event{Type: game.EventGameUpdated, GameID: "played", Game: &game.Summary{Moves: 5, Status: game.StatusPlaying}}
+	close(events)
+
+	m.Watch(context.Background(), events)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.moves))
+	assert.NotContains(t, m.moveCounts, "left", "games without updates within the window are forgotten")
+	assert.Equal(t, 5, m.moveCounts["played"].moves)
+}
+
+func TestCountGames(t *testing.T) {
+	m := New()
+	m.CountGames(&stubGames{counts: map[game.Status]int{game.StatusSetup: 102, game.StatusPlaying: 101}})
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `battleship_games{status="setup"} 102`)
+	assert.Contains(t, body, `battleship_games{status="playing"} 101`)
+	assert.Contains(t, body, `battleship_games{status="won"} 0`)
+
+	m = New()
+	m.CountGames(&stubGames{err: errors.New("database down")})
+	body = scrape(t, m)
+	assert.NotContains(t, body, "battleship_games{", "games are left out while they cannot be counted")
+	assert.Contains(t, body, "battleship_active_sessions 0")
+}
@@ -0,0 +1,99 @@
+// Package ratelimit limits the rate of requests with token buckets kept in a pluggable store.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket: it holds up to Burst tokens and refills at Rate tokens per second. Every request
+// takes a token. A Rate of 0 means no limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Store keeps the token buckets by key, e.g. a client IP. MemoryStore keeps them in the process; a store
+// shared by several server instances limits the requests across them.
+type Store interface {
+	// Take takes a token from the bucket of the key. If the bucket is empty it returns false and the time
+	// until the next token.
+	Take(key string, limit Limit) (bool, time.Duration, error)
+}
+
+// sweepInterval is how often MemoryStore forgets the buckets which have been refilled completely
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket is refilled completely, afterwards it is the same as a new one
+	full time.Time
+}
+
+// MemoryStore keeps the token buckets in memory. Full buckets are forgotten, so the memory grows with the
+// recent clients only.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(key string, limit Limit) (bool, time.Duration, error) {
+	if limit.Rate <= 0 {
+		return true, 0, nil
+	}
+	burst := float64(max(limit.Burst, 1))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst}
+		s.buckets[key] = b
+	} else {
+		b.tokens = min(burst, b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	}
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.full = now.Add(seconds((burst - b.tokens) / limit.Rate))
+
+	if allowed {
+		return true, 0, nil
+	}
+	return false, seconds((1 - b.tokens) / limit.Rate), nil
+}
+
+// sweep forgets the full buckets once per sweep interval
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestMemoryStore(t *testing.T) {
+	s, now := newTestStore()
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for range 3 {
+		ok, _, err := s.Take("alice", limit)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, wait, _ := s.Take("alice", limit)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// other keys have their own bucket
+	ok, _, _ = s.Take("bob", limit)
+	assert.True(t, ok)
+
+	*now = now.Add(500 * time.Millisecond)
+	ok, _, _ = s.Take("alice", limit)
+	assert.True(t, ok)
+	ok, _, _ = s.Take("alice", limit)
+	assert.False(t, ok)
+
+	// the bucket does not fill beyond the burst
+	*now = now.Add(time.Hour)
+	for range 3 {
+		ok, _, _ = s.Take("alice", limit)
+		assert.True(t, ok)
+	}
+	ok, _, _ = s.Take("alice", limit)
+	assert.False(t, ok)
+}
+
+func TestMemoryStoreWithoutLimit(t *testing.T) {
+	s, _ := newTestStore()
+	for range 100 {
+		ok, _, _ := s.Take("alice", Limit{})
+		assert.True(t, ok)
+	}
+	assert.Empty(t, s.buckets)
+}
+
+func TestMemoryStoreForgetsFullBuckets(t *testing.T) {
+	s, now := newTestStore()
+	limit := Limit{Rate: 1, Burst: 10}
+
+	s.Take("alice", limit)
+	*now = now.Add(2 * sweepInterval)
+	s.Take("bob", limit)
+	assert.NotContains(t, s.buckets, "alice")
+	assert.Contains(t, s.buckets, "bob")
+}
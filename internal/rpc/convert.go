@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/rpc/pb"
+)
+
+var shipTypes = map[game.ShipType]pb.ShipType{
+	game.Battleship: pb.ShipType_SHIP_TYPE_BATTLESHIP,
+	game.Cruiser:    pb.ShipType_SHIP_TYPE_CRUISER,
+	game.Destroyer:  pb.ShipType_SHIP_TYPE_DESTROYER,
+	game.Submarine:  pb.ShipType_SHIP_TYPE_SUBMARINE,
+}
+
+var orientations = map[game.ShipOrientation]pb.Orientation{
+	game.OrientationHorizontal: pb.Orientation_ORIENTATION_HORIZONTAL,
+	game.OrientationVertical:   pb.Orientation_ORIENTATION_VERTICAL,
+}
+
+// mapError maps an error of the game package to a gRPC status, like mapErrorToStatusErr of the REST API
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, game.ErrorNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, game.ErrorIllegal):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, game.ErrorInvalid), errors.Is(err, game.ErrorInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, game.ErrorNotReady):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, game.ErrorLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func fromShipType(t pb.ShipType) (game.ShipType, error) {
+	for shipType, v := range shipTypes {
+		if v == t {
+			return shipType, nil
+		}
+	}
+	return "", fmt.Errorf("invalid ship type %s: %w", t, game.ErrorInvalidInput)
+}
+
+func fromOrientation(o pb.Orientation) (game.ShipOrientation, error) {
+	for orientation, v := range orientations {
+		if v == o {
+			return orientation, nil
+		}
+	}
+	return "", fmt.Errorf("invalid orientation %s: %w", o, game.ErrorInvalidInput)
+}
+
+// fromPosition returns the field of a position given as coordinate or as x and y
+func fromPosition(p *pb.Position) (int, int, error) {
+	if p == nil {
+		return 0, 0, fmt.Errorf("position is missing: %w", game.ErrorInvalidInput)
+	}
+	if p.Coordinate != "" {
+		return game.ParseCoordinate(p.Coordinate)
+	}
+	return int(p.X), int(p.Y), nil
+}
+
+func toPlayer(p *game.Player) *pb.Player {
+	if p == nil {
+		return nil
+	}
+	return &pb.Player{Id: p.ID, Name: p.Name, Score: int32(p.Score)}
+}
+
+func toStatus(s game.Status) pb.GameStatus {
+	return pb.GameStatus(s + 1)
+}
+
+// toGame returns the game from the point of view of the user, who only sees their own board
+func toGame(user string, g *game.Game) *pb.Game {
+	view := &pb.Game{
+		Id:           g.ID,
+		Name:         g.Name,
+		User:         user,
+		Status:       toStatus(g.Status),
+		Private:      g.Visibility == game.VisibilityPrivate,
+		Player1:      toPlayer(g.Player1),
+		Player2:      toPlayer(g.Player2),
+		PlayerToMove: g.PlayerToMove,
+		CreatedAt:    timestamppb.New(g.CreatedAt),
+		UpdatedAt:    timestamppb.New(g.UpdatedAt),
+	}
+	for _, move := range g.History {
+		view.History = append(view.History, &pb.Move{Player: move.Player, X: int32(move.X), Y: int32(move.Y), Hit: move.Hit})
+	}
+
+	// only the players see their board and may pass the invite code on
+	if board, ok := g.Boards[user]; ok {
+		view.Board = toBoard(board)
+		view.InviteCode = game.FormatInviteCode(g.InviteCode)
+	}
+	return view
+}
+
+func toBoard(b *game.Board) *pb.Board {
+	board := &pb.Board{
+		PinsAvailable: int32(b.PinsAvailable),
+		Ships:         toBoardMap(b.ShipsMap()),
+		Shots:         toBoardMap(b.ShotsMap()),
+	}
+	for _, ship := range b.Fleet {
+		board.Fleet = append(board.Fleet, &pb.Ship{
+			Type:        shipTypes[ship.ShipType],
+			X:           int32(ship.Position.X),
+			Y:           int32(ship.Position.Y),
+			Orientation: orientations[ship.Orientation],
+			Length:      int32(ship.Length),
+			Hits:        ship.Hits,
+		})
+	}
+	return board
+}
+
+func toBoardMap(m *game.BoardMap) *pb.BoardMap {
+	boardMap := &pb.BoardMap{Title: m.Title}
+	for _, row := range m.Map {
+		fields := make([]byte, len(row))
+		for x, field := range row {
+			fields[x] = byte(field)
+		}
+		boardMap.Rows = append(boardMap.Rows, string(fields))
+	}
+	return boardMap
+}
+
+func toSummary(s *game.Summary) *pb.GameSummary {
+	if s == nil {
+		return nil
+	}
+	return &pb.GameSummary{
+		Id:        s.ID,
+		Name:      s.Name,
+		Status:    toStatus(s.Status),
+		Player1:   toPlayer(s.Player1),
+		Player2:   toPlayer(s.Player2),
+		Moves:     int32(s.Moves),
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func toEvent(e game.Event) *pb.GameEvent {
+	event := &pb.GameEvent{
+		Type:   string(e.Type),
+		GameId: e.GameID,
+		Time:   timestamppb.New(e.Time),
+		Game:   toSummary(e.Game),
+	}
+	if m := e.Message; m != nil {
+		event.Message = &pb.ChatMessage{
+			Id:        m.ID,
+			GameId:    m.GameID,
+			Player:    m.Player,
+			Text:      m.Text,
+			CreatedAt: timestamppb.New(m.CreatedAt),
+		}
+	}
+	return event
+}
@@ -0,0 +1,2074 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: battleship/v1/battleship.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GameStatus int32
+
+const (
+	GameStatus_GAME_STATUS_UNSPECIFIED GameStatus = 0
+	GameStatus_GAME_STATUS_SETUP       GameStatus = 1
+	GameStatus_GAME_STATUS_PLAYING     GameStatus = 2
+	// Player 1 won
+	GameStatus_GAME_STATUS_WON GameStatus = 3
+	// Player 2 won
+	GameStatus_GAME_STATUS_LOST GameStatus = 4
+)
+
+// Enum value maps for GameStatus.
+var (
+	GameStatus_name = map[int32]string{
+		0: "GAME_STATUS_UNSPECIFIED",
+		1: "GAME_STATUS_SETUP",
+		2: "GAME_STATUS_PLAYING",
+		3: "GAME_STATUS_WON",
+		4: "GAME_STATUS_LOST",
+	}
+	GameStatus_value = map[string]int32{
+		"GAME_STATUS_UNSPECIFIED": 0,
+		"GAME_STATUS_SETUP":       1,
+		"GAME_STATUS_PLAYING":     2,
+		"GAME_STATUS_WON":         3,
+		"GAME_STATUS_LOST":        4,
+	}
+)
+
+func (x GameStatus) Enum() *GameStatus {
+	p := new(GameStatus)
+	*p = x
+	return p
+}
+
+func (x GameStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_battleship_v1_battleship_proto_enumTypes[0].Descriptor()
+}
+
+func (GameStatus) Type() protoreflect.EnumType {
+	return &file_battleship_v1_battleship_proto_enumTypes[0]
+}
+
+func (x GameStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameStatus.Descriptor instead.
+func (GameStatus) EnumDescriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{0}
+}
+
+type ShipType int32
+
+const (
+	ShipType_SHIP_TYPE_UNSPECIFIED ShipType = 0
+	ShipType_SHIP_TYPE_BATTLESHIP  ShipType = 1
+	ShipType_SHIP_TYPE_CRUISER     ShipType = 2
+	ShipType_SHIP_TYPE_DESTROYER   ShipType = 3
+	ShipType_SHIP_TYPE_SUBMARINE   ShipType = 4
+)
+
+// Enum value maps for ShipType.
+var (
+	ShipType_name = map[int32]string{
+		0: "SHIP_TYPE_UNSPECIFIED",
+		1: "SHIP_TYPE_BATTLESHIP",
+		2: "SHIP_TYPE_CRUISER",
+		3: "SHIP_TYPE_DESTROYER",
+		4: "SHIP_TYPE_SUBMARINE",
+	}
+	ShipType_value = map[string]int32{
+		"SHIP_TYPE_UNSPECIFIED": 0,
+		"SHIP_TYPE_BATTLESHIP":  1,
+		"SHIP_TYPE_CRUISER":     2,
+		"SHIP_TYPE_DESTROYER":   3,
+		"SHIP_TYPE_SUBMARINE":   4,
+	}
+)
+
+func (x ShipType) Enum() *ShipType {
+	p := new(ShipType)
+	*p = x
+	return p
+}
+
+func (x ShipType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShipType) Descriptor() protoreflect.EnumDescriptor {
+	return file_battleship_v1_battleship_proto_enumTypes[1].Descriptor()
+}
+
+func (ShipType) Type() protoreflect.EnumType {
+	return &file_battleship_v1_battleship_proto_enumTypes[1]
+}
+
+func (x ShipType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShipType.Descriptor instead.
+func (ShipType) EnumDescriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{1}
+}
+
+type Orientation int32
+
+const (
+	Orientation_ORIENTATION_UNSPECIFIED Orientation = 0
+	Orientation_ORIENTATION_HORIZONTAL  Orientation = 1
+	Orientation_ORIENTATION_VERTICAL    Orientation = 2
+)
+
+// Enum value maps for Orientation.
+var (
+	Orientation_name = map[int32]string{
+		0: "ORIENTATION_UNSPECIFIED",
+		1: "ORIENTATION_HORIZONTAL",
+		2: "ORIENTATION_VERTICAL",
+	}
+	Orientation_value = map[string]int32{
+		"ORIENTATION_UNSPECIFIED": 0,
+		"ORIENTATION_HORIZONTAL":  1,
+		"ORIENTATION_VERTICAL":    2,
+	}
+)
+
+func (x Orientation) Enum() *Orientation {
+	p := new(Orientation)
+	*p = x
+	return p
+}
+
+func (x Orientation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Orientation) Descriptor() protoreflect.EnumDescriptor {
+	return file_battleship_v1_battleship_proto_enumTypes[2].Descriptor()
+}
+
+func (Orientation) Type() protoreflect.EnumType {
+	return &file_battleship_v1_battleship_proto_enumTypes[2]
+}
+
+func (x Orientation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Orientation.Descriptor instead.
+func (Orientation) EnumDescriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{2}
+}
+
+type Player struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// Position is a field of the board. Either x and y, counted from 0, or a coordinate like "B7" is given.
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Coordinate    string                 `protobuf:"bytes,3,opt,name=coordinate,proto3" json:"coordinate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Position) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Position) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Position) GetCoordinate() string {
+	if x != nil {
+		return x.Coordinate
+	}
+	return ""
+}
+
+type Move struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
+	Hit           bool                   `protobuf:"varint,4,opt,name=hit,proto3" json:"hit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Move) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *Move) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Move) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Move) GetHit() bool {
+	if x != nil {
+		return x.Hit
+	}
+	return false
+}
+
+type Ship struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          ShipType               `protobuf:"varint,1,opt,name=type,proto3,enum=battleship.v1.ShipType" json:"type,omitempty"`
+	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
+	Orientation   Orientation            `protobuf:"varint,4,opt,name=orientation,proto3,enum=battleship.v1.Orientation" json:"orientation,omitempty"`
+	Length        int32                  `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	Hits          []bool                 `protobuf:"varint,6,rep,packed,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ship) Reset() {
+	*x = Ship{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ship) ProtoMessage() {}
+
+func (x *Ship) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ship.ProtoReflect.Descriptor instead.
+func (*Ship) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Ship) GetType() ShipType {
+	if x != nil {
+		return x.Type
+	}
+	return ShipType_SHIP_TYPE_UNSPECIFIED
+}
+
+func (x *Ship) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Ship) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Ship) GetOrientation() Orientation {
+	if x != nil {
+		return x.Orientation
+	}
+	return Orientation_ORIENTATION_UNSPECIFIED
+}
+
+func (x *Ship) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Ship) GetHits() []bool {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// BoardMap is a grid of ten rows. Each row has ten fields: ' ' is empty, 'O' a ship, 'X' a hit and '-' a miss.
+type BoardMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Rows          []string               `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoardMap) Reset() {
+	*x = BoardMap{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoardMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardMap) ProtoMessage() {}
+
+func (x *BoardMap) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardMap.ProtoReflect.Descriptor instead.
+func (*BoardMap) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BoardMap) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BoardMap) GetRows() []string {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Board is a player's own fleet and the shots at the opponent
+type Board struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PinsAvailable int32                  `protobuf:"varint,1,opt,name=pins_available,json=pinsAvailable,proto3" json:"pins_available,omitempty"`
+	Ships         *BoardMap              `protobuf:"bytes,2,opt,name=ships,proto3" json:"ships,omitempty"`
+	Shots         *BoardMap              `protobuf:"bytes,3,opt,name=shots,proto3" json:"shots,omitempty"`
+	Fleet         []*Ship                `protobuf:"bytes,4,rep,name=fleet,proto3" json:"fleet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Board) GetPinsAvailable() int32 {
+	if x != nil {
+		return x.PinsAvailable
+	}
+	return 0
+}
+
+func (x *Board) GetShips() *BoardMap {
+	if x != nil {
+		return x.Ships
+	}
+	return nil
+}
+
+func (x *Board) GetShots() *BoardMap {
+	if x != nil {
+		return x.Shots
+	}
+	return nil
+}
+
+func (x *Board) GetFleet() []*Ship {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+// Game is a game from the point of view of a user: a player sees their own board, a viewer none
+type Game struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	User    string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Board   *Board                 `protobuf:"bytes,4,opt,name=board,proto3" json:"board,omitempty"`
+	History []*Move                `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	Status  GameStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=battleship.v1.GameStatus" json:"status,omitempty"`
+	Private bool                   `protobuf:"varint,7,opt,name=private,proto3" json:"private,omitempty"`
+	// invite_code is only shown to the players
+	InviteCode    string                 `protobuf:"bytes,8,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	Player1       *Player                `protobuf:"bytes,9,opt,name=player1,proto3" json:"player1,omitempty"`
+	Player2       *Player                `protobuf:"bytes,10,opt,name=player2,proto3" json:"player2,omitempty"`
+	PlayerToMove  string                 `protobuf:"bytes,11,opt,name=player_to_move,json=playerToMove,proto3" json:"player_to_move,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Game) Reset() {
+	*x = Game{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Game) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Game) ProtoMessage() {}
+
+func (x *Game) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Game.ProtoReflect.Descriptor instead.
+func (*Game) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Game) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Game) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Game) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Game) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *Game) GetHistory() []*Move {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Game) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *Game) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *Game) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *Game) GetPlayer1() *Player {
+	if x != nil {
+		return x.Player1
+	}
+	return nil
+}
+
+func (x *Game) GetPlayer2() *Player {
+	if x != nil {
+		return x.Player2
+	}
+	return nil
+}
+
+func (x *Game) GetPlayerToMove() string {
+	if x != nil {
+		return x.PlayerToMove
+	}
+	return ""
+}
+
+func (x *Game) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Game) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GameSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        GameStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=battleship.v1.GameStatus" json:"status,omitempty"`
+	Player1       *Player                `protobuf:"bytes,4,opt,name=player1,proto3" json:"player1,omitempty"`
+	Player2       *Player                `protobuf:"bytes,5,opt,name=player2,proto3" json:"player2,omitempty"`
+	Moves         int32                  `protobuf:"varint,6,opt,name=moves,proto3" json:"moves,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameSummary) Reset() {
+	*x = GameSummary{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSummary) ProtoMessage() {}
+
+func (x *GameSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSummary.ProtoReflect.Descriptor instead.
+func (*GameSummary) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GameSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GameSummary) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *GameSummary) GetPlayer1() *Player {
+	if x != nil {
+		return x.Player1
+	}
+	return nil
+}
+
+func (x *GameSummary) GetPlayer2() *Player {
+	if x != nil {
+		return x.Player2
+	}
+	return nil
+}
+
+func (x *GameSummary) GetMoves() int32 {
+	if x != nil {
+		return x.Moves
+	}
+	return 0
+}
+
+func (x *GameSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GameSummary) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Player        string                 `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChatMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// GameEvent carries the summary of the game for game events and the message for chat events
+type GameEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is game.created, game.updated or chat.message
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Game          *GameSummary           `protobuf:"bytes,4,opt,name=game,proto3" json:"game,omitempty"`
+	Message       *ChatMessage           `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameEvent) Reset() {
+	*x = GameEvent{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameEvent) ProtoMessage() {}
+
+func (x *GameEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameEvent.ProtoReflect.Descriptor instead.
+func (*GameEvent) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GameEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GameEvent) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GameEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GameEvent) GetGame() *GameSummary {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type Scoreboard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*Player              `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scoreboard) Reset() {
+	*x = Scoreboard{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scoreboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scoreboard) ProtoMessage() {}
+
+func (x *Scoreboard) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scoreboard.ProtoReflect.Descriptor instead.
+func (*Scoreboard) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Scoreboard) GetScores() []*Player {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Player *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	// session authenticates the player, it is also valid as session cookie of the REST API
+	Session       string `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LoginResponse) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+type GetPlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRequest) Reset() {
+	*x = GetPlayerRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerRequest) ProtoMessage() {}
+
+func (x *GetPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPlayerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetScoreboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScoreboardRequest) Reset() {
+	*x = GetScoreboardRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScoreboardRequest) ProtoMessage() {}
+
+func (x *GetScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*GetScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{14}
+}
+
+type ListGamesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page is counted from 0
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// count defaults to 10
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGamesRequest) Reset() {
+	*x = ListGamesRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGamesRequest) ProtoMessage() {}
+
+func (x *ListGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGamesRequest.ProtoReflect.Descriptor instead.
+func (*ListGamesRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListGamesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListGamesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListGamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*GameSummary         `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGamesResponse) Reset() {
+	*x = ListGamesResponse{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGamesResponse) ProtoMessage() {}
+
+func (x *ListGamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGamesResponse.ProtoReflect.Descriptor instead.
+func (*ListGamesResponse) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListGamesResponse) GetGames() []*GameSummary {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+type GetGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameRequest) Reset() {
+	*x = GetGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameRequest) ProtoMessage() {}
+
+func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
+func (*GetGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type CreateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Private       bool                   `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateGameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGameRequest) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+type JoinGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,2,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameRequest) Reset() {
+	*x = JoinGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameRequest) ProtoMessage() {}
+
+func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
+func (*JoinGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *JoinGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type CancelGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelGameRequest) Reset() {
+	*x = CancelGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGameRequest) ProtoMessage() {}
+
+func (x *CancelGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGameRequest.ProtoReflect.Descriptor instead.
+func (*CancelGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type CancelGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelGameResponse) Reset() {
+	*x = CancelGameResponse{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGameResponse) ProtoMessage() {}
+
+func (x *CancelGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGameResponse.ProtoReflect.Descriptor instead.
+func (*CancelGameResponse) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{21}
+}
+
+type PlaceShipRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	GameId string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Type   ShipType               `protobuf:"varint,2,opt,name=type,proto3,enum=battleship.v1.ShipType" json:"type,omitempty"`
+	// position is the top left end of the ship
+	Position      *Position   `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Orientation   Orientation `protobuf:"varint,4,opt,name=orientation,proto3,enum=battleship.v1.Orientation" json:"orientation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceShipRequest) Reset() {
+	*x = PlaceShipRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceShipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceShipRequest) ProtoMessage() {}
+
+func (x *PlaceShipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceShipRequest.ProtoReflect.Descriptor instead.
+func (*PlaceShipRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlaceShipRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *PlaceShipRequest) GetType() ShipType {
+	if x != nil {
+		return x.Type
+	}
+	return ShipType_SHIP_TYPE_UNSPECIFIED
+}
+
+func (x *PlaceShipRequest) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *PlaceShipRequest) GetOrientation() Orientation {
+	if x != nil {
+		return x.Orientation
+	}
+	return Orientation_ORIENTATION_UNSPECIFIED
+}
+
+type RemoveShipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Position      *Position              `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveShipRequest) Reset() {
+	*x = RemoveShipRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveShipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveShipRequest) ProtoMessage() {}
+
+func (x *RemoveShipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveShipRequest.ProtoReflect.Descriptor instead.
+func (*RemoveShipRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RemoveShipRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *RemoveShipRequest) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type StartGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartGameRequest) Reset() {
+	*x = StartGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameRequest) ProtoMessage() {}
+
+func (x *StartGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameRequest.ProtoReflect.Descriptor instead.
+func (*StartGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StartGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type FireRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Target        *Position              `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FireRequest) Reset() {
+	*x = FireRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FireRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FireRequest) ProtoMessage() {}
+
+func (x *FireRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FireRequest.ProtoReflect.Descriptor instead.
+func (*FireRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FireRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *FireRequest) GetTarget() *Position {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type WatchGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchGameRequest) Reset() {
+	*x = WatchGameRequest{}
+	mi := &file_battleship_v1_battleship_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchGameRequest) ProtoMessage() {}
+
+func (x *WatchGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_battleship_v1_battleship_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchGameRequest.ProtoReflect.Descriptor instead.
+func (*WatchGameRequest) Descriptor() ([]byte, []int) {
+	return file_battleship_v1_battleship_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+var File_battleship_v1_battleship_proto protoreflect.FileDescriptor
+
+const file_battleship_v1_battleship_proto_rawDesc = "" +
+	"\n" +
+	"\x1ebattleship/v1/battleship.proto\x12\rbattleship.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"B\n" +
+	"\x06Player\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"F\n" +
+	"\bPosition\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\x1e\n" +
+	"\n" +
+	"coordinate\x18\x03 \x01(\tR\n" +
+	"coordinate\"L\n" +
+	"\x04Move\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x05R\x01y\x12\x10\n" +
+	"\x03hit\x18\x04 \x01(\bR\x03hit\"\xb9\x01\n" +
+	"\x04Ship\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.battleship.v1.ShipTypeR\x04type\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x05R\x01y\x12<\n" +
+	"\vorientation\x18\x04 \x01(\x0e2\x1a.battleship.v1.OrientationR\vorientation\x12\x16\n" +
+	"\x06length\x18\x05 \x01(\x05R\x06length\x12\x12\n" +
+	"\x04hits\x18\x06 \x03(\bR\x04hits\"4\n" +
+	"\bBoardMap\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04rows\x18\x02 \x03(\tR\x04rows\"\xb7\x01\n" +
+	"\x05Board\x12%\n" +
+	"\x0epins_available\x18\x01 \x01(\x05R\rpinsAvailable\x12-\n" +
+	"\x05ships\x18\x02 \x01(\v2\x17.battleship.v1.BoardMapR\x05ships\x12-\n" +
+	"\x05shots\x18\x03 \x01(\v2\x17.battleship.v1.BoardMapR\x05shots\x12)\n" +
+	"\x05fleet\x18\x04 \x03(\v2\x13.battleship.v1.ShipR\x05fleet\"\x85\x04\n" +
+	"\x04Game\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12*\n" +
+	"\x05board\x18\x04 \x01(\v2\x14.battleship.v1.BoardR\x05board\x12-\n" +
+	"\ahistory\x18\x05 \x03(\v2\x13.battleship.v1.MoveR\ahistory\x121\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x19.battleship.v1.GameStatusR\x06status\x12\x18\n" +
+	"\aprivate\x18\a \x01(\bR\aprivate\x12\x1f\n" +
+	"\vinvite_code\x18\b \x01(\tR\n" +
+	"inviteCode\x12/\n" +
+	"\aplayer1\x18\t \x01(\v2\x15.battleship.v1.PlayerR\aplayer1\x12/\n" +
+	"\aplayer2\x18\n" +
+	" \x01(\v2\x15.battleship.v1.PlayerR\aplayer2\x12$\n" +
+	"\x0eplayer_to_move\x18\v \x01(\tR\fplayerToMove\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd2\x02\n" +
+	"\vGameSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.battleship.v1.GameStatusR\x06status\x12/\n" +
+	"\aplayer1\x18\x04 \x01(\v2\x15.battleship.v1.PlayerR\aplayer1\x12/\n" +
+	"\aplayer2\x18\x05 \x01(\v2\x15.battleship.v1.PlayerR\aplayer2\x12\x14\n" +
+	"\x05moves\x18\x06 \x01(\x05R\x05moves\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9d\x01\n" +
+	"\vChatMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12\x16\n" +
+	"\x06player\x18\x03 \x01(\tR\x06player\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xce\x01\n" +
+	"\tGameEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12.\n" +
+	"\x04game\x18\x04 \x01(\v2\x1a.battleship.v1.GameSummaryR\x04game\x124\n" +
+	"\amessage\x18\x05 \x01(\v2\x1a.battleship.v1.ChatMessageR\amessage\";\n" +
+	"\n" +
+	"Scoreboard\x12-\n" +
+	"\x06scores\x18\x01 \x03(\v2\x15.battleship.v1.PlayerR\x06scores\"*\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"X\n" +
+	"\rLoginResponse\x12-\n" +
+	"\x06player\x18\x01 \x01(\v2\x15.battleship.v1.PlayerR\x06player\x12\x18\n" +
+	"\asession\x18\x02 \x01(\tR\asession\"&\n" +
+	"\x10GetPlayerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x16\n" +
+	"\x14GetScoreboardRequest\"<\n" +
+	"\x10ListGamesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"E\n" +
+	"\x11ListGamesResponse\x120\n" +
+	"\x05games\x18\x01 \x03(\v2\x1a.battleship.v1.GameSummaryR\x05games\")\n" +
+	"\x0eGetGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"A\n" +
+	"\x11CreateGameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aprivate\x18\x02 \x01(\bR\aprivate\"K\n" +
+	"\x0fJoinGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1f\n" +
+	"\vinvite_code\x18\x02 \x01(\tR\n" +
+	"inviteCode\",\n" +
+	"\x11CancelGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"\x14\n" +
+	"\x12CancelGameResponse\"\xcb\x01\n" +
+	"\x10PlaceShipRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.battleship.v1.ShipTypeR\x04type\x123\n" +
+	"\bposition\x18\x03 \x01(\v2\x17.battleship.v1.PositionR\bposition\x12<\n" +
+	"\vorientation\x18\x04 \x01(\x0e2\x1a.battleship.v1.OrientationR\vorientation\"a\n" +
+	"\x11RemoveShipRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x123\n" +
+	"\bposition\x18\x02 \x01(\v2\x17.battleship.v1.PositionR\bposition\"+\n" +
+	"\x10StartGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"W\n" +
+	"\vFireRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12/\n" +
+	"\x06target\x18\x02 \x01(\v2\x17.battleship.v1.PositionR\x06target\"+\n" +
+	"\x10WatchGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId*\x84\x01\n" +
+	"\n" +
+	"GameStatus\x12\x1b\n" +
+	"\x17GAME_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11GAME_STATUS_SETUP\x10\x01\x12\x17\n" +
+	"\x13GAME_STATUS_PLAYING\x10\x02\x12\x13\n" +
+	"\x0fGAME_STATUS_WON\x10\x03\x12\x14\n" +
+	"\x10GAME_STATUS_LOST\x10\x04*\x88\x01\n" +
+	"\bShipType\x12\x19\n" +
+	"\x15SHIP_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SHIP_TYPE_BATTLESHIP\x10\x01\x12\x15\n" +
+	"\x11SHIP_TYPE_CRUISER\x10\x02\x12\x17\n" +
+	"\x13SHIP_TYPE_DESTROYER\x10\x03\x12\x17\n" +
+	"\x13SHIP_TYPE_SUBMARINE\x10\x04*`\n" +
+	"\vOrientation\x12\x1b\n" +
+	"\x17ORIENTATION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ORIENTATION_HORIZONTAL\x10\x01\x12\x18\n" +
+	"\x14ORIENTATION_VERTICAL\x10\x022\x9c\a\n" +
+	"\n" +
+	"Battleship\x12B\n" +
+	"\x05Login\x12\x1b.battleship.v1.LoginRequest\x1a\x1c.battleship.v1.LoginResponse\x12C\n" +
+	"\tGetPlayer\x12\x1f.battleship.v1.GetPlayerRequest\x1a\x15.battleship.v1.Player\x12O\n" +
+	"\rGetScoreboard\x12#.battleship.v1.GetScoreboardRequest\x1a\x19.battleship.v1.Scoreboard\x12N\n" +
+	"\tListGames\x12\x1f.battleship.v1.ListGamesRequest\x1a .battleship.v1.ListGamesResponse\x12=\n" +
+	"\aGetGame\x12\x1d.battleship.v1.GetGameRequest\x1a\x13.battleship.v1.Game\x12C\n" +
+	"\n" +
+	"CreateGame\x12 .battleship.v1.CreateGameRequest\x1a\x13.battleship.v1.Game\x12?\n" +
+	"\bJoinGame\x12\x1e.battleship.v1.JoinGameRequest\x1a\x13.battleship.v1.Game\x12Q\n" +
+	"\n" +
+	"CancelGame\x12 .battleship.v1.CancelGameRequest\x1a!.battleship.v1.CancelGameResponse\x12A\n" +
+	"\tPlaceShip\x12\x1f.battleship.v1.PlaceShipRequest\x1a\x13.battleship.v1.Game\x12C\n" +
+	"\n" +
+	"RemoveShip\x12 .battleship.v1.RemoveShipRequest\x1a\x13.battleship.v1.Game\x12A\n" +
+	"\tStartGame\x12\x1f.battleship.v1.StartGameRequest\x1a\x13.battleship.v1.Game\x127\n" +
+	"\x04Fire\x12\x1a.battleship.v1.FireRequest\x1a\x13.battleship.v1.Game\x12H\n" +
+	"\tWatchGame\x12\x1f.battleship.v1.WatchGameRequest\x1a\x18.battleship.v1.GameEvent0\x01B6Z4github.com/Jagreen1970/battleship/internal/rpc/pb;pbb\x06proto3"
+
+var (
+	file_battleship_v1_battleship_proto_rawDescOnce sync.Once
+	file_battleship_v1_battleship_proto_rawDescData []byte
+)
+
+func file_battleship_v1_battleship_proto_rawDescGZIP() []byte {
+	file_battleship_v1_battleship_proto_rawDescOnce.Do(func() {
+		file_battleship_v1_battleship_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_battleship_v1_battleship_proto_rawDesc), len(file_battleship_v1_battleship_proto_rawDesc)))
+	})
+	return file_battleship_v1_battleship_proto_rawDescData
+}
+
+var file_battleship_v1_battleship_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_battleship_v1_battleship_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_battleship_v1_battleship_proto_goTypes = []any{
+	(GameStatus)(0),               // 0: battleship.v1.GameStatus
+	(ShipType)(0),                 // 1: battleship.v1.ShipType
+	(Orientation)(0),              // 2: battleship.v1.Orientation
+	(*Player)(nil),                // 3: battleship.v1.Player
+	(*Position)(nil),              // 4: battleship.v1.Position
+	(*Move)(nil),                  // 5: battleship.v1.Move
+	(*Ship)(nil),                  // 6: battleship.v1.Ship
+	(*BoardMap)(nil),              // 7: battleship.v1.BoardMap
+	(*Board)(nil),                 // 8: battleship.v1.Board
+	(*Game)(nil),                  // 9: battleship.v1.Game
+	(*GameSummary)(nil),           // 10: battleship.v1.GameSummary
+	(*ChatMessage)(nil),           // 11: battleship.v1.ChatMessage
+	(*GameEvent)(nil),             // 12: battleship.v1.GameEvent
+	(*Scoreboard)(nil),            // 13: battleship.v1.Scoreboard
+	(*LoginRequest)(nil),          // 14: battleship.v1.LoginRequest
+	(*LoginResponse)(nil),         // 15: battleship.v1.LoginResponse
+	(*GetPlayerRequest)(nil),      // 16: battleship.v1.GetPlayerRequest
+	(*GetScoreboardRequest)(nil),  // 17: battleship.v1.GetScoreboardRequest
+	(*ListGamesRequest)(nil),      // 18: battleship.v1.ListGamesRequest
+	(*ListGamesResponse)(nil),     // 19: battleship.v1.ListGamesResponse
+	(*GetGameRequest)(nil),        // 20: battleship.v1.GetGameRequest
+	(*CreateGameRequest)(nil),     // 21: battleship.v1.CreateGameRequest
+	(*JoinGameRequest)(nil),       // 22: battleship.v1.JoinGameRequest
+	(*CancelGameRequest)(nil),     // 23: battleship.v1.CancelGameRequest
+	(*CancelGameResponse)(nil),    // 24: battleship.v1.CancelGameResponse
+	(*PlaceShipRequest)(nil),      // 25: battleship.v1.PlaceShipRequest
+	(*RemoveShipRequest)(nil),     // 26: battleship.v1.RemoveShipRequest
+	(*StartGameRequest)(nil),      // 27: battleship.v1.StartGameRequest
+	(*FireRequest)(nil),           // 28: battleship.v1.FireRequest
+	(*WatchGameRequest)(nil),      // 29: battleship.v1.WatchGameRequest
+	(*timestamppb.Timestamp)(nil), // 30: google.protobuf.Timestamp
+}
+var file_battleship_v1_battleship_proto_depIdxs = []int32{
+	1,  // 0: battleship.v1.Ship.type:type_name -> battleship.v1.ShipType
+	2,  // 1: battleship.v1.Ship.orientation:type_name -> battleship.v1.Orientation
+	7,  // 2: battleship.v1.Board.ships:type_name -> battleship.v1.BoardMap
+	7,  // 3: battleship.v1.Board.shots:type_name -> battleship.v1.BoardMap
+	6,  // 4: battleship.v1.Board.fleet:type_name -> battleship.v1.Ship
+	8,  // 5: battleship.v1.Game.board:type_name -> battleship.v1.Board
+	5,  // 6: battleship.v1.Game.history:type_name -> battleship.v1.Move
+	0,  // 7: battleship.v1.Game.status:type_name -> battleship.v1.GameStatus
+	3,  // 8: battleship.v1.Game.player1:type_name -> battleship.v1.Player
+	3,  // 9: battleship.v1.Game.player2:type_name -> battleship.v1.Player
+	30, // 10: battleship.v1.Game.created_at:type_name -> google.protobuf.Timestamp
+	30, // 11: battleship.v1.Game.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: battleship.v1.GameSummary.status:type_name -> battleship.v1.GameStatus
+	3,  // 13: battleship.v1.GameSummary.player1:type_name -> battleship.v1.Player
+	3,  // 14: battleship.v1.GameSummary.player2:type_name -> battleship.v1.Player
+	30, // 15: battleship.v1.GameSummary.created_at:type_name -> google.protobuf.Timestamp
+	30, // 16: battleship.v1.GameSummary.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 17: battleship.v1.ChatMessage.created_at:type_name -> google.protobuf.Timestamp
+	30, // 18: battleship.v1.GameEvent.time:type_name -> google.protobuf.Timestamp
+	10, // 19: battleship.v1.GameEvent.game:type_name -> battleship.v1.GameSummary
+	11, // 20: battleship.v1.GameEvent.message:type_name -> battleship.v1.ChatMessage
+	3,  // 21: battleship.v1.Scoreboard.scores:type_name -> battleship.v1.Player
+	3,  // 22: battleship.v1.LoginResponse.player:type_name -> battleship.v1.Player
+	10, // 23: battleship.v1.ListGamesResponse.games:type_name -> battleship.v1.GameSummary
+	1,  // 24: battleship.v1.PlaceShipRequest.type:type_name -> battleship.v1.ShipType
+	4,  // 25: battleship.v1.PlaceShipRequest.position:type_name -> battleship.v1.Position
+	2,  // 26: battleship.v1.PlaceShipRequest.orientation:type_name -> battleship.v1.Orientation
+	4,  // 27: battleship.v1.RemoveShipRequest.position:type_name -> battleship.v1.Position
+	4,  // 28: battleship.v1.FireRequest.target:type_name -> battleship.v1.Position
+	14, // 29: battleship.v1.Battleship.Login:input_type -> battleship.v1.LoginRequest
+	16, // 30: battleship.v1.Battleship.GetPlayer:input_type -> battleship.v1.GetPlayerRequest
+	17, // 31: battleship.v1.Battleship.GetScoreboard:input_type -> battleship.v1.GetScoreboardRequest
+	18, // 32: battleship.v1.Battleship.ListGames:input_type -> battleship.v1.ListGamesRequest
+	20, // 33: battleship.v1.Battleship.GetGame:input_type -> battleship.v1.GetGameRequest
+	21, // 34: battleship.v1.Battleship.CreateGame:input_type -> battleship.v1.CreateGameRequest
+	22, // 35: battleship.v1.Battleship.JoinGame:input_type -> battleship.v1.JoinGameRequest
+	23, // 36: battleship.v1.Battleship.CancelGame:input_type -> battleship.v1.CancelGameRequest
+	25, // 37: battleship.v1.Battleship.PlaceShip:input_type -> battleship.v1.PlaceShipRequest
+	26, // 38: battleship.v1.Battleship.RemoveShip:input_type -> battleship.v1.RemoveShipRequest
+	27, // 39: battleship.v1.Battleship.StartGame:input_type -> battleship.v1.StartGameRequest
+	28, // 40: battleship.v1.Battleship.Fire:input_type -> battleship.v1.FireRequest
+	29, // 41: battleship.v1.Battleship.WatchGame:input_type -> battleship.v1.WatchGameRequest
+	15, // 42: battleship.v1.Battleship.Login:output_type -> battleship.v1.LoginResponse
+	3,  // 43: battleship.v1.Battleship.GetPlayer:output_type -> battleship.v1.Player
+	13, // 44: battleship.v1.Battleship.GetScoreboard:output_type -> battleship.v1.Scoreboard
+	19, // 45: battleship.v1.Battleship.ListGames:output_type -> battleship.v1.ListGamesResponse
+	9,  // 46: battleship.v1.Battleship.GetGame:output_type -> battleship.v1.Game
+	9,  // 47: battleship.v1.Battleship.CreateGame:output_type -> battleship.v1.Game
+	9,  // 48: battleship.v1.Battleship.JoinGame:output_type -> battleship.v1.Game
+	24, // 49: battleship.v1.Battleship.CancelGame:output_type -> battleship.v1.CancelGameResponse
+	9,  // 50: battleship.v1.Battleship.PlaceShip:output_type -> battleship.v1.Game
+	9,  // 51: battleship.v1.Battleship.RemoveShip:output_type -> battleship.v1.Game
+	9,  // 52: battleship.v1.Battleship.StartGame:output_type -> battleship.v1.Game
+	9,  // 53: battleship.v1.Battleship.Fire:output_type -> battleship.v1.Game
+	12, // 54: battleship.v1.Battleship.WatchGame:output_type -> battleship.v1.GameEvent
+	42, // [42:55] is the sub-list for method output_type
+	29, // [29:42] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_battleship_v1_battleship_proto_init() }
+func file_battleship_v1_battleship_proto_init() {
+	if File_battleship_v1_battleship_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_battleship_v1_battleship_proto_rawDesc), len(file_battleship_v1_battleship_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_battleship_v1_battleship_proto_goTypes,
+		DependencyIndexes: file_battleship_v1_battleship_proto_depIdxs,
+		EnumInfos:         file_battleship_v1_battleship_proto_enumTypes,
+		MessageInfos:      file_battleship_v1_battleship_proto_msgTypes,
+	}.Build()
+	File_battleship_v1_battleship_proto = out.File
+	file_battleship_v1_battleship_proto_goTypes = nil
+	file_battleship_v1_battleship_proto_depIdxs = nil
+}
@@ -0,0 +1,599 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: battleship/v1/battleship.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Battleship_Login_FullMethodName         = "/battleship.v1.Battleship/Login"
+	Battleship_GetPlayer_FullMethodName     = "/battleship.v1.Battleship/GetPlayer"
+	Battleship_GetScoreboard_FullMethodName = "/battleship.v1.Battleship/GetScoreboard"
+	Battleship_ListGames_FullMethodName     = "/battleship.v1.Battleship/ListGames"
+	Battleship_GetGame_FullMethodName       = "/battleship.v1.Battleship/GetGame"
+	Battleship_CreateGame_FullMethodName    = "/battleship.v1.Battleship/CreateGame"
+	Battleship_JoinGame_FullMethodName      = "/battleship.v1.Battleship/JoinGame"
+	Battleship_CancelGame_FullMethodName    = "/battleship.v1.Battleship/CancelGame"
+	Battleship_PlaceShip_FullMethodName     = "/battleship.v1.Battleship/PlaceShip"
+	Battleship_RemoveShip_FullMethodName    = "/battleship.v1.Battleship/RemoveShip"
+	Battleship_StartGame_FullMethodName     = "/battleship.v1.Battleship/StartGame"
+	Battleship_Fire_FullMethodName          = "/battleship.v1.Battleship/Fire"
+	Battleship_WatchGame_FullMethodName     = "/battleship.v1.Battleship/WatchGame"
+)
+
+// BattleshipClient is the client API for Battleship service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Battleship is the gRPC API of the game. It is served next to the REST API and shares its sessions: the
+// session of Login is sent as "authorization: Bearer <session>" metadata, and the session cookie of the REST
+// API is accepted as "cookie" metadata as well.
+type BattleshipClient interface {
+	// Login starts a session for the player, who is created on the first login
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*Player, error)
+	GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error)
+	ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error)
+	// GetGame returns the game from the point of view of the logged in player, or of a viewer without session
+	GetGame(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*Game, error)
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*Game, error)
+	// JoinGame joins a game by its ID, or a private game by its invite code
+	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*Game, error)
+	// CancelGame deletes a game of the logged in player that nobody has joined yet
+	CancelGame(ctx context.Context, in *CancelGameRequest, opts ...grpc.CallOption) (*CancelGameResponse, error)
+	PlaceShip(ctx context.Context, in *PlaceShipRequest, opts ...grpc.CallOption) (*Game, error)
+	RemoveShip(ctx context.Context, in *RemoveShipRequest, opts ...grpc.CallOption) (*Game, error)
+	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*Game, error)
+	Fire(ctx context.Context, in *FireRequest, opts ...grpc.CallOption) (*Game, error)
+	// WatchGame streams the events of a game until the client cancels, private games only to their players
+	WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error)
+}
+
+type battleshipClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBattleshipClient(cc grpc.ClientConnInterface) BattleshipClient {
+	return &battleshipClient{cc}
+}
+
+func (c *battleshipClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, Battleship_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, Battleship_GetPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scoreboard)
+	err := c.cc.Invoke(ctx, Battleship_GetScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGamesResponse)
+	err := c.cc.Invoke(ctx, Battleship_ListGames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) GetGame(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_GetGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_CreateGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_JoinGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) CancelGame(ctx context.Context, in *CancelGameRequest, opts ...grpc.CallOption) (*CancelGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelGameResponse)
+	err := c.cc.Invoke(ctx, Battleship_CancelGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) PlaceShip(ctx context.Context, in *PlaceShipRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_PlaceShip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) RemoveShip(ctx context.Context, in *RemoveShipRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_RemoveShip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_StartGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) Fire(ctx context.Context, in *FireRequest, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, Battleship_Fire_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleshipClient) WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Battleship_ServiceDesc.Streams[0], Battleship_WatchGame_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchGameRequest, GameEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Battleship_WatchGameClient = grpc.ServerStreamingClient[GameEvent]
+
+// BattleshipServer is the server API for Battleship service.
+// All implementations must embed UnimplementedBattleshipServer
+// for forward compatibility.
+//
+// Battleship is the gRPC API of the game. It is served next to the REST API and shares its sessions: the
+// session of Login is sent as "authorization: Bearer <session>" metadata, and the session cookie of the REST
+// API is accepted as "cookie" metadata as well.
+type BattleshipServer interface {
+	// Login starts a session for the player, who is created on the first login
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	GetPlayer(context.Context, *GetPlayerRequest) (*Player, error)
+	GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error)
+	ListGames(context.Context, *ListGamesRequest) (*ListGamesResponse, error)
+	// GetGame returns the game from the point of view of the logged in player, or of a viewer without session
+	GetGame(context.Context, *GetGameRequest) (*Game, error)
+	CreateGame(context.Context, *CreateGameRequest) (*Game, error)
+	// JoinGame joins a game by its ID, or a private game by its invite code
+	JoinGame(context.Context, *JoinGameRequest) (*Game, error)
+	// CancelGame deletes a game of the logged in player that nobody has joined yet
+	CancelGame(context.Context, *CancelGameRequest) (*CancelGameResponse, error)
+	PlaceShip(context.Context, *PlaceShipRequest) (*Game, error)
+	RemoveShip(context.Context, *RemoveShipRequest) (*Game, error)
+	StartGame(context.Context, *StartGameRequest) (*Game, error)
+	Fire(context.Context, *FireRequest) (*Game, error)
+	// WatchGame streams the events of a game until the client cancels, private games only to their players
+	WatchGame(*WatchGameRequest, grpc.ServerStreamingServer[GameEvent]) error
+	mustEmbedUnimplementedBattleshipServer()
+}
+
+// UnimplementedBattleshipServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBattleshipServer struct{}
+
+func (UnimplementedBattleshipServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedBattleshipServer) GetPlayer(context.Context, *GetPlayerRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+}
+func (UnimplementedBattleshipServer) GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreboard not implemented")
+}
+func (UnimplementedBattleshipServer) ListGames(context.Context, *ListGamesRequest) (*ListGamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGames not implemented")
+}
+func (UnimplementedBattleshipServer) GetGame(context.Context, *GetGameRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGame not implemented")
+}
+func (UnimplementedBattleshipServer) CreateGame(context.Context, *CreateGameRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGame not implemented")
+}
+func (UnimplementedBattleshipServer) JoinGame(context.Context, *JoinGameRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinGame not implemented")
+}
+func (UnimplementedBattleshipServer) CancelGame(context.Context, *CancelGameRequest) (*CancelGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelGame not implemented")
+}
+func (UnimplementedBattleshipServer) PlaceShip(context.Context, *PlaceShipRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceShip not implemented")
+}
+func (UnimplementedBattleshipServer) RemoveShip(context.Context, *RemoveShipRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveShip not implemented")
+}
+func (UnimplementedBattleshipServer) StartGame(context.Context, *StartGameRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedBattleshipServer) Fire(context.Context, *FireRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fire not implemented")
+}
+func (UnimplementedBattleshipServer) WatchGame(*WatchGameRequest, grpc.ServerStreamingServer[GameEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGame not implemented")
+}
+func (UnimplementedBattleshipServer) mustEmbedUnimplementedBattleshipServer() {}
+func (UnimplementedBattleshipServer) testEmbeddedByValue()                    {}
+
+// UnsafeBattleshipServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BattleshipServer will
+// result in compilation errors.
+type UnsafeBattleshipServer interface {
+	mustEmbedUnimplementedBattleshipServer()
+}
+
+func RegisterBattleshipServer(s grpc.ServiceRegistrar, srv BattleshipServer) {
+	// If the following call pancis, it indicates UnimplementedBattleshipServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Battleship_ServiceDesc, srv)
+}
+
+func _Battleship_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).GetPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_GetPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).GetPlayer(ctx, req.(*GetPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_GetScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).GetScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_GetScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).GetScoreboard(ctx, req.(*GetScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_ListGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).ListGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_ListGames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).ListGames(ctx, req.(*ListGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_GetGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).GetGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_GetGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).GetGame(ctx, req.(*GetGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_CreateGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).JoinGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_JoinGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).JoinGame(ctx, req.(*JoinGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_CancelGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).CancelGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_CancelGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).CancelGame(ctx, req.(*CancelGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_PlaceShip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceShipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).PlaceShip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_PlaceShip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).PlaceShip(ctx, req.(*PlaceShipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_RemoveShip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveShipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).RemoveShip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_RemoveShip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).RemoveShip(ctx, req.(*RemoveShipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_StartGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).StartGame(ctx, req.(*StartGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_Fire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleshipServer).Fire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Battleship_Fire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleshipServer).Fire(ctx, req.(*FireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Battleship_WatchGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BattleshipServer).WatchGame(m, &grpc.GenericServerStream[WatchGameRequest, GameEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Battleship_WatchGameServer = grpc.ServerStreamingServer[GameEvent]
+
+// Battleship_ServiceDesc is the grpc.ServiceDesc for Battleship service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Battleship_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "battleship.v1.Battleship",
+	HandlerType: (*BattleshipServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _Battleship_Login_Handler,
+		},
+		{
+			MethodName: "GetPlayer",
+			Handler:    _Battleship_GetPlayer_Handler,
+		},
+		{
+			MethodName: "GetScoreboard",
+			Handler:    _Battleship_GetScoreboard_Handler,
+		},
+		{
+			MethodName: "ListGames",
+			Handler:    _Battleship_ListGames_Handler,
+		},
+		{
+			MethodName: "GetGame",
+			Handler:    _Battleship_GetGame_Handler,
+		},
+		{
+			MethodName: "CreateGame",
+			Handler:    _Battleship_CreateGame_Handler,
+		},
+		{
+			MethodName: "JoinGame",
+			Handler:    _Battleship_JoinGame_Handler,
+		},
+		{
+			MethodName: "CancelGame",
+			Handler:    _Battleship_CancelGame_Handler,
+		},
+		{
+			MethodName: "PlaceShip",
+			Handler:    _Battleship_PlaceShip_Handler,
+		},
+		{
+			MethodName: "RemoveShip",
+			Handler:    _Battleship_RemoveShip_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _Battleship_StartGame_Handler,
+		},
+		{
+			MethodName: "Fire",
+			Handler:    _Battleship_Fire_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGame",
+			Handler:       _Battleship_WatchGame_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "battleship/v1/battleship.proto",
+}
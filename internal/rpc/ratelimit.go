@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+)
+
+// limiter limits the calls by client IP and by the player of the session, with the same limits and keys as
+// the REST API. A call over a limit fails with ResourceExhausted and a retry-after header with the seconds
+// to wait. If the store fails, the call is let through.
+type limiter struct {
+	store       ratelimit.Store
+	ipLimit     ratelimit.Limit
+	playerLimit ratelimit.Limit
+}
+
+func newLimiter(cfg app.RateLimitConfig, store ratelimit.Store) *limiter {
+	return &limiter{
+		store:       store,
+		ipLimit:     ratelimit.Limit{Rate: cfg.IPRate, Burst: cfg.IPBurst},
+		playerLimit: ratelimit.Limit{Rate: cfg.PlayerRate, Burst: cfg.PlayerBurst},
+	}
+}
+
+func (l *limiter) unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if wait, ok := l.check(ctx); !ok {
+		_ = grpc.SetHeader(ctx, retryAfter(wait))
+		return nil, exhausted(wait)
+	}
+	return handler(ctx, req)
+}
+
+func (l *limiter) stream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if wait, ok := l.check(ss.Context()); !ok {
+		_ = ss.SetHeader(retryAfter(wait))
+		return exhausted(wait)
+	}
+	return handler(srv, ss)
+}
+
+// check takes a token of the client IP and one of the player, if the call has a session. It returns false
+// and the time to wait when one of them has none left.
+func (l *limiter) check(ctx context.Context) (time.Duration, bool) {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip := p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		if wait, ok := l.take(ctx, "ip:"+ip, l.ipLimit); !ok {
+			return wait, false
+		}
+	}
+	if player := playerFromContext(ctx); player != "" {
+		if wait, ok := l.take(ctx, "player:"+player, l.playerLimit); !ok {
+			return wait, false
+		}
+	}
+	return 0, true
+}
+
+func (l *limiter) take(ctx context.Context, key string, limit ratelimit.Limit) (time.Duration, bool) {
+	if limit.Rate <= 0 {
+		return 0, true
+	}
+
+	ok, wait, err := l.store.Take(key, limit)
+	if err != nil {
+		app.LoggerFromContext(ctx).Warn("rate limit not checked", "key", key, "error", err)
+		return 0, true
+	}
+	return wait, ok
+}
+
+// retrySeconds rounds the time to wait up to whole seconds, like the Retry-After header of the REST API
+func retrySeconds(wait time.Duration) int {
+	return max(int(math.Ceil(wait.Seconds())), 1)
+}
+
+func retryAfter(wait time.Duration) metadata.MD {
+	return metadata.Pairs("retry-after", strconv.Itoa(retrySeconds(wait)))
+}
+
+func exhausted(wait time.Duration) error {
+	return status.Error(codes.ResourceExhausted,
+		fmt.Sprintf("rate limit exceeded, retry in %s", time.Duration(retrySeconds(wait))*time.Second))
+}
@@ -0,0 +1,304 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+	"github.com/Jagreen1970/battleship/internal/rpc/pb"
+	"github.com/Jagreen1970/battleship/internal/server"
+)
+
+var testFleet = []string{
+	"Battleship A1",
+	"Cruiser A3",
+	"Cruiser F3",
+	"Destroyer A5",
+	"Destroyer E5",
+	"Destroyer A7",
+	"Submarine E7",
+	"Submarine I7",
+	"Submarine A9",
+	"Submarine D9",
+}
+
+var testShipTypes = map[string]pb.ShipType{
+	"Battleship": pb.ShipType_SHIP_TYPE_BATTLESHIP,
+	"Cruiser":    pb.ShipType_SHIP_TYPE_CRUISER,
+	"Destroyer":  pb.ShipType_SHIP_TYPE_DESTROYER,
+	"Submarine":  pb.ShipType_SHIP_TYPE_SUBMARINE,
+}
+
+// newClient serves the gRPC API on an in-memory connection
+func newClient(t *testing.T) (pb.BattleshipClient, *server.SessionCodec) {
+	return newClientWithConfig(t, app.ServerConfig{Timeout: time.Second})
+}
+
+func newClientWithConfig(t *testing.T, cfg app.ServerConfig) (pb.BattleshipClient, *server.SessionCodec) {
+	sessions := server.NewSessionCodec([]byte("test"))
+	srv := New(cfg, game.NewApi(gametest.NewDatabase()), sessions, nil)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Shutdown)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return pb.NewBattleshipClient(conn), sessions
+}
+
+// login returns a context authenticated as the player
+func login(t *testing.T, client pb.BattleshipClient, player string) context.Context {
+	response, err := client.Login(context.Background(), &pb.LoginRequest{Username: player})
+	require.NoError(t, err)
+	assert.Equal(t, player, response.Player.Name)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+response.Session)
+}
+
+func placeFleet(t *testing.T, client pb.BattleshipClient, ctx context.Context, gameID string) *pb.Game {
+	var g *pb.Game
+	for _, ship := range testFleet {
+		fields := strings.Fields(ship)
+		var err error
+		g, err = client.PlaceShip(ctx, &pb.PlaceShipRequest{
+			GameId:      gameID,
+			Type:        testShipTypes[fields[0]],
+			Position:    &pb.Position{Coordinate: fields[1]},
+			Orientation: pb.Orientation_ORIENTATION_HORIZONTAL,
+		})
+		require.NoError(t, err, ship)
+	}
+	return g
+}
+
+func assertCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+	assert.Equal(t, code, status.Code(err), "%v", err)
+}
+
+func TestPlayAGame(t *testing.T) {
+	client, _ := newClient(t)
+	alice := login(t, client, "alice")
+	bob := login(t, client, "bob")
+
+	g, err := client.CreateGame(alice, &pb.CreateGameRequest{Name: "grpc"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", g.User)
+	assert.Equal(t, pb.GameStatus_GAME_STATUS_SETUP, g.Status)
+	require.NotNil(t, g.Board)
+	assert.Equal(t, int32(30), g.Board.PinsAvailable)
+	assert.Equal(t, strings.Repeat(" ", 10), g.Board.Ships.Rows[0])
+
+	watchCtx, cancel := context.WithCancel(alice)
+	defer cancel()
+	events, err := client.WatchGame(watchCtx, &pb.WatchGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+
+	// the header is sent once the server subscribed to the events of the game
+	_, err = events.Header()
+	require.NoError(t, err)
+	_, err = client.JoinGame(bob, &pb.JoinGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+	event, err := events.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, string(game.EventGameUpdated), event.Type)
+	assert.Equal(t, "bob", event.Game.Player2.Name)
+
+	placeFleet(t, client, bob, g.Id)
+	g = placeFleet(t, client, alice, g.Id)
+	assert.Zero(t, g.Board.PinsAvailable)
+	assert.Len(t, g.Board.Fleet, 10)
+	assert.Equal(t, "OOOOO     ", g.Board.Ships.Rows[0])
+
+	_, err = client.StartGame(alice, &pb.StartGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+
+	g, err = client.Fire(alice, &pb.FireRequest{GameId: g.Id, Target: &pb.Position{X: 0, Y: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.Move{{Player: "alice", X: 0, Y: 0, Hit: true}}, toMoves(g.History))
+	assert.Equal(t, "X         ", g.Board.Shots.Rows[0])
+	assert.Equal(t, "bob", g.PlayerToMove)
+
+	_, err = client.Fire(alice, &pb.FireRequest{GameId: g.Id, Target: &pb.Position{Coordinate: "B1"}})
+	assertCode(t, codes.PermissionDenied, err)
+
+	g, err = client.GetGame(context.Background(), &pb.GetGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "guest", g.User)
+	assert.Nil(t, g.Board, "viewers don't see the fleets")
+	assert.Equal(t, pb.GameStatus_GAME_STATUS_PLAYING, g.Status)
+
+	games, err := client.ListGames(context.Background(), &pb.ListGamesRequest{})
+	require.NoError(t, err)
+	require.Len(t, games.Games, 1)
+	assert.Equal(t, int32(1), games.Games[0].Moves)
+}
+
+// toMoves copies the moves without the protobuf internals, so they can be compared
+func toMoves(history []*pb.Move) []*pb.Move {
+	var moves []*pb.Move
+	for _, move := range history {
+		moves = append(moves, &pb.Move{Player: move.Player, X: move.X, Y: move.Y, Hit: move.Hit})
+	}
+	return moves
+}
+
+func TestAuthentication(t *testing.T) {
+	client, sessions := newClient(t)
+
+	_, err := client.CreateGame(context.Background(), &pb.CreateGameRequest{})
+	assertCode(t, codes.Unauthenticated, err)
+
+	invalid := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetGame(invalid, &pb.GetGameRequest{GameId: "game1"})
+	assertCode(t, codes.Unauthenticated, err)
+
+	_, err = client.Login(context.Background(), &pb.LoginRequest{})
+	assertCode(t, codes.InvalidArgument, err)
+
+	// the session cookie of the REST API is accepted as well
+	_ = login(t, client, "alice") // creates the player
+	session, err := sessions.Encode("alice")
+	require.NoError(t, err)
+	cookie := metadata.AppendToOutgoingContext(context.Background(), "cookie", "other=1; "+server.SessionName+"="+session)
+	g, err := client.CreateGame(cookie, &pb.CreateGameRequest{Private: true})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", g.User)
+	assert.True(t, g.Private)
+	assert.NotEmpty(t, g.InviteCode)
+}
+
+func TestWatchGame(t *testing.T) {
+	client, _ := newClient(t)
+	alice := login(t, client, "alice")
+	bob := login(t, client, "bob")
+
+	g, err := client.CreateGame(alice, &pb.CreateGameRequest{Private: true})
+	require.NoError(t, err)
+
+	// an accepted stream waits for events until the deadline, a rejected one ends with its error
+	watch := func(ctx context.Context, gameID string) error {
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		events, err := client.WatchGame(ctx, &pb.WatchGameRequest{GameId: gameID})
+		require.NoError(t, err)
+		_, err = events.Recv()
+		if status.Code(err) == codes.DeadlineExceeded {
+			return nil
+		}
+		return err
+	}
+
+	assertCode(t, codes.Unauthenticated, watch(context.Background(), g.Id))
+	assertCode(t, codes.InvalidArgument, watch(alice, ""))
+	assertCode(t, codes.NotFound, watch(bob, g.Id))
+	assert.NoError(t, watch(alice, g.Id))
+}
+
+func TestPrivateGameIsHidden(t *testing.T) {
+	client, _ := newClient(t)
+	alice := login(t, client, "alice")
+	bob := login(t, client, "bob")
+
+	g, err := client.CreateGame(alice, &pb.CreateGameRequest{Private: true})
+	require.NoError(t, err)
+
+	_, err = client.GetGame(bob, &pb.GetGameRequest{GameId: g.Id})
+	assertCode(t, codes.NotFound, err)
+	_, err = client.GetGame(context.Background(), &pb.GetGameRequest{GameId: g.Id})
+	assertCode(t, codes.NotFound, err)
+
+	g, err = client.GetGame(alice, &pb.GetGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+	assert.True(t, g.Private)
+}
+
+func TestErrors(t *testing.T) {
+	client, _ := newClient(t)
+	alice := login(t, client, "alice")
+
+	_, err := client.GetGame(alice, &pb.GetGameRequest{GameId: "no-such-game"})
+	assertCode(t, codes.NotFound, err)
+
+	_, err = client.GetPlayer(alice, &pb.GetPlayerRequest{Name: "bob"})
+	assertCode(t, codes.NotFound, err)
+
+	g, err := client.CreateGame(alice, &pb.CreateGameRequest{})
+	require.NoError(t, err)
+
+	_, err = client.PlaceShip(alice, &pb.PlaceShipRequest{GameId: g.Id, Position: &pb.Position{}})
+	assertCode(t, codes.InvalidArgument, err)
+
+	_, err = client.PlaceShip(alice, &pb.PlaceShipRequest{
+		GameId:      g.Id,
+		Type:        pb.ShipType_SHIP_TYPE_BATTLESHIP,
+		Position:    &pb.Position{Coordinate: "K1"},
+		Orientation: pb.Orientation_ORIENTATION_VERTICAL,
+	})
+	assertCode(t, codes.InvalidArgument, err)
+
+	_, err = client.StartGame(alice, &pb.StartGameRequest{GameId: g.Id})
+	assertCode(t, codes.FailedPrecondition, err)
+
+	events, err := client.WatchGame(alice, &pb.WatchGameRequest{GameId: "no-such-game"})
+	require.NoError(t, err)
+	_, err = events.Recv()
+	assertCode(t, codes.NotFound, err)
+}
+
+func TestCancelGame(t *testing.T) {
+	client, _ := newClient(t)
+	alice := login(t, client, "alice")
+	bob := login(t, client, "bob")
+
+	g, err := client.CreateGame(alice, &pb.CreateGameRequest{})
+	require.NoError(t, err)
+
+	_, err = client.CancelGame(bob, &pb.CancelGameRequest{GameId: g.Id})
+	assertCode(t, codes.PermissionDenied, err)
+
+	_, err = client.CancelGame(alice, &pb.CancelGameRequest{GameId: g.Id})
+	require.NoError(t, err)
+	_, err = client.GetGame(alice, &pb.GetGameRequest{GameId: g.Id})
+	assertCode(t, codes.NotFound, err)
+}
+
+func TestRateLimit(t *testing.T) {
+	client, _ := newClientWithConfig(t, app.ServerConfig{
+		Timeout:   time.Second,
+		RateLimit: app.RateLimitConfig{PlayerRate: 0.1, PlayerBurst: 2},
+	})
+	alice := login(t, client, "alice")
+
+	for range 2 {
+		_, err := client.ListGames(alice, &pb.ListGamesRequest{})
+		require.NoError(t, err)
+	}
+
+	var header metadata.MD
+	_, err := client.ListGames(alice, &pb.ListGamesRequest{}, grpc.Header(&header))
+	assertCode(t, codes.ResourceExhausted, err)
+	assert.Equal(t, []string{"10"}, header.Get("retry-after"))
+
+	// the limit is per player
+	_, err = client.ListGames(login(t, client, "bob"), &pb.ListGamesRequest{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+	"github.com/Jagreen1970/battleship/internal/rpc/pb"
+	"github.com/Jagreen1970/battleship/internal/server"
+)
+
+// Server serves the gRPC API on its own port next to the HTTP server
+type Server struct {
+	grpcServer *grpc.Server
+	logger     *slog.Logger
+	cfg        app.ServerConfig
+}
+
+// New returns the gRPC server of the API. The rate limits of the configuration keep their token buckets in
+// limits, in memory if it is nil; share the store with the HTTP router to limit both APIs together.
+func New(cfg app.ServerConfig, api GameAPI, sessions SessionCodec, limits ratelimit.Store) *Server {
+	if limits == nil {
+		limits = ratelimit.NewMemoryStore()
+	}
+	a := &authenticator{sessions: sessions}
+	l := newLimiter(cfg.RateLimit, limits)
+	grpcServer := grpc.NewServer(
+		// calls are traced like the HTTP requests, continuing the trace context sent by the client
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(a.unary, l.unary),
+		grpc.ChainStreamInterceptor(a.stream, l.stream),
+	)
+	pb.RegisterBattleshipServer(grpcServer, NewService(api, sessions))
+	// reflection lets tools like grpcurl call the API without the proto files
+	reflection.Register(grpcServer)
+
+	return &Server{
+		grpcServer: grpcServer,
+		logger:     slog.Default(),
+		cfg:        cfg,
+	}
+}
+
+// SetLogger sets the logger of the server, by default slog.Default() is used
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// Start listens on the gRPC port and serves until Shutdown is called
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.GRPCPort))
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves the gRPC API on the listener until Shutdown is called
+func (s *Server) Serve(listener net.Listener) error {
+	s.logger.Info("gRPC server listening", "addr", listener.Addr().String())
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown waits for running calls to finish. Streams still open after the server timeout are closed.
+func (s *Server) Shutdown() {
+	done := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(s.cfg.Timeout):
+		s.grpcServer.Stop()
+	}
+}
+
+type playerKey struct{}
+
+// playerFromContext returns the logged in player, or "" if the call has no session
+func playerFromContext(ctx context.Context) string {
+	player, _ := ctx.Value(playerKey{}).(string)
+	return player
+}
+
+func requirePlayer(ctx context.Context) (string, error) {
+	player := playerFromContext(ctx)
+	if player == "" {
+		return "", status.Error(codes.Unauthenticated, "invalid player - you must be logged in")
+	}
+	return player, nil
+}
+
+// authenticator puts the player of the session sent with a call into its context
+type authenticator struct {
+	sessions SessionCodec
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate decodes the session of a Login call or the session cookie of the REST API. Calls without
+// session are anonymous, calls with an invalid one are rejected.
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var session string
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			session = token
+		}
+	}
+	for _, value := range md.Get("cookie") {
+		cookies, err := http.ParseCookie(value)
+		if err != nil {
+			continue
+		}
+		for _, cookie := range cookies {
+			if cookie.Name == server.SessionName && session == "" {
+				session = cookie.Value
+			}
+		}
+	}
+	if session == "" {
+		return ctx, nil
+	}
+
+	player, err := a.sessions.Decode(session)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, playerKey{}, player), nil
+}
+
+// authenticatedStream is a server stream with the context of the authenticated call
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
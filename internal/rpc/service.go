@@ -0,0 +1,318 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/rpc/pb"
+)
+
+// DefaultGamesPerPage is the number of games ListGames returns without count
+const DefaultGamesPerPage = 10
+
+// GameAPI is the part of game.API served by the gRPC service
+type GameAPI interface {
+	ScoreBoard(playerName string) (*game.ScoreBoard, error)
+	GameSummaries(page int, count int) ([]game.Summary, error)
+	GetGame(id string) (*game.Game, error)
+	NewGame(player string, name string) (*game.Game, error)
+	NewPrivateGame(player string, name string) (*game.Game, error)
+	JoinGame(id string, playerName string) (*game.Game, error)
+	JoinGameByCode(code string, playerName string) (*game.Game, error)
+	CancelGame(id string, playerName string) error
+	UpdateGame(g *game.Game) (*game.Game, error)
+	GetPlayer(playerName string) (*game.Player, error)
+	NewPlayer(playerName string) (*game.Player, error)
+	Subscribe(gameID string) (<-chan game.Event, func())
+}
+
+// SessionCodec encodes and decodes the sessions shared with the REST API
+type SessionCodec interface {
+	Encode(playerName string) (string, error)
+	Decode(value string) (string, error)
+}
+
+// Service implements the Battleship gRPC service on top of game.API
+type Service struct {
+	pb.UnimplementedBattleshipServer
+
+	api      GameAPI
+	sessions SessionCodec
+}
+
+func NewService(api GameAPI, sessions SessionCodec) *Service {
+	return &Service{
+		api:      api,
+		sessions: sessions,
+	}
+}
+
+// games returns the API with its storage operations bound to the context of the call, so they are traced
+// as part of it
+func (s *Service) games(ctx context.Context) GameAPI {
+	if api, ok := s.api.(interface {
+		WithContext(ctx context.Context) *game.API
+	}); ok {
+		return api.WithContext(ctx)
+	}
+	return s.api
+}
+
+func (s *Service) Login(ctx context.Context, request *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if request.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is missing")
+	}
+
+	player, err := s.games(ctx).GetPlayer(request.Username)
+	if player == nil && errors.Is(err, game.ErrorNotFound) {
+		player, err = s.games(ctx).NewPlayer(request.Username)
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	session, err := s.sessions.Encode(player.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not create session: %v", err)
+	}
+	return &pb.LoginResponse{Player: toPlayer(player), Session: session}, nil
+}
+
+func (s *Service) GetPlayer(ctx context.Context, request *pb.GetPlayerRequest) (*pb.Player, error) {
+	player, err := s.games(ctx).GetPlayer(request.Name)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toPlayer(player), nil
+}
+
+func (s *Service) GetScoreboard(ctx context.Context, _ *pb.GetScoreboardRequest) (*pb.Scoreboard, error) {
+	scoreboard, err := s.games(ctx).ScoreBoard(playerFromContext(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	response := &pb.Scoreboard{}
+	for _, player := range scoreboard.Scores {
+		response.Scores = append(response.Scores, toPlayer(&player))
+	}
+	return response, nil
+}
+
+func (s *Service) ListGames(ctx context.Context, request *pb.ListGamesRequest) (*pb.ListGamesResponse, error) {
+	count := int(request.Count)
+	if count <= 0 {
+		count = DefaultGamesPerPage
+	}
+
+	summaries, err := s.games(ctx).GameSummaries(max(0, int(request.Page)), count)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	response := &pb.ListGamesResponse{}
+	for _, summary := range summaries {
+		response.Games = append(response.Games, toSummary(&summary))
+	}
+	return response, nil
+}
+
+func (s *Service) GetGame(ctx context.Context, request *pb.GetGameRequest) (*pb.Game, error) {
+	g, err := s.games(ctx).GetGame(request.GameId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	user := playerFromContext(ctx)
+	if err := g.CanWatch(user); err != nil {
+		return nil, mapError(err)
+	}
+	if user == "" {
+		user = "guest"
+	}
+	return toGame(user, g), nil
+}
+
+func (s *Service) CreateGame(ctx context.Context, request *pb.CreateGameRequest) (*pb.Game, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var g *game.Game
+	if request.Private {
+		g, err = s.games(ctx).NewPrivateGame(player, request.Name)
+	} else {
+		g, err = s.games(ctx).NewGame(player, request.Name)
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toGame(player, g), nil
+}
+
+func (s *Service) JoinGame(ctx context.Context, request *pb.JoinGameRequest) (*pb.Game, error) {
+	playerName, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.InviteCode != "" {
+		g, err := s.games(ctx).JoinGameByCode(request.InviteCode, playerName)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		return toGame(playerName, g), nil
+	}
+
+	g, err := s.games(ctx).JoinGame(request.GameId, playerName)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toGame(playerName, g), nil
+}
+
+func (s *Service) CancelGame(ctx context.Context, request *pb.CancelGameRequest) (*pb.CancelGameResponse, error) {
+	playerName, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.games(ctx).CancelGame(request.GameId, playerName); err != nil {
+		return nil, mapError(err)
+	}
+	return &pb.CancelGameResponse{}, nil
+}
+
+func (s *Service) PlaceShip(ctx context.Context, request *pb.PlaceShipRequest) (*pb.Game, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shipType, err := fromShipType(request.Type)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	orientation, err := fromOrientation(request.Orientation)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	x, y, err := fromPosition(request.Position)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return s.update(ctx, player, request.GameId, func(g *game.Game) error {
+		return g.PlaceShip(player, shipType, x, y, orientation)
+	})
+}
+
+func (s *Service) RemoveShip(ctx context.Context, request *pb.RemoveShipRequest) (*pb.Game, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, err := fromPosition(request.Position)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return s.update(ctx, player, request.GameId, func(g *game.Game) error {
+		return g.RemoveShip(player, x, y)
+	})
+}
+
+func (s *Service) StartGame(ctx context.Context, request *pb.StartGameRequest) (*pb.Game, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, player, request.GameId, func(g *game.Game) error {
+		return g.Start(player)
+	})
+}
+
+func (s *Service) Fire(ctx context.Context, request *pb.FireRequest) (*pb.Game, error) {
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, err := fromPosition(request.Target)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return s.update(ctx, player, request.GameId, func(g *game.Game) error {
+		return g.MakeMove(game.Move{Player: player, X: x, Y: y})
+	})
+}
+
+// update loads the game, applies the action of the player and stores the game
+func (s *Service) update(ctx context.Context, player string, gameID string, action func(g *game.Game) error) (*pb.Game, error) {
+	g, err := s.games(ctx).GetGame(gameID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	if err := action(g); err != nil {
+		return nil, mapError(err)
+	}
+
+	g, err = s.games(ctx).UpdateGame(g)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toGame(player, g), nil
+}
+
+func (s *Service) WatchGame(request *pb.WatchGameRequest, stream grpc.ServerStreamingServer[pb.GameEvent]) error {
+	ctx := stream.Context()
+	player, err := requirePlayer(ctx)
+	if err != nil {
+		return err
+	}
+	if request.GameId == "" {
+		return status.Error(codes.InvalidArgument, "game_id is required")
+	}
+
+	g, err := s.games(ctx).GetGame(request.GameId)
+	if err != nil {
+		return mapError(err)
+	}
+	if err := g.CanWatch(player); err != nil {
+		return mapError(err)
+	}
+
+	events, unsubscribe := s.games(ctx).Subscribe(request.GameId)
+	defer unsubscribe()
+
+	// the header tells the client that no event is missed from now on
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return fmt.Errorf("error sending header: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return fmt.Errorf("error sending event: %w", err)
+			}
+		}
+	}
+}
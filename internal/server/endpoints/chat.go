@@ -0,0 +1,101 @@
+package endpoints
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultChatMessagesPerPage = 50
+
+func (c *Controller) SendChatMessage(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player"})
+		return
+	}
+
+	var request struct {
+		Text string `json:"text"`
+	}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message, err := c.games(context).SendChatMessage(gameID, playerName, request.Text)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, message)
+}
+
+func (c *Controller) ChatMessages(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	if _, ok := c.watchedGame(context, gameID); !ok {
+		return
+	}
+
+	page, count := firstPageParams(context, DefaultChatMessagesPerPage)
+
+	messages, err := c.games(context).ChatMessages(gameID, page, count)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// GameEvents streams the events of a game as server-sent events: game updates as well as chat messages
+func (c *Controller) GameEvents(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	if _, ok := c.watchedGame(context, gameID); !ok {
+		return
+	}
+
+	logger := requestLogger(context).With("game", gameID)
+
+	// the stream stays open for as long as the client listens, longer than the write timeout of the server
+	if err := http.NewResponseController(context.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Warn("could not clear the write deadline of the event stream", "error", err)
+	}
+
+	events, unsubscribe := c.games(context).Subscribe(gameID)
+	defer unsubscribe()
+	logger.Debug("event stream opened")
+	defer logger.Debug("event stream closed")
+
+	context.Stream(func(w io.Writer) bool {
+		select {
+		case <-context.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			context.SSEvent(string(event.Type), event)
+			return true
+		}
+	})
+}
@@ -5,40 +5,80 @@ import (
 
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/graphql"
 )
 
 type Controller struct {
-	gameAPI GameAPI
+	gameAPI       GameAPI
+	tournamentAPI TournamentAPI
+	webhookAPI    WebhookAPI
+	graphQL       http.Handler
+	health        HealthChecker
 }
 
 const (
-	sessionKeyPlayerName = "playerName"
+	// SessionKeyPlayerName is the session value holding the name of the logged in player
+	SessionKeyPlayerName = "playerName"
+	// SessionMaxAge is the lifetime of a session in seconds
+	SessionMaxAge = 15 * 60
 )
 
-func NewController(api GameAPI) *Controller {
+func NewController(api GameAPI, tournamentAPI TournamentAPI, webhookAPI WebhookAPI) *Controller {
 	return &Controller{
-		gameAPI: api,
+		gameAPI:       api,
+		tournamentAPI: tournamentAPI,
+		webhookAPI:    webhookAPI,
+		graphQL:       graphql.NewHandler(api),
 	}
 }
 
 func (c *Controller) Register(engine *gin.Engine) {
 	engine.Use(static.Serve("/", static.LocalFile("./frontend/build", true)))
+	engine.GET("/healthz", c.Healthz)
+	engine.GET("/readyz", c.Readyz)
 
 	api := engine.Group("/api")
 	{
 		api.GET("/", func(context *gin.Context) {
 			context.JSON(http.StatusOK, gin.H{"message": "pong"})
 		})
+		api.GET("/openapi.yaml", c.OpenAPISpec)
+		api.GET("/docs", c.Docs)
+		api.GET("/docs/:file", c.DocsAsset)
 		api.POST("/login", c.Login)
 		api.GET("/logout", c.Logout)
 		api.GET("/scoreboard", c.Scoreboard)
 		api.GET("/games", c.Games)
 		api.POST("/games", c.CreateGame)
 		api.GET("/games/:id", c.GetGame)
+		api.DELETE("/games/:id", c.CancelGame)
+		api.GET("/games/:id/export", c.ExportGame)
 		api.PATCH("/games/:id", c.JoinGame)
+		api.POST("/games/:id/ships", c.PlaceShip)
 		api.PUT("/games/:id/pin/:pin", c.PlacePin)
 		api.DELETE("/games/:id/pin/:pin", c.RecoverPin)
 		api.GET("/games/:id/start", c.StartGame)
 		api.POST("/games/:id/target", c.Target)
+		api.GET("/games/:id/chat", c.ChatMessages)
+		api.POST("/games/:id/chat", c.SendChatMessage)
+		api.GET("/games/:id/events", c.GameEvents)
+		api.POST("/games/:id/rematch", c.RequestRematch)
+		api.POST("/games/:id/rematch/accept", c.AcceptRematch)
+		api.POST("/invites/:code", c.JoinGameByCode)
+		api.POST("/lobby", c.FindMatch)
+		api.GET("/lobby", c.MatchStatus)
+		api.DELETE("/lobby", c.CancelMatch)
+		api.GET("/tournaments", c.Tournaments)
+		api.POST("/tournaments", c.CreateTournament)
+		api.GET("/tournaments/:id", c.GetTournament)
+		api.POST("/tournaments/:id/players", c.JoinTournament)
+		api.POST("/tournaments/:id/start", c.StartTournament)
+		api.POST("/tournaments/:id/advance", c.AdvanceTournament)
+		api.GET("/webhooks", c.Webhooks)
+		api.POST("/webhooks", c.CreateWebhook)
+		api.DELETE("/webhooks/:id", c.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", c.WebhookDeliveries)
+		api.POST("/graphql", c.GraphQL)
 	}
 }
@@ -0,0 +1,29 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/server/openapi"
+)
+
+// OpenAPISpec serves the specification of the REST API
+func (c *Controller) OpenAPISpec(context *gin.Context) {
+	context.Data(http.StatusOK, "application/yaml", openapi.Spec())
+}
+
+// Docs serves the documentation page of the specification
+func (c *Controller) Docs(context *gin.Context) {
+	context.Data(http.StatusOK, "text/html; charset=utf-8", openapi.Docs())
+}
+
+// DocsAsset serves the scripts and styles of the documentation page
+func (c *Controller) DocsAsset(context *gin.Context) {
+	data, contentType, err := openapi.DocsAsset(context.Param("file"))
+	if err != nil {
+		context.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	context.Data(http.StatusOK, contentType, data)
+}
@@ -2,28 +2,43 @@ package endpoints
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 
+	"github.com/Jagreen1970/battleship/internal/app"
 	"github.com/Jagreen1970/battleship/internal/game"
 )
 
 type GameAPI interface {
 	ScoreBoard(playerName string) (*game.ScoreBoard, error)
 	Games(page int, count int) ([]*game.Game, error)
+	GameSummaries(page int, count int) ([]game.Summary, error)
 	GetGame(id string) (*game.Game, error)
-	NewGame(player string) (*game.Game, error)
+	NewGame(player string, name string) (*game.Game, error)
+	NewPrivateGame(player string, name string) (*game.Game, error)
+	JoinGame(id string, playerName string) (*game.Game, error)
+	JoinGameByCode(code string, playerName string) (*game.Game, error)
+	CancelGame(id string, playerName string) error
 	UpdateGame(g *game.Game) (*game.Game, error)
 	GetPlayer(playerName string) (*game.Player, error)
 	NewPlayer(playerName string) (*game.Player, error)
+	RequestRematch(gameID string, playerName string) (*game.Game, error)
+	AcceptRematch(gameID string, playerName string) (*game.Game, error)
+	SendChatMessage(gameID string, playerName string, text string) (*game.ChatMessage, error)
+	ChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error)
+	Subscribe(gameID string) (<-chan game.Event, func())
+	FindMatch(playerName string, request game.MatchRequest) (*game.MatchTicket, error)
+	MatchStatus(playerName string) (*game.MatchTicket, error)
+	CancelMatch(playerName string) error
 }
 
 func playerFromSession(context *gin.Context) string {
 	session := sessions.Default(context)
-	v := session.Get(sessionKeyPlayerName)
+	v := session.Get(SessionKeyPlayerName)
 	if v == nil {
 		return ""
 	}
@@ -32,6 +47,11 @@ func playerFromSession(context *gin.Context) string {
 	return playerName
 }
 
+// requestLogger returns the logger of the request, it carries the request ID
+func requestLogger(context *gin.Context) *slog.Logger {
+	return app.LoggerFromContext(context.Request.Context())
+}
+
 func paginationParams(context *gin.Context, defaultItems int) (int, int) {
 	// fetch page and items from context
 	page := context.Query("page")
@@ -40,6 +60,15 @@ func paginationParams(context *gin.Context, defaultItems int) (int, int) {
 	return pageAsInt(page), itemsAsInt(items, defaultItems)
 }
 
+// firstPageParams returns the zero-based page and the items per page, without page the first page
+func firstPageParams(context *gin.Context, defaultItems int) (int, int) {
+	page, err := strconv.Atoi(context.Query("page"))
+	if err != nil || page < 0 {
+		page = 0
+	}
+	return page, itemsAsInt(context.Query("items"), defaultItems)
+}
+
 func itemsAsInt(items string, defaultItems int) int {
 	i, err := strconv.Atoi(items)
 	if err != nil {
@@ -63,6 +92,8 @@ func pageAsInt(page string) int {
 //	ErrorNotReady  = errors.New("not ready")
 //	ErrorInvalid   = errors.New("invalid")
 //	ErrorAmbiguous = errors.New("duplicate")
+//	ErrorInvalidInput = errors.New("invalid input")
+//	ErrorLimitExceeded = errors.New("limit exceeded")
 func mapErrorToStatusErr(err error) (int, any) {
 	if errors.Is(err, game.ErrorNotFound) {
 		return http.StatusNotFound, gin.H{"error": err.Error()}
@@ -70,10 +101,12 @@ func mapErrorToStatusErr(err error) (int, any) {
 	if errors.Is(err, game.ErrorIllegal) {
 		return http.StatusForbidden, gin.H{"error": err.Error()}
 	}
-	if errors.Is(err, game.ErrorInvalid) {
+	if errors.Is(err, game.ErrorInvalid) || errors.Is(err, game.ErrorInvalidInput) {
 		return http.StatusBadRequest, gin.H{"error": err.Error()}
 	}
-	if errors.Is(err, game.ErrorNotReady) {
+	// the open games of a player stay over the limit until one of them is finished or cancelled, so unlike
+	// a rate limit it is a conflict, not a request to try again later
+	if errors.Is(err, game.ErrorNotReady) || errors.Is(err, game.ErrorLimitExceeded) {
 		return http.StatusConflict, gin.H{"error": err.Error()}
 	}
 	return http.StatusInternalServerError, gin.H{"error": err.Error()}
@@ -15,7 +15,7 @@ func (c *Controller) Games(context *gin.Context) {
 	// fetch pagination from query params
 	page, count := paginationParams(context, DefaultGamesPerPage)
 
-	games, err := c.gameAPI.Games(page, count)
+	games, err := c.games(context).GameSummaries(page, count)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -27,8 +27,8 @@ func (c *Controller) Games(context *gin.Context) {
 	}
 
 	response := struct {
-		Games []*game.Game `json:"games"`
-		User  string       `json:"user"`
+		Games []game.Summary `json:"games"`
+		User  string         `json:"user"`
 	}{
 		Games: games,
 		User:  user,
@@ -44,9 +44,8 @@ func (c *Controller) GetGame(context *gin.Context) {
 		return
 	}
 
-	game, err := c.gameAPI.GetGame(gameID)
-	if err != nil {
-		context.JSON(mapErrorToStatusErr(err))
+	game, ok := c.watchedGame(context, gameID)
+	if !ok {
 		return
 	}
 
@@ -59,6 +58,49 @@ func (c *Controller) GetGame(context *gin.Context) {
 	context.JSON(http.StatusOK, playerPerspective(player, game))
 }
 
+// ExportGame downloads a finished game in the portable export format. Running games are not exported since
+// the export contains the fleets of both players.
+func (c *Controller) ExportGame(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	g, ok := c.watchedGame(context, gameID)
+	if !ok {
+		return
+	}
+
+	if !g.Finished() {
+		context.JSON(http.StatusForbidden, gin.H{"error": "only finished games can be exported"})
+		return
+	}
+
+	export, err := g.Export()
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "game-"+g.ID+".json"))
+	context.IndentedJSON(http.StatusOK, export)
+}
+
+// watchedGame returns the game if the player of the session may see it and answers the request if not.
+// Private games are only shown to their players, everybody else is told they don't exist.
+func (c *Controller) watchedGame(context *gin.Context, gameID string) (*game.Game, bool) {
+	g, err := c.games(context).GetGame(gameID)
+	if err == nil {
+		err = g.CanWatch(playerFromSession(context))
+	}
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return nil, false
+	}
+	return g, true
+}
+
 func (c *Controller) CreateGame(context *gin.Context) {
 	player := playerFromSession(context)
 	if player == "" {
@@ -66,13 +108,37 @@ func (c *Controller) CreateGame(context *gin.Context) {
 		return
 	}
 
-	game, err := c.gameAPI.NewGame(player)
+	var request struct {
+		Name       string          `json:"name"`
+		Visibility game.Visibility `json:"visibility"`
+	}
+	if context.Request.ContentLength != 0 {
+		if err := context.ShouldBindJSON(&request); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var (
+		g   *game.Game
+		err error
+	)
+	switch request.Visibility {
+	case "", game.VisibilityPublic:
+		g, err = c.games(context).NewGame(player, request.Name)
+	case game.VisibilityPrivate:
+		g, err = c.games(context).NewPrivateGame(player, request.Name)
+	default:
+		context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid visibility %q", request.Visibility)})
+		return
+	}
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
 	}
 
-	context.JSON(http.StatusCreated, playerPerspective(player, game))
+	requestLogger(context).Info("game created", "game", g.ID, "player", player, "visibility", g.Visibility)
+	context.JSON(http.StatusCreated, playerPerspective(player, g))
 }
 
 func (c *Controller) JoinGame(context *gin.Context) {
@@ -85,27 +151,50 @@ func (c *Controller) JoinGame(context *gin.Context) {
 	gameID := context.Param("id")
 	if gameID == "" {
 		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game"})
+		return
 	}
 
-	player, err := c.gameAPI.GetPlayer(playerName)
+	game, err := c.games(context).JoinGame(gameID, playerName)
 	if err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "could not load player object"})
+		context.JSON(mapErrorToStatusErr(err))
 		return
 	}
 
-	game, err := c.gameAPI.GetGame(gameID)
-	if err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "could not load game object"})
+	context.JSON(http.StatusAccepted, playerPerspective(playerName, game))
+}
+
+// CancelGame deletes a game of the logged in player that nobody has joined yet
+func (c *Controller) CancelGame(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
 		return
 	}
 
-	err = game.Join(player)
-	if err != nil {
+	gameID := context.Param("id")
+	if err := c.games(context).CancelGame(gameID, playerName); err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
 	}
 
-	game, err = c.gameAPI.UpdateGame(game)
+	requestLogger(context).Info("game cancelled", "game", gameID, "player", playerName)
+	context.Status(http.StatusNoContent)
+}
+
+func (c *Controller) JoinGameByCode(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	code := context.Param("code")
+	if code == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid invite code"})
+		return
+	}
+
+	game, err := c.games(context).JoinGameByCode(code, playerName)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -140,7 +229,7 @@ func (c *Controller) PlacePin(context *gin.Context) {
 		return
 	}
 
-	game, err := c.gameAPI.GetGame(gameID)
+	game, err := c.games(context).GetGame(gameID)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -152,7 +241,7 @@ func (c *Controller) PlacePin(context *gin.Context) {
 		return
 	}
 
-	game, err = c.gameAPI.UpdateGame(game)
+	game, err = c.games(context).UpdateGame(game)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -161,6 +250,63 @@ func (c *Controller) PlacePin(context *gin.Context) {
 	context.JSON(http.StatusCreated, playerPerspective(playerName, game))
 }
 
+// PlaceShip places a ship of the logged in player. The field of its top left end is given either as x and y
+// or as a coordinate like "B7".
+func (c *Controller) PlaceShip(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player"})
+		return
+	}
+
+	var request struct {
+		Type        game.ShipType        `json:"type"`
+		X           int                  `json:"x"`
+		Y           int                  `json:"y"`
+		Coordinate  string               `json:"coordinate"`
+		Orientation game.ShipOrientation `json:"orientation"`
+	}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Coordinate != "" {
+		x, y, err := game.ParseCoordinate(request.Coordinate)
+		if err != nil {
+			context.JSON(mapErrorToStatusErr(err))
+			return
+		}
+		request.X, request.Y = x, y
+	}
+
+	g, err := c.games(context).GetGame(gameID)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	err = g.PlaceShip(playerName, request.Type, request.X, request.Y, request.Orientation)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	g, err = c.games(context).UpdateGame(g)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, playerPerspective(playerName, g))
+}
+
 func (c *Controller) RecoverPin(context *gin.Context) {
 	gameID := context.Param("id")
 	if gameID == "" {
@@ -182,13 +328,13 @@ func (c *Controller) RecoverPin(context *gin.Context) {
 		return
 	}
 
-	game, err := c.gameAPI.GetGame(gameID)
+	game, err := c.games(context).GetGame(gameID)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
 	}
 
-	game, err = c.gameAPI.UpdateGame(game)
+	game, err = c.games(context).UpdateGame(game)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -210,7 +356,7 @@ func (c *Controller) StartGame(context *gin.Context) {
 		return
 	}
 
-	game, err := c.gameAPI.GetGame(gameID)
+	game, err := c.games(context).GetGame(gameID)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -222,7 +368,7 @@ func (c *Controller) StartGame(context *gin.Context) {
 		return
 	}
 
-	game, err = c.gameAPI.UpdateGame(game)
+	game, err = c.games(context).UpdateGame(game)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -251,7 +397,7 @@ func (c *Controller) Target(context *gin.Context) {
 		return
 	}
 
-	g, err := c.gameAPI.GetGame(gameID)
+	g, err := c.games(context).GetGame(gameID)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -264,7 +410,7 @@ func (c *Controller) Target(context *gin.Context) {
 		return
 	}
 
-	g, err = c.gameAPI.UpdateGame(g)
+	g, err = c.games(context).UpdateGame(g)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -273,42 +419,116 @@ func (c *Controller) Target(context *gin.Context) {
 	context.JSON(http.StatusOK, playerPerspective(playerName, g))
 }
 
+func (c *Controller) RequestRematch(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player"})
+		return
+	}
+
+	g, err := c.games(context).RequestRematch(gameID, playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusAccepted, playerPerspective(playerName, g))
+}
+
+func (c *Controller) AcceptRematch(context *gin.Context) {
+	gameID := context.Param("id")
+	if gameID == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player"})
+		return
+	}
+
+	g, err := c.games(context).AcceptRematch(gameID, playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, playerPerspective(playerName, g))
+}
+
 type gameView struct {
 	ID      string      `json:"_id,omitempty"`
+	Name    string      `json:"name,omitempty"`
 	User    string      `json:"user"`
 	Board   *game.Board `json:"board"`
 	History []game.Move `json:"history"`
 	Status  game.Status `json:"status"`
 
+	Visibility game.Visibility `json:"visibility"`
+	InviteCode string          `json:"invite_code,omitempty"`
+
 	Player1      *game.Player `json:"player_1"`
 	Player2      *game.Player `json:"player_2"`
 	PlayerToMove string       `json:"player_to_move"`
+
+	RematchOf          string      `json:"rematch_of,omitempty"`
+	RematchID          string      `json:"rematch_id,omitempty"`
+	RematchRequestedBy string      `json:"rematch_requested_by,omitempty"`
+	Series             game.Series `json:"series"`
 }
 
 func playerPerspective(name string, g *game.Game) gameView {
-	return gameView{
-		ID:      g.ID,
-		User:    name,
-		Board:   g.Boards[name],
-		History: g.History,
-		Status:  g.Status,
+	view := gameView{
+		ID:         g.ID,
+		Name:       g.Name,
+		User:       name,
+		Board:      g.Boards[name],
+		History:    g.History,
+		Status:     g.Status,
+		Visibility: g.Visibility,
 
 		Player1:      g.Player1,
 		Player2:      g.Player2,
 		PlayerToMove: g.PlayerToMove,
+
+		RematchOf:          g.RematchOf,
+		RematchID:          g.RematchID,
+		RematchRequestedBy: g.RematchRequestedBy,
+		Series:             g.SeriesScore(),
 	}
+
+	// only the players may pass the invite code on
+	if _, ok := g.Boards[name]; ok {
+		view.InviteCode = game.FormatInviteCode(g.InviteCode)
+	}
+
+	return view
 }
 
 func viewerPerspective(game *game.Game) gameView {
 	return gameView{
 		ID:           game.ID,
+		Name:         game.Name,
 		User:         "guest",
 		Board:        makeViewerBoard(game),
 		History:      game.History,
 		Status:       game.Status,
+		Visibility:   game.Visibility,
 		Player1:      game.Player1,
 		Player2:      game.Player2,
 		PlayerToMove: game.PlayerToMove,
+
+		RematchOf:          game.RematchOf,
+		RematchID:          game.RematchID,
+		RematchRequestedBy: game.RematchRequestedBy,
+		Series:             game.SeriesScore(),
 	}
 }
 
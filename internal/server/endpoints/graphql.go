@@ -0,0 +1,13 @@
+package endpoints
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/graphql"
+)
+
+// GraphQL answers queries and mutations as the player of the session
+func (c *Controller) GraphQL(context *gin.Context) {
+	ctx := graphql.WithPlayer(context.Request.Context(), playerFromSession(context))
+	c.graphQL.ServeHTTP(context.Writer, context.Request.WithContext(ctx))
+}
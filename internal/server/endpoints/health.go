@@ -0,0 +1,37 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/health"
+)
+
+type HealthChecker interface {
+	Ready() health.Report
+}
+
+// SetHealth sets the checker of the readiness, without it the server is ready as soon as it answers
+func (c *Controller) SetHealth(checker HealthChecker) {
+	c.health = checker
+}
+
+// Healthz reports that the server is alive
+func (c *Controller) Healthz(context *gin.Context) {
+	context.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readyz reports whether the server is ready to serve, with the results of the checks
+func (c *Controller) Readyz(context *gin.Context) {
+	report := health.Report{Ready: true, Checks: []health.Check{}, Workers: []health.Worker{}}
+	if c.health != nil {
+		report = c.health.Ready()
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	context.JSON(status, report)
+}
@@ -0,0 +1,69 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+func (c *Controller) FindMatch(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	var request game.MatchRequest
+	if context.Request.ContentLength != 0 {
+		if err := context.ShouldBindJSON(&request); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ticket, err := c.games(context).FindMatch(playerName, request)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	if ticket.Status == game.MatchStatusMatched {
+		context.JSON(http.StatusCreated, ticket)
+		return
+	}
+	context.JSON(http.StatusAccepted, ticket)
+}
+
+func (c *Controller) MatchStatus(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	ticket, err := c.games(context).MatchStatus(playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, ticket)
+}
+
+func (c *Controller) CancelMatch(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	err := c.games(context).CancelMatch(playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"player": playerName, "status": "cancelled"})
+}
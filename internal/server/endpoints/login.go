@@ -24,10 +24,10 @@ func (c *Controller) Login(context *gin.Context) {
 
 	session := sessions.Default(context)
 	session.Options(sessions.Options{
-		MaxAge: 15 * 60,
+		MaxAge: SessionMaxAge,
 	})
 	var playerName string
-	v := session.Get(sessionKeyPlayerName)
+	v := session.Get(SessionKeyPlayerName)
 	if v == nil {
 		playerName = l.Username
 	} else {
@@ -35,21 +35,22 @@ func (c *Controller) Login(context *gin.Context) {
 		context.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("you are already logged in as player %q", playerName)})
 		return
 	}
-	session.Set(sessionKeyPlayerName, playerName)
+	session.Set(SessionKeyPlayerName, playerName)
 	err = session.Save()
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	player, err := c.gameAPI.GetPlayer(playerName)
+	player, err := c.games(context).GetPlayer(playerName)
 	if player == nil && errors.Is(err, game.ErrorNotFound) {
-		player, err = c.gameAPI.NewPlayer(playerName)
+		player, err = c.games(context).NewPlayer(playerName)
 	}
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
 	}
 
+	requestLogger(context).Info("player logged in", "player", playerName)
 	context.JSON(http.StatusOK, player)
 }
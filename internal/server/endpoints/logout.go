@@ -8,6 +8,7 @@ import (
 )
 
 func (c *Controller) Logout(context *gin.Context) {
+	player := playerFromSession(context)
 	session := sessions.Default(context)
 	session.Options(sessions.Options{
 		MaxAge: -1,
@@ -15,7 +16,11 @@ func (c *Controller) Logout(context *gin.Context) {
 	session.Clear()
 	err := session.Save()
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error})
+		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if player != "" {
+		requestLogger(context).Info("player logged out", "player", player)
 	}
 	context.JSON(http.StatusOK, gin.H{"playerName": ""})
 }
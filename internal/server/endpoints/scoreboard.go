@@ -9,7 +9,7 @@ import (
 func (c *Controller) Scoreboard(context *gin.Context) {
 	playerName := playerFromSession(context)
 
-	scoreboard, err := c.gameAPI.ScoreBoard(playerName)
+	scoreboard, err := c.games(context).ScoreBoard(playerName)
 	if err != nil {
 		context.JSON(mapErrorToStatusErr(err))
 		return
@@ -0,0 +1,127 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/tournament"
+)
+
+type TournamentAPI interface {
+	Create(name string, format tournament.Format, organizer string) (*tournament.Tournament, error)
+	Get(id string) (*tournament.Tournament, error)
+	Tournaments(page int, count int) ([]*tournament.Tournament, error)
+	Join(id string, playerName string) (*tournament.Tournament, error)
+	Start(id string, playerName string) (*tournament.Tournament, error)
+	Advance(id string, playerName string) (*tournament.Tournament, error)
+}
+
+const DefaultTournamentsPerPage = 10
+
+type tournamentView struct {
+	*tournament.Tournament
+	Standings []tournament.Standing `json:"standings"`
+}
+
+func newTournamentView(t *tournament.Tournament) tournamentView {
+	return tournamentView{
+		Tournament: t,
+		Standings:  t.Standings(),
+	}
+}
+
+func (c *Controller) Tournaments(context *gin.Context) {
+	page, count := firstPageParams(context, DefaultTournamentsPerPage)
+
+	tournaments, err := c.tournaments(context).Tournaments(page, count)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"tournaments": tournaments})
+}
+
+func (c *Controller) CreateTournament(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	var request struct {
+		Name   string            `json:"name"`
+		Format tournament.Format `json:"format"`
+	}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := c.tournaments(context).Create(request.Name, request.Format, playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusCreated, newTournamentView(t))
+}
+
+func (c *Controller) GetTournament(context *gin.Context) {
+	t, err := c.tournaments(context).Get(context.Param("id"))
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, newTournamentView(t))
+}
+
+func (c *Controller) JoinTournament(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	t, err := c.tournaments(context).Join(context.Param("id"), playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusAccepted, newTournamentView(t))
+}
+
+func (c *Controller) StartTournament(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	t, err := c.tournaments(context).Start(context.Param("id"), playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, newTournamentView(t))
+}
+
+func (c *Controller) AdvanceTournament(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	t, err := c.tournaments(context).Advance(context.Param("id"), playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, newTournamentView(t))
+}
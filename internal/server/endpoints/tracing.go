@@ -0,0 +1,280 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/tracing"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+// games returns the game API traced as part of the request: every call is a span, and the storage
+// operations of the call are its children
+func (c *Controller) games(context *gin.Context) GameAPI {
+	return &tracedGameAPI{api: c.gameAPI, ctx: context.Request.Context()}
+}
+
+type tracedGameAPI struct {
+	api GameAPI
+	ctx context.Context
+}
+
+// bind passes the span of the call on to the storage, if the API supports it
+func (t *tracedGameAPI) bind(ctx context.Context) GameAPI {
+	if api, ok := t.api.(interface {
+		WithContext(ctx context.Context) *game.API
+	}); ok {
+		return api.WithContext(ctx)
+	}
+	return t.api
+}
+
+func (t *tracedGameAPI) ScoreBoard(playerName string) (*game.ScoreBoard, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.ScoreBoard")
+	result, err := t.bind(ctx).ScoreBoard(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) Games(page int, count int) ([]*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.Games")
+	result, err := t.bind(ctx).Games(page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) GameSummaries(page int, count int) ([]game.Summary, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.GameSummaries")
+	result, err := t.bind(ctx).GameSummaries(page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) GetGame(id string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.GetGame")
+	result, err := t.bind(ctx).GetGame(id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) NewGame(player string, name string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.NewGame")
+	result, err := t.bind(ctx).NewGame(player, name)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) NewPrivateGame(player string, name string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.NewPrivateGame")
+	result, err := t.bind(ctx).NewPrivateGame(player, name)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) JoinGame(id string, playerName string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.JoinGame")
+	result, err := t.bind(ctx).JoinGame(id, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) JoinGameByCode(code string, playerName string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.JoinGameByCode")
+	result, err := t.bind(ctx).JoinGameByCode(code, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) CancelGame(id string, playerName string) error {
+	ctx, span := tracing.Start(t.ctx, "game.API.CancelGame")
+	err := t.bind(ctx).CancelGame(id, playerName)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedGameAPI) UpdateGame(g *game.Game) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.UpdateGame")
+	result, err := t.bind(ctx).UpdateGame(g)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) GetPlayer(playerName string) (*game.Player, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.GetPlayer")
+	result, err := t.bind(ctx).GetPlayer(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) NewPlayer(playerName string) (*game.Player, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.NewPlayer")
+	result, err := t.bind(ctx).NewPlayer(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) RequestRematch(gameID string, playerName string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.RequestRematch")
+	result, err := t.bind(ctx).RequestRematch(gameID, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) AcceptRematch(gameID string, playerName string) (*game.Game, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.AcceptRematch")
+	result, err := t.bind(ctx).AcceptRematch(gameID, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) SendChatMessage(gameID string, playerName string, text string) (*game.ChatMessage, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.SendChatMessage")
+	result, err := t.bind(ctx).SendChatMessage(gameID, playerName, text)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) ChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.ChatMessages")
+	result, err := t.bind(ctx).ChatMessages(gameID, page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) Subscribe(gameID string) (<-chan game.Event, func()) {
+	return t.api.Subscribe(gameID)
+}
+
+func (t *tracedGameAPI) FindMatch(playerName string, request game.MatchRequest) (*game.MatchTicket, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.FindMatch")
+	result, err := t.bind(ctx).FindMatch(playerName, request)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) MatchStatus(playerName string) (*game.MatchTicket, error) {
+	ctx, span := tracing.Start(t.ctx, "game.API.MatchStatus")
+	result, err := t.bind(ctx).MatchStatus(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedGameAPI) CancelMatch(playerName string) error {
+	ctx, span := tracing.Start(t.ctx, "game.API.CancelMatch")
+	err := t.bind(ctx).CancelMatch(playerName)
+	tracing.End(span, err)
+	return err
+}
+
+// tournaments returns the tournament API traced as part of the request, like games
+func (c *Controller) tournaments(context *gin.Context) TournamentAPI {
+	return &tracedTournamentAPI{api: c.tournamentAPI, ctx: context.Request.Context()}
+}
+
+type tracedTournamentAPI struct {
+	api TournamentAPI
+	ctx context.Context
+}
+
+// bind passes the span of the call on to the storage, if the API supports it
+func (t *tracedTournamentAPI) bind(ctx context.Context) TournamentAPI {
+	if api, ok := t.api.(interface {
+		WithContext(ctx context.Context) *tournament.API
+	}); ok {
+		return api.WithContext(ctx)
+	}
+	return t.api
+}
+
+func (t *tracedTournamentAPI) Create(name string, format tournament.Format, organizer string) (*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Create")
+	result, err := t.bind(ctx).Create(name, format, organizer)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedTournamentAPI) Get(id string) (*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Get")
+	result, err := t.bind(ctx).Get(id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedTournamentAPI) Tournaments(page int, count int) ([]*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Tournaments")
+	result, err := t.bind(ctx).Tournaments(page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedTournamentAPI) Join(id string, playerName string) (*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Join")
+	result, err := t.bind(ctx).Join(id, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedTournamentAPI) Start(id string, playerName string) (*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Start")
+	result, err := t.bind(ctx).Start(id, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedTournamentAPI) Advance(id string, playerName string) (*tournament.Tournament, error) {
+	ctx, span := tracing.Start(t.ctx, "tournament.API.Advance")
+	result, err := t.bind(ctx).Advance(id, playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+// webhooks returns the webhook API traced as part of the request, like games
+func (c *Controller) webhooks(context *gin.Context) WebhookAPI {
+	return &tracedWebhookAPI{api: c.webhookAPI, ctx: context.Request.Context()}
+}
+
+type tracedWebhookAPI struct {
+	api WebhookAPI
+	ctx context.Context
+}
+
+// bind passes the span of the call on to the storage, if the API supports it
+func (t *tracedWebhookAPI) bind(ctx context.Context) WebhookAPI {
+	if api, ok := t.api.(interface {
+		WithContext(ctx context.Context) *webhook.API
+	}); ok {
+		return api.WithContext(ctx)
+	}
+	return t.api
+}
+
+func (t *tracedWebhookAPI) Subscribe(owner string, url string, secret string, events []game.EventType) (*webhook.Subscription, error) {
+	ctx, span := tracing.Start(t.ctx, "webhook.API.Subscribe")
+	result, err := t.bind(ctx).Subscribe(owner, url, secret, events)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedWebhookAPI) Webhooks(owner string) ([]*webhook.Subscription, error) {
+	ctx, span := tracing.Start(t.ctx, "webhook.API.Webhooks")
+	result, err := t.bind(ctx).Webhooks(owner)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t *tracedWebhookAPI) Unsubscribe(id string, owner string) error {
+	ctx, span := tracing.Start(t.ctx, "webhook.API.Unsubscribe")
+	err := t.bind(ctx).Unsubscribe(id, owner)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedWebhookAPI) Deliveries(id string, owner string, page int, count int) ([]*webhook.Delivery, error) {
+	ctx, span := tracing.Start(t.ctx, "webhook.API.Deliveries")
+	result, err := t.bind(ctx).Deliveries(id, owner, page, count)
+	tracing.End(span, err)
+	return result, err
+}
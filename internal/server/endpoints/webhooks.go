@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+type WebhookAPI interface {
+	Subscribe(owner string, url string, secret string, events []game.EventType) (*webhook.Subscription, error)
+	Webhooks(owner string) ([]*webhook.Subscription, error)
+	Unsubscribe(id string, owner string) error
+	Deliveries(id string, owner string, page int, count int) ([]*webhook.Delivery, error)
+}
+
+const DefaultWebhookDeliveriesPerPage = 20
+
+func (c *Controller) Webhooks(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	webhooks, err := c.webhooks(context).Webhooks(playerName)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (c *Controller) CreateWebhook(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	var request struct {
+		URL    string           `json:"url"`
+		Secret string           `json:"secret"`
+		Events []game.EventType `json:"events"`
+	}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s, err := c.webhooks(context).Subscribe(playerName, request.URL, request.Secret, request.Events)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	// The secret is hidden from all other responses
+	context.JSON(http.StatusCreated, gin.H{"webhook": s, "secret": s.Secret})
+}
+
+func (c *Controller) DeleteWebhook(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	if err := c.webhooks(context).Unsubscribe(context.Param("id"), playerName); err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
+
+func (c *Controller) WebhookDeliveries(context *gin.Context) {
+	playerName := playerFromSession(context)
+	if playerName == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid player - you must be logged in"})
+		return
+	}
+
+	page, count := firstPageParams(context, DefaultWebhookDeliveriesPerPage)
+
+	deliveries, err := c.webhooks(context).Deliveries(context.Param("id"), playerName, page, count)
+	if err != nil {
+		context.JSON(mapErrorToStatusErr(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
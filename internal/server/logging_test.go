@@ -0,0 +1,70 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+)
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	engine := gin.New()
+	engine.Use(RequestLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+	engine.GET("/api/ok", func(c *gin.Context) {
+		app.LoggerFromContext(c.Request.Context()).Info("handled")
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	engine.GET("/api/fail", func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "broken"})
+	})
+
+	serve := func(path string, id string) (*httptest.ResponseRecorder, []map[string]any) {
+		logs.Reset()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		var records []map[string]any
+		decoder := json.NewDecoder(&logs)
+		for decoder.More() {
+			var record map[string]any
+			require.NoError(t, decoder.Decode(&record))
+			records = append(records, record)
+		}
+		return rec, records
+	}
+
+	rec, records := serve("/api/ok", "")
+	id := rec.Header().Get(RequestIDHeader)
+	assert.Len(t, id, 16)
+	require.Len(t, records, 2)
+	assert.Equal(t, "handled", records[0]["msg"])
+	assert.Equal(t, id, records[0]["request_id"], "handlers log with the request ID")
+	assert.Equal(t, "request", records[1]["msg"])
+	assert.Equal(t, "INFO", records[1]["level"])
+	assert.Equal(t, id, records[1]["request_id"])
+	assert.Equal(t, "/api/ok", records[1]["path"])
+	assert.EqualValues(t, http.StatusOK, records[1]["status"])
+
+	rec, records = serve("/api/fail", "proxy-42")
+	assert.Equal(t, "proxy-42", rec.Header().Get(RequestIDHeader), "IDs of clients are kept")
+	require.Len(t, records, 1)
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "proxy-42", records[0]["request_id"])
+
+	rec, _ = serve("/api/ok", "not an id\n")
+	assert.Len(t, rec.Header().Get(RequestIDHeader), 16, "invalid IDs are replaced")
+}
@@ -0,0 +1,240 @@
+// Package openapi holds the OpenAPI specification of the REST API and validates requests and responses
+// against it.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+)
+
+var (
+	//go:embed openapi.yaml
+	spec []byte
+	//go:embed docs.html
+	docs []byte
+)
+
+// Spec returns the specification in YAML
+func Spec() []byte {
+	return spec
+}
+
+// Docs returns the page showing the specification, which it loads from openapi.yaml next to the page
+func Docs() []byte {
+	return docs
+}
+
+// docsAssets are the files of Swagger UI loaded by the docs page, with their content types
+var docsAssets = map[string]string{
+	"swagger-ui.css":       "text/css; charset=utf-8",
+	"swagger-ui-bundle.js": "text/javascript; charset=utf-8",
+}
+
+// DocsAsset returns a file of Swagger UI loaded by the docs page and its content type. The files are
+// embedded in the binary instead of being loaded from a CDN, so the page, which sends the session cookie
+// along, only runs the version of Swagger UI pinned in go.mod.
+func DocsAsset(name string) ([]byte, string, error) {
+	contentType, ok := docsAssets[name]
+	if !ok {
+		return nil, "", fmt.Errorf("no docs asset %q: %w", name, fs.ErrNotExist)
+	}
+
+	data, err := fs.ReadFile(swaggerFiles.FS, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentType, nil
+}
+
+// Load parses and validates the specification
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the OpenAPI specification: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI specification: %w", err)
+	}
+	return doc, nil
+}
+
+// Validator rejects requests not matching the specification and reports responses not matching it.
+// Requests of paths missing in the specification, like the frontend files, are passed on unchecked.
+type Validator struct {
+	router routers.Router
+	// StrictResponses replaces responses not matching the specification by an internal server error,
+	// otherwise they are logged and sent anyway
+	StrictResponses bool
+}
+
+func NewValidator() (*Validator, error) {
+	doc, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{router: router}, nil
+}
+
+// options leave the requests as they are, the handlers apply their own defaults. Sessions are checked by
+// the handlers as well.
+func options() *openapi3filter.Options {
+	opts := &openapi3filter.Options{
+		SkipSettingDefaults: true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	}
+	opts.WithCustomSchemaErrorFunc(schemaError)
+	return opts
+}
+
+// schemaError names the invalid value without dumping the schema into the message
+func schemaError(err *openapi3.SchemaError) string {
+	if path := err.JSONPointer(); len(path) > 0 {
+		return fmt.Sprintf("%q %s", strings.Join(path, "."), err.Reason)
+	}
+	return err.Reason
+}
+
+// Middleware validates the requests and responses of the routes in the specification
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, params, err := v.router.FindRoute(c.Request)
+		if err != nil {
+			// routes missing in the specification are answered by gin
+			c.Next()
+			return
+		}
+
+		request := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options:    options(),
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), request); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": requestError(err)})
+			return
+		}
+
+		if streams(route) {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		response := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: request,
+			Status:                 writer.status,
+			Header:                 writer.Header(),
+			Options:                options(),
+		}
+		response.SetBodyBytes(writer.body.Bytes())
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), response); err != nil {
+			app.LoggerFromContext(c.Request.Context()).Warn("response does not match the OpenAPI specification",
+				"method", c.Request.Method, "route", route.Path, "error", err)
+			if v.StrictResponses {
+				writer.Header().Del("Content-Length")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response: " + err.Error()})
+				return
+			}
+		}
+		writer.flush()
+	}
+}
+
+// requestError returns the message of a rejected request
+func requestError(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		return "invalid request: " + requestErr.Error()
+	}
+	return "invalid request: " + err.Error()
+}
+
+// streams reports whether the route answers with server-sent events, which are not buffered
+func streams(route *routers.Route) bool {
+	for _, response := range route.Operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		if _, ok := response.Value.Content["text/event-stream"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the response back until it is validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+	w.written = true
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// flush sends the held back response
+func (w *bufferedWriter) flush() {
+	if !w.written {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
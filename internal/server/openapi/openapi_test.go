@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEngine(t *testing.T, strict bool, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	validator, err := NewValidator()
+	require.NoError(t, err)
+	validator.StrictResponses = strict
+
+	engine := gin.New()
+	engine.Use(validator.Middleware())
+	engine.POST("/api/login", handler)
+	engine.GET("/api/games/:id/events", handler)
+	engine.GET("/index.html", handler)
+	return engine
+}
+
+func serve(engine *gin.Engine, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func errorMessage(t *testing.T, rec *httptest.ResponseRecorder) string {
+	var response struct {
+		Error string `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+	return response.Error
+}
+
+func TestValidateRequests(t *testing.T) {
+	called := 0
+	engine := newTestEngine(t, true, func(c *gin.Context) {
+		called++
+		c.JSON(http.StatusOK, gin.H{"name": "alice", "id": "1", "score": 0})
+	})
+
+	rec := serve(engine, http.MethodPost, "/api/login", `{"username": "alice"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name": "alice", "id": "1", "score": 0}`, rec.Body.String())
+	assert.Equal(t, 1, called)
+
+	rec = serve(engine, http.MethodPost, "/api/login", `{"username": ""}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, errorMessage(t, rec), `"username"`)
+
+	rec = serve(engine, http.MethodPost, "/api/login", `{"username": 7}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, errorMessage(t, rec), "invalid request")
+
+	rec = serve(engine, http.MethodPost, "/api/login", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 1, called, "invalid requests don't reach the handler")
+
+	// paths missing in the specification are not validated
+	rec = serve(engine, http.MethodGet, "/index.html", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, called)
+}
+
+func TestValidateResponses(t *testing.T) {
+	invalid := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": 7})
+	}
+
+	rec := serve(newTestEngine(t, false, invalid), http.MethodPost, "/api/login", `{"username": "alice"}`)
+	assert.Equal(t, http.StatusOK, rec.Code, "invalid responses are only logged")
+	assert.JSONEq(t, `{"name": 7}`, rec.Body.String())
+
+	rec = serve(newTestEngine(t, true, invalid), http.MethodPost, "/api/login", `{"username": "alice"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, errorMessage(t, rec), "invalid response")
+
+	rec = serve(newTestEngine(t, true, func(c *gin.Context) {
+		c.JSON(http.StatusConflict, gin.H{"error": "already logged in"})
+	}), http.MethodPost, "/api/login", `{"username": "alice"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, "undocumented status codes are accepted")
+
+	// event streams are passed through
+	rec = serve(newTestEngine(t, true, func(c *gin.Context) {
+		c.SSEvent("game.updated", "{}")
+	}), http.MethodGet, "/api/games/game1/events", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "event:game.updated")
+}
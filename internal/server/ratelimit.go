@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+)
+
+// rateLimit limits the requests to the API by client IP and by the player of the session. A request over a
+// limit is answered with 429 Too Many Requests and a Retry-After header. If the store fails, the request
+// is let through.
+func rateLimit(cfg app.RateLimitConfig, store ratelimit.Store) gin.HandlerFunc {
+	ipLimit := ratelimit.Limit{Rate: cfg.IPRate, Burst: cfg.IPBurst}
+	playerLimit := ratelimit.Limit{Rate: cfg.PlayerRate, Burst: cfg.PlayerBurst}
+
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+
+		if !take(c, store, "ip:"+c.ClientIP(), ipLimit) {
+			return
+		}
+		if player, _ := sessions.Default(c).Get(endpoints.SessionKeyPlayerName).(string); player != "" {
+			if !take(c, store, "player:"+player, playerLimit) {
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// take takes a token of the key, or aborts the request when there is none
+func take(c *gin.Context, store ratelimit.Store, key string, limit ratelimit.Limit) bool {
+	if limit.Rate <= 0 {
+		return true
+	}
+
+	ok, wait, err := store.Take(key, limit)
+	if err != nil {
+		app.LoggerFromContext(c.Request.Context()).Warn("rate limit not checked", "key", key, "error", err)
+		return true
+	}
+	if ok {
+		return true
+	}
+
+	retry := max(int(math.Ceil(wait.Seconds())), 1)
+	c.Header("Retry-After", strconv.Itoa(retry))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"error": fmt.Sprintf("rate limit exceeded, retry in %s", time.Duration(retry)*time.Second),
+	})
+	return false
+}
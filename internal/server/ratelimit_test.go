@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+)
+
+func newRateLimitedEngine(cfg app.RateLimitConfig, store ratelimit.Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(sessions.Sessions(SessionName, cookie.NewStore([]byte("test"))), rateLimit(cfg, store))
+	engine.GET("/api/login/:player", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set(endpoints.SessionKeyPlayerName, c.Param("player"))
+		_ = session.Save()
+	})
+	engine.GET("/api/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+func serve(engine *gin.Engine, path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitByIP(t *testing.T) {
+	engine := newRateLimitedEngine(app.RateLimitConfig{IPRate: 0.5, IPBurst: 2}, ratelimit.NewMemoryStore())
+
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/").Code)
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/").Code)
+
+	rec := serve(engine, "/api/")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error": "rate limit exceeded, retry in 2s"}`, rec.Body.String())
+
+	// only the API is limited
+	assert.Equal(t, http.StatusOK, serve(engine, "/healthz").Code)
+}
+
+func TestRateLimitByPlayer(t *testing.T) {
+	engine := newRateLimitedEngine(app.RateLimitConfig{PlayerRate: 1, PlayerBurst: 1}, ratelimit.NewMemoryStore())
+
+	alice := serve(engine, "/api/login/alice").Result().Cookies()
+	bob := serve(engine, "/api/login/bob").Result().Cookies()
+
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/", alice...).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(engine, "/api/", alice...).Code)
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/", bob...).Code)
+
+	// guests are limited by IP only
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/").Code)
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/").Code)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(string, ratelimit.Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func TestRateLimitStoreFailure(t *testing.T) {
+	engine := newRateLimitedEngine(app.RateLimitConfig{IPRate: 1, IPBurst: 1}, failingStore{})
+
+	assert.Equal(t, http.StatusOK, serve(engine, "/api/").Code)
+}
+
+func TestRateLimitIgnoresForwardedForOfClients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := app.ServerConfig{SessionSecret: "test", RateLimit: app.RateLimitConfig{IPRate: 1, IPBurst: 1}}
+	router, err := NewRouter(cfg, endpoints.NewController(nil, nil, nil), slog.New(slog.DiscardHandler), nil, nil)
+	require.NoError(t, err)
+
+	codes := make([]int, 2)
+	for i, ip := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/", nil)
+		req.Header.Set("X-Forwarded-For", ip)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/metrics"
+	"github.com/Jagreen1970/battleship/internal/ratelimit"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+	"github.com/Jagreen1970/battleship/internal/server/openapi"
+	"github.com/Jagreen1970/battleship/internal/tracing"
+)
+
+// SessionName is the name of the session cookie
+const SessionName = "battleship"
+
+// NewRouter returns the handler of the REST API with cookie sessions. Requests are traced and logged with the
+// logger, requests and responses are validated against the OpenAPI specification. With metrics the requests
+// and sessions are counted and the metrics are served at /metrics. The rate limits of the configuration keep
+// their token buckets in limits, in memory if it is nil.
+func NewRouter(cfg app.ServerConfig, controller *endpoints.Controller, logger *slog.Logger,
+	m *metrics.Metrics, limits ratelimit.Store) (http.Handler, error) {
+	secret, err := SessionSecret(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := openapi.NewValidator()
+	if err != nil {
+		return nil, err
+	}
+	validator.StrictResponses = cfg.StrictResponses
+	if limits == nil {
+		limits = ratelimit.NewMemoryStore()
+	}
+
+	engine := gin.New()
+	if err := engine.SetTrustedProxies(trustedProxies(cfg.TrustedProxies)); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	engine.Use(tracing.Middleware(), RequestLogger(logger), gin.Recovery())
+	if m != nil {
+		engine.Use(m.Middleware())
+		engine.GET("/metrics", gin.WrapH(m.Handler()))
+	}
+	engine.Use(sessions.Sessions(SessionName, cookie.NewStore(secret)))
+	if m != nil {
+		engine.Use(sessionMetrics(m))
+	}
+	engine.Use(rateLimit(cfg.RateLimit, limits))
+	engine.Use(validator.Middleware())
+	controller.Register(engine)
+
+	return engine, nil
+}
+
+// SessionSecret returns the configured session secret, or a random one when none is configured. APIs sharing
+// the sessions have to be given the same secret.
+func SessionSecret(cfg app.ServerConfig) ([]byte, error) {
+	if cfg.SessionSecret != "" {
+		return []byte(cfg.SessionSecret), nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("could not create session secret: %w", err)
+	}
+	return secret, nil
+}
+
+// sessionMetrics marks the player of the session as active, or as gone once the request ended the session
+func sessionMetrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		before, _ := session.Get(endpoints.SessionKeyPlayerName).(string)
+		c.Next()
+
+		after, _ := session.Get(endpoints.SessionKeyPlayerName).(string)
+		switch {
+		case after != "":
+			m.SessionSeen(after)
+		case before != "":
+			m.SessionEnded(before)
+		}
+	}
+}
+
+// trustedProxies splits the comma separated list of proxies, nil trusts none
+func trustedProxies(list string) []string {
+	var proxies []string
+	for _, p := range strings.Split(list, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
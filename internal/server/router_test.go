@@ -0,0 +1,151 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/health"
+	"github.com/Jagreen1970/battleship/internal/metrics"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+	"github.com/Jagreen1970/battleship/internal/server/openapi"
+)
+
+func TestSpecCoversAllRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	endpoints.NewController(nil, nil, nil).Register(engine)
+
+	params := regexp.MustCompile(`:[a-z]+`)
+	for _, route := range engine.Routes() {
+		// a value valid for all path parameters, pins are given as x-y
+		path := params.ReplaceAllString(route.Path, "1-2")
+		_, _, err := router.FindRoute(httptest.NewRequest(route.Method, path, nil))
+		assert.NoError(t, err, "%s %s", route.Method, route.Path)
+	}
+}
+
+func TestMetricsRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router, err := NewRouter(app.ServerConfig{SessionSecret: "test"}, endpoints.NewController(nil, nil, nil),
+		slog.New(slog.DiscardHandler), metrics.New(), nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `battleship_http_requests_total{method="GET",route="/api/docs",status="200"} 1`)
+}
+
+func TestDocsAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router, err := NewRouter(app.ServerConfig{SessionSecret: "test"}, endpoints.NewController(nil, nil, nil),
+		slog.New(slog.DiscardHandler), metrics.New(), nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "https://", "the page loads nothing from other sites")
+
+	for path, contentType := range map[string]string{
+		"/api/docs/swagger-ui.css":       "text/css",
+		"/api/docs/swagger-ui-bundle.js": "text/javascript",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Header().Get("Content-Type"), contentType, path)
+		assert.NotEmpty(t, rec.Body.Bytes(), path)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/index.html", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "only the files of the page are served")
+}
+
+func TestSessionMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+	engine := gin.New()
+	engine.Use(sessions.Sessions(SessionName, cookie.NewStore([]byte("test"))), sessionMetrics(m))
+	engine.GET("/login/:player", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set(endpoints.SessionKeyPlayerName, c.Param("player"))
+		_ = session.Save()
+	})
+	engine.GET("/logout", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Clear()
+		_ = session.Save()
+	})
+
+	active := func() string {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		for _, line := range strings.Split(rec.Body.String(), "\n") {
+			if strings.HasPrefix(line, "battleship_active_sessions ") {
+				return strings.TrimPrefix(line, "battleship_active_sessions ")
+			}
+		}
+		return ""
+	}
+
+	var cookies []*http.Cookie
+	for _, path := range []string{"/login/alice", "/login/bob"} {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		cookies = append(cookies, rec.Result().Cookies()...)
+	}
+	assert.Equal(t, "2", active())
+
+	req := httptest.NewRequest(http.MethodGet, "/logout", nil)
+	req.AddCookie(cookies[0])
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "1", active())
+}
+
+func TestHealthRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := endpoints.NewController(nil, nil, nil)
+	checker := health.New()
+	storageErr := errors.New("connection refused")
+	checker.AddCheck("storage", func() error { return storageErr })
+	controller.SetHealth(checker)
+	router, err := NewRouter(app.ServerConfig{SessionSecret: "test"}, controller, slog.New(slog.DiscardHandler), nil, nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not ready: storage: connection refused")
+
+	storageErr = nil
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"ready":true`)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/Jagreen1970/battleship/internal/app"
@@ -10,23 +11,43 @@ import (
 
 type Server struct {
 	httpServer *http.Server
+	handler    http.Handler
+	logger     *slog.Logger
 	cfg        app.ServerConfig
 }
 
 func New(cfg app.ServerConfig) *Server {
 	return &Server{
-		cfg: cfg,
+		cfg:    cfg,
+		logger: slog.Default(),
 	}
 }
 
+// SetLogger sets the logger of the server, by default slog.Default() is used
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// SetHandler sets the handler serving all requests, by default http.DefaultServeMux is used
+func (s *Server) SetHandler(handler http.Handler) {
+	s.handler = handler
+}
+
 func (s *Server) Start() error {
+	handler := s.handler
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+
 	s.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", s.cfg.Port),
-		Handler:      http.DefaultServeMux,
+		Handler:      handler,
 		ReadTimeout:  s.cfg.Timeout,
 		WriteTimeout: s.cfg.Timeout,
+		ErrorLog:     slog.NewLogLogger(s.logger.Handler(), slog.LevelError),
 	}
 
+	s.logger.Info("HTTP server listening", "addr", s.httpServer.Addr)
 	return s.httpServer.ListenAndServe()
 }
 
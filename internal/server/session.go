@@ -0,0 +1,47 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/gorilla/securecookie"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+)
+
+// SessionCodec encodes and decodes the session cookies of the REST API outside of gin, so other APIs like
+// gRPC share the sessions with it
+type SessionCodec struct {
+	codecs []securecookie.Codec
+}
+
+// NewSessionCodec returns the codec for the sessions signed with the secret of the REST API
+func NewSessionCodec(secret []byte) *SessionCodec {
+	codecs := securecookie.CodecsFromPairs(secret)
+	for _, codec := range codecs {
+		if c, ok := codec.(*securecookie.SecureCookie); ok {
+			c.MaxAge(endpoints.SessionMaxAge)
+		}
+	}
+	return &SessionCodec{codecs: codecs}
+}
+
+// Encode returns the value of the session cookie of the player
+func (s *SessionCodec) Encode(playerName string) (string, error) {
+	values := map[any]any{endpoints.SessionKeyPlayerName: playerName}
+	return securecookie.EncodeMulti(SessionName, values, s.codecs...)
+}
+
+// Decode returns the player of a session cookie value
+func (s *SessionCodec) Decode(value string) (string, error) {
+	values := make(map[any]any)
+	if err := securecookie.DecodeMulti(SessionName, value, &values, s.codecs...); err != nil {
+		return "", fmt.Errorf("invalid session: %v: %w", err, game.ErrorIllegal)
+	}
+
+	playerName, ok := values[endpoints.SessionKeyPlayerName].(string)
+	if !ok || playerName == "" {
+		return "", fmt.Errorf("session without player: %w", game.ErrorIllegal)
+	}
+	return playerName, nil
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/server/endpoints"
+)
+
+// sessionRouter logs in the player of the query on /login and answers the player of the session on /player,
+// like the REST API does
+func sessionRouter(secret []byte) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(sessions.Sessions(SessionName, cookie.NewStore(secret)))
+	router.GET("/login", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set(endpoints.SessionKeyPlayerName, c.Query("player"))
+		_ = session.Save()
+	})
+	router.GET("/player", func(c *gin.Context) {
+		player, _ := sessions.Default(c).Get(endpoints.SessionKeyPlayerName).(string)
+		c.String(http.StatusOK, player)
+	})
+	return router
+}
+
+func TestSessionCodec(t *testing.T) {
+	router := sessionRouter([]byte("secret"))
+	codec := NewSessionCodec([]byte("secret"))
+
+	// a session of the REST API is decoded
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/login?player=alice", nil))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	player, err := codec.Decode(cookies[0].Value)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", player)
+
+	// an encoded session is accepted by the REST API
+	value, err := codec.Encode("bob")
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, "/player", nil)
+	r.AddCookie(&http.Cookie{Name: SessionName, Value: value})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, "bob", w.Body.String())
+
+	_, err = NewSessionCodec([]byte("other secret")).Decode(value)
+	assert.ErrorIs(t, err, game.ErrorIllegal)
+}
@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+// Observer is told about every storage operation: the name of the Storage method, how long it took and its
+// error. Not found is an answer rather than a failure, so it is reported as success.
+type Observer interface {
+	ObserveStorage(operation string, duration time.Duration, err error)
+}
+
+// Instrument returns a Storage reporting every operation of s to the observer
+func Instrument(s Storage, observer Observer) Storage {
+	return &instrumented{Storage: s, observer: observer}
+}
+
+type instrumented struct {
+	Storage
+	observer Observer
+}
+
+func (i *instrumented) observe(operation string, start time.Time, err error) {
+	if errors.Is(err, game.ErrorNotFound) {
+		err = nil
+	}
+	i.observer.ObserveStorage(operation, time.Since(start), err)
+}
+
+func (i *instrumented) Connect() error {
+	start := time.Now()
+	err := i.Storage.Connect()
+	i.observe("Connect", start, err)
+	return err
+}
+
+func (i *instrumented) Disconnect() error {
+	start := time.Now()
+	err := i.Storage.Disconnect()
+	i.observe("Disconnect", start, err)
+	return err
+}
+
+func (i *instrumented) Ping() error {
+	start := time.Now()
+	err := i.Storage.Ping()
+	i.observe("Ping", start, err)
+	return err
+}
+
+func (i *instrumented) Close() error {
+	start := time.Now()
+	err := i.Storage.Close()
+	i.observe("Close", start, err)
+	return err
+}
+
+func (i *instrumented) Indexes() ([]IndexStatus, error) {
+	start := time.Now()
+	result, err := i.Storage.Indexes()
+	i.observe("Indexes", start, err)
+	return result, err
+}
+
+func (i *instrumented) CreateIndexes() error {
+	start := time.Now()
+	err := i.Storage.CreateIndexes()
+	i.observe("CreateIndexes", start, err)
+	return err
+}
+
+func (i *instrumented) CreatePlayer(playerName string) (*game.Player, error) {
+	start := time.Now()
+	result, err := i.Storage.CreatePlayer(playerName)
+	i.observe("CreatePlayer", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindPlayerByName(username string) (*game.Player, error) {
+	start := time.Now()
+	result, err := i.Storage.FindPlayerByName(username)
+	i.observe("FindPlayerByName", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryGames(page int, count int) ([]*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryGames(page, count)
+	i.observe("QueryGames", start, err)
+	return result, err
+}
+
+func (i *instrumented) CreateGame(game *game.Game) (*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateGame(game)
+	i.observe("CreateGame", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindGameByID(id string) (*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.FindGameByID(id)
+	i.observe("FindGameByID", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindGameByName(name string) (*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.FindGameByName(name)
+	i.observe("FindGameByName", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindGameByInviteCode(code string) (*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.FindGameByInviteCode(code)
+	i.observe("FindGameByInviteCode", start, err)
+	return result, err
+}
+
+func (i *instrumented) UpdateGame(game *game.Game) (*game.Game, error) {
+	start := time.Now()
+	result, err := i.Storage.UpdateGame(game)
+	i.observe("UpdateGame", start, err)
+	return result, err
+}
+
+func (i *instrumented) DeleteGame(id string) error {
+	start := time.Now()
+	err := i.Storage.DeleteGame(id)
+	i.observe("DeleteGame", start, err)
+	return err
+}
+
+func (i *instrumented) DeleteAllGames() (int, error) {
+	start := time.Now()
+	result, err := i.Storage.DeleteAllGames()
+	i.observe("DeleteAllGames", start, err)
+	return result, err
+}
+
+func (i *instrumented) CountOpenGames(playerName string) (int, error) {
+	start := time.Now()
+	result, err := i.Storage.CountOpenGames(playerName)
+	i.observe("CountOpenGames", start, err)
+	return result, err
+}
+
+func (i *instrumented) CountGamesByStatus() (map[game.Status]int, error) {
+	start := time.Now()
+	result, err := i.Storage.CountGamesByStatus()
+	i.observe("CountGamesByStatus", start, err)
+	return result, err
+}
+
+func (i *instrumented) CreateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateMatchTicket(ticket)
+	i.observe("CreateMatchTicket", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindMatchTicket(playerName string) (*game.MatchTicket, error) {
+	start := time.Now()
+	result, err := i.Storage.FindMatchTicket(playerName)
+	i.observe("FindMatchTicket", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryMatchTickets(preset game.RulePreset) ([]*game.MatchTicket, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryMatchTickets(preset)
+	i.observe("QueryMatchTickets", start, err)
+	return result, err
+}
+
+func (i *instrumented) ClaimRematch(gameID string, rematchID string) error {
+	start := time.Now()
+	err := i.Storage.ClaimRematch(gameID, rematchID)
+	i.observe("ClaimRematch", start, err)
+	return err
+}
+
+func (i *instrumented) ClaimMatchTicket(playerName string) (*game.MatchTicket, error) {
+	start := time.Now()
+	result, err := i.Storage.ClaimMatchTicket(playerName)
+	i.observe("ClaimMatchTicket", start, err)
+	return result, err
+}
+
+func (i *instrumented) UpdateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	start := time.Now()
+	result, err := i.Storage.UpdateMatchTicket(ticket)
+	i.observe("UpdateMatchTicket", start, err)
+	return result, err
+}
+
+func (i *instrumented) DeleteMatchTicket(playerName string) error {
+	start := time.Now()
+	err := i.Storage.DeleteMatchTicket(playerName)
+	i.observe("DeleteMatchTicket", start, err)
+	return err
+}
+
+func (i *instrumented) CreateChatMessage(message *game.ChatMessage) (*game.ChatMessage, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateChatMessage(message)
+	i.observe("CreateChatMessage", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryChatMessages(gameID, page, count)
+	i.observe("QueryChatMessages", start, err)
+	return result, err
+}
+
+func (i *instrumented) CreateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateTournament(t)
+	i.observe("CreateTournament", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindTournamentByID(id string) (*tournament.Tournament, error) {
+	start := time.Now()
+	result, err := i.Storage.FindTournamentByID(id)
+	i.observe("FindTournamentByID", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryTournaments(page int, count int) ([]*tournament.Tournament, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryTournaments(page, count)
+	i.observe("QueryTournaments", start, err)
+	return result, err
+}
+
+func (i *instrumented) UpdateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	start := time.Now()
+	result, err := i.Storage.UpdateTournament(t)
+	i.observe("UpdateTournament", start, err)
+	return result, err
+}
+
+func (i *instrumented) UpdateTournamentRound(t *tournament.Tournament, rounds int) (*tournament.Tournament, error) {
+	start := time.Now()
+	result, err := i.Storage.UpdateTournamentRound(t, rounds)
+	i.observe("UpdateTournamentRound", start, err)
+	return result, err
+}
+
+func (i *instrumented) CreateWebhook(s *webhook.Subscription) (*webhook.Subscription, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateWebhook(s)
+	i.observe("CreateWebhook", start, err)
+	return result, err
+}
+
+func (i *instrumented) FindWebhookByID(id string) (*webhook.Subscription, error) {
+	start := time.Now()
+	result, err := i.Storage.FindWebhookByID(id)
+	i.observe("FindWebhookByID", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryWebhooks(owners ...string) ([]*webhook.Subscription, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryWebhooks(owners...)
+	i.observe("QueryWebhooks", start, err)
+	return result, err
+}
+
+func (i *instrumented) DeleteWebhook(id string) error {
+	start := time.Now()
+	err := i.Storage.DeleteWebhook(id)
+	i.observe("DeleteWebhook", start, err)
+	return err
+}
+
+func (i *instrumented) CreateWebhookDelivery(d *webhook.Delivery) (*webhook.Delivery, error) {
+	start := time.Now()
+	result, err := i.Storage.CreateWebhookDelivery(d)
+	i.observe("CreateWebhookDelivery", start, err)
+	return result, err
+}
+
+func (i *instrumented) QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*webhook.Delivery, error) {
+	start := time.Now()
+	result, err := i.Storage.QueryWebhookDeliveries(subscriptionID, page, count)
+	i.observe("QueryWebhookDeliveries", start, err)
+	return result, err
+}
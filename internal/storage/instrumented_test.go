@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+type observation struct {
+	operation string
+	err       error
+}
+
+type recorder struct {
+	observations []observation
+}
+
+func (r *recorder) ObserveStorage(operation string, duration time.Duration, err error) {
+	r.observations = append(r.observations, observation{operation, err})
+}
+
+// stubStorage answers the calls of the test, all other methods are missing
+type stubStorage struct {
+	Storage
+	err error
+}
+
+func (s *stubStorage) FindGameByID(id string) (*game.Game, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &game.Game{ID: id}, nil
+}
+
+func (s *stubStorage) DeleteGame(id string) error {
+	return s.err
+}
+
+func TestInstrument(t *testing.T) {
+	r := &recorder{}
+	stub := &stubStorage{}
+	db := Instrument(stub, r)
+
+	g, err := db.FindGameByID("game1")
+	assert.NoError(t, err)
+	assert.Equal(t, "game1", g.ID)
+
+	failure := errors.New("timeout")
+	stub.err = failure
+	_, err = db.FindGameByID("game1")
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorIs(t, db.DeleteGame("game1"), failure)
+
+	stub.err = game.ErrorNotFound
+	_, err = db.FindGameByID("game1")
+	assert.ErrorIs(t, err, game.ErrorNotFound)
+
+	assert.Equal(t, []observation{
+		{"FindGameByID", nil},
+		{"FindGameByID", failure},
+		{"DeleteGame", failure},
+		{"FindGameByID", nil},
+	}, r.observations, "not found is no failure")
+}
@@ -0,0 +1,72 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChatMessage struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	Message *game.ChatMessage  `bson:"message"`
+}
+
+// CreateChatMessage stores a chat message of a game
+func (m *MongoDB) CreateChatMessage(message *game.ChatMessage) (*game.ChatMessage, error) {
+	objID := primitive.NewObjectID()
+	message.ID = objID.Hex()
+
+	doc := ChatMessage{
+		ID:      objID,
+		Message: message,
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("chat")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating chat message: %w", err)
+	}
+
+	return doc.Message, nil
+}
+
+// QueryChatMessages retrieves the chat messages of a game with pagination, newest first
+func (m *MongoDB) QueryChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("chat")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	skip := int64(page * count)
+	if skip < 0 {
+		skip = 0
+	}
+
+	opts := options.Find().SetSkip(skip).SetLimit(int64(count))
+	opts.SetSort(bson.D{primitive.E{Key: "_id", Value: -1}})
+
+	cursor, err := collection.Find(ctx, bson.D{primitive.E{Key: "message.game_id", Value: gameID}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error querying chat messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []ChatMessage
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("error decoding chat messages: %w", err)
+	}
+
+	ret := make([]*game.ChatMessage, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.Message
+	}
+	return ret, nil
+}
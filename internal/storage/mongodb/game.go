@@ -16,7 +16,7 @@ type Game struct {
 	Game *game.Game         `bson:"game"`
 }
 
-// QueryGames retrieves a list of games with pagination
+// QueryGames retrieves a list of public games with pagination
 func (m *MongoDB) QueryGames(page int, count int) ([]*game.Game, error) {
 	collection := m.client.Database(m.cfg.Name).Collection("games")
 
@@ -33,7 +33,12 @@ func (m *MongoDB) QueryGames(page int, count int) ([]*game.Game, error) {
 	// Sort by _id in descending order to get newest games first
 	opts.SetSort(bson.D{primitive.E{Key: "_id", Value: -1}})
 	
-	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	// Private games are only reachable through their invite code
+	filter := bson.D{primitive.E{Key: "game.visibility", Value: bson.D{
+		primitive.E{Key: "$ne", Value: game.VisibilityPrivate},
+	}}}
+
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error querying games: %w", err)
 	}
@@ -129,6 +134,31 @@ func (m *MongoDB) FindGameByName(name string) (*game.Game, error) {
 	return g.Game, nil
 }
 
+// FindGameByInviteCode retrieves a game by its invite code
+func (m *MongoDB) FindGameByInviteCode(code string) (*game.Game, error) {
+	if code == "" {
+		return nil, fmt.Errorf("invite code cannot be empty: %w", game.ErrorInvalidInput)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("games")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	var g Game
+	err := collection.FindOne(ctx, bson.D{primitive.E{Key: "game.invite_code", Value: code}}).Decode(&g)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, game.ErrorNotFound
+		}
+		return nil, fmt.Errorf("error finding game by invite code: %w", err)
+	}
+
+	// Make sure to set the ID in the game object
+	g.Game.ID = g.ID.Hex()
+	return g.Game, nil
+}
+
 // UpdateGame updates an existing game in the database
 func (m *MongoDB) UpdateGame(g *game.Game) (*game.Game, error) {
 	gameID, err := primitive.ObjectIDFromHex(g.ID)
@@ -159,6 +189,43 @@ func (m *MongoDB) UpdateGame(g *game.Game) (*game.Game, error) {
 	return g, nil
 }
 
+// ClaimRematch atomically sets the rematch ID of a game which has none yet. It returns ErrorNotFound if
+// the game doesn't exist or already has a rematch, e.g. because another request claimed it first.
+func (m *MongoDB) ClaimRematch(gameID string, rematchID string) error {
+	id, err := primitive.ObjectIDFromHex(gameID)
+	if err != nil {
+		return fmt.Errorf("invalid game ID: %w", err)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("games")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "game.rematch_id", Value: bson.D{
+			primitive.E{Key: "$in", Value: bson.A{nil, ""}},
+		}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "game.rematch_id", Value: rematchID},
+		}},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error claiming rematch: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return game.ErrorNotFound
+	}
+
+	return nil
+}
+
 // DeleteGame deletes a specific game by ID
 func (m *MongoDB) DeleteGame(id string) error {
 	gameID, err := primitive.ObjectIDFromHex(id)
@@ -197,3 +264,58 @@ func (m *MongoDB) DeleteAllGames() (int, error) {
 
 	return int(result.DeletedCount), nil
 }
+
+// CountOpenGames counts the games in setup or in progress the player takes part in
+func (m *MongoDB) CountOpenGames(playerName string) (int, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("games")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"game.player1.name": playerName},
+			bson.M{"game.player2.name": playerName},
+		},
+		"game.status": bson.M{"$in": bson.A{game.StatusSetup, game.StatusPlaying}},
+	}
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("error counting open games: %w", err)
+	}
+	return int(count), nil
+}
+
+// CountGamesByStatus counts all games, private ones included, grouped by their status
+func (m *MongoDB) CountGamesByStatus() (map[game.Status]int, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("games")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$game.status"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error counting games by status: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Status game.Status `bson:"_id"`
+		Count  int         `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("error decoding game counts: %w", err)
+	}
+
+	counts := make(map[game.Status]int, len(groups))
+	for _, group := range groups {
+		counts[group.Status] = group.Count
+	}
+	return counts, nil
+}
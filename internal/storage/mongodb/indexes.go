@@ -0,0 +1,143 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexStatus tells whether an index the queries rely on exists. A unique index is only present if the
+// existing index is unique as well.
+type IndexStatus struct {
+	Collection string   `json:"collection"`
+	Keys       []string `json:"keys"`
+	Unique     bool     `json:"unique,omitempty"`
+	Present    bool     `json:"present"`
+}
+
+// indexes are the indexes of the fields the queries filter and sort by, apart from _id. Unique indexes
+// enforce what the game logic relies on, like one matchmaking ticket per player.
+var indexes = []struct {
+	collection string
+	keys       []string
+	unique     bool
+}{
+	{"players", []string{"player.name"}, false},
+	{"games", []string{"game.name"}, false},
+	{"games", []string{"game.invite_code"}, false},
+	{"games", []string{"game.player1.name", "game.status"}, false},
+	{"games", []string{"game.player2.name", "game.status"}, false},
+	{"lobby", []string{"ticket.player"}, true},
+	{"lobby", []string{"ticket.preset", "ticket.status", "ticket.created_at"}, false},
+	{"chat", []string{"message.game_id"}, false},
+	{"webhooks", []string{"webhook.owner"}, false},
+	{"webhook_deliveries", []string{"delivery.subscription_id"}, false},
+}
+
+// existingIndex is an index found in a collection
+type existingIndex struct {
+	name   string
+	keys   []string
+	unique bool
+}
+
+// Indexes reports which of the indexes the queries rely on exist
+func (m *MongoDB) Indexes() ([]IndexStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	existing := make(map[string][]existingIndex)
+	statuses := make([]IndexStatus, 0, len(indexes))
+	for _, index := range indexes {
+		found, ok := existing[index.collection]
+		if !ok {
+			var err error
+			if found, err = m.existingIndexes(ctx, index.collection); err != nil {
+				return nil, err
+			}
+			existing[index.collection] = found
+		}
+
+		status := IndexStatus{Collection: index.collection, Keys: index.keys, Unique: index.unique}
+		for _, e := range found {
+			if slices.Equal(e.keys, index.keys) && e.unique == index.unique {
+				status.Present = true
+				break
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CreateIndexes creates the missing indexes, existing ones are left as they are. An index on the same fields
+// which is not unique as required is replaced.
+func (m *MongoDB) CreateIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	for _, index := range indexes {
+		collection := m.client.Database(m.cfg.Name).Collection(index.collection)
+		found, err := m.existingIndexes(ctx, index.collection)
+		if err != nil {
+			return err
+		}
+		for _, e := range found {
+			if slices.Equal(e.keys, index.keys) && e.unique != index.unique {
+				if _, err := collection.Indexes().DropOne(ctx, e.name); err != nil {
+					return fmt.Errorf("error replacing index %s of %s: %w", e.name, index.collection, err)
+				}
+			}
+		}
+
+		keys := bson.D{}
+		for _, k := range index.keys {
+			keys = append(keys, bson.E{Key: k, Value: 1})
+		}
+		model := mongo.IndexModel{Keys: keys}
+		if index.unique {
+			model.Options = options.Index().SetUnique(true)
+		}
+		if _, err := collection.Indexes().CreateOne(ctx, model); err != nil {
+			return fmt.Errorf("error creating index on %s %v: %w", index.collection, index.keys, err)
+		}
+	}
+	return nil
+}
+
+// existingIndexes returns the indexes of a collection with their fields in order
+func (m *MongoDB) existingIndexes(ctx context.Context, collection string) ([]existingIndex, error) {
+	cursor, err := m.client.Database(m.cfg.Name).Collection(collection).Indexes().List(ctx)
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Name == "NamespaceNotFound" {
+		// the collection is created with its first document
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing indexes of %s: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var found []existingIndex
+	for cursor.Next(ctx) {
+		var index struct {
+			Name   string `bson:"name"`
+			Key    bson.D `bson:"key"`
+			Unique bool   `bson:"unique"`
+		}
+		if err := cursor.Decode(&index); err != nil {
+			return nil, fmt.Errorf("error decoding index of %s: %w", collection, err)
+		}
+		fields := make([]string, len(index.Key))
+		for i, e := range index.Key {
+			fields[i] = e.Key
+		}
+		found = append(found, existingIndex{name: index.Name, keys: fields, unique: index.Unique})
+	}
+	return found, cursor.Err()
+}
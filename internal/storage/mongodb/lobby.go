@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MatchTicket struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	Ticket *game.MatchTicket  `bson:"ticket"`
+}
+
+// CreateMatchTicket adds a ticket to the matchmaking queue. The unique index on the player rejects a second
+// ticket of the same player.
+func (m *MongoDB) CreateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	t := MatchTicket{
+		ID:     primitive.NewObjectID(),
+		Ticket: ticket,
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, &t)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("player %s already has a match ticket: %w", ticket.Player, game.ErrorAmbiguous)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating match ticket: %w", err)
+	}
+
+	return t.Ticket, nil
+}
+
+// FindMatchTicket retrieves the ticket of a player
+func (m *MongoDB) FindMatchTicket(playerName string) (*game.MatchTicket, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	var t MatchTicket
+	err := collection.FindOne(ctx, bson.D{primitive.E{Key: "ticket.player", Value: playerName}}).Decode(&t)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, game.ErrorNotFound
+		}
+		return nil, fmt.Errorf("error finding match ticket: %w", err)
+	}
+
+	return t.Ticket, nil
+}
+
+// QueryMatchTickets retrieves all waiting tickets for a rule preset, oldest first
+func (m *MongoDB) QueryMatchTickets(preset game.RulePreset) ([]*game.MatchTicket, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.D{
+		primitive.E{Key: "ticket.preset", Value: preset},
+		primitive.E{Key: "ticket.status", Value: game.MatchStatusWaiting},
+	}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "ticket.created_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error querying match tickets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tickets []MatchTicket
+	if err := cursor.All(ctx, &tickets); err != nil {
+		return nil, fmt.Errorf("error decoding match tickets: %w", err)
+	}
+
+	ret := make([]*game.MatchTicket, len(tickets))
+	for i, t := range tickets {
+		ret[i] = t.Ticket
+	}
+	return ret, nil
+}
+
+// ClaimMatchTicket atomically marks a waiting ticket as matched. It returns ErrorNotFound if the player
+// has no waiting ticket, e.g. because another request claimed it first.
+func (m *MongoDB) ClaimMatchTicket(playerName string) (*game.MatchTicket, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.D{
+		primitive.E{Key: "ticket.player", Value: playerName},
+		primitive.E{Key: "ticket.status", Value: game.MatchStatusWaiting},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "ticket.status", Value: game.MatchStatusMatched},
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var t MatchTicket
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&t)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, game.ErrorNotFound
+		}
+		return nil, fmt.Errorf("error claiming match ticket: %w", err)
+	}
+
+	return t.Ticket, nil
+}
+
+// UpdateMatchTicket replaces the ticket of a player
+func (m *MongoDB) UpdateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "ticket", Value: ticket},
+		}},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "ticket.player", Value: ticket.Player}}, update)
+	if err != nil {
+		return nil, fmt.Errorf("error updating match ticket: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, game.ErrorNotFound
+	}
+
+	return ticket, nil
+}
+
+// DeleteMatchTicket removes the ticket of a player from the queue
+func (m *MongoDB) DeleteMatchTicket(playerName string) error {
+	collection := m.client.Database(m.cfg.Name).Collection("lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, bson.D{primitive.E{Key: "ticket.player", Value: playerName}})
+	if err != nil {
+		return fmt.Errorf("error deleting match ticket: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return game.ErrorNotFound
+	}
+
+	return nil
+}
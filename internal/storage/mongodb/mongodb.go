@@ -3,9 +3,11 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/url"
 
 	"github.com/Jagreen1970/battleship/internal/app"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,9 +16,11 @@ type MongoDB struct {
 	client *mongo.Client
 	dbName string
 	cfg    app.DatabaseConfig
+	logger *slog.Logger
 }
 
-func NewMongoDB(cfg app.DatabaseConfig) (*MongoDB, error) {
+// NewMongoDB creates the client of the database. The commands sent to the database are logged at debug level.
+func NewMongoDB(cfg app.DatabaseConfig, logger *slog.Logger) (*MongoDB, error) {
 	// Create the connection URI
 	uri := cfg.URL
 	if cfg.User != "" && cfg.Password != "" {
@@ -39,6 +43,7 @@ func NewMongoDB(cfg app.DatabaseConfig) (*MongoDB, error) {
 
 	clientOptions := options.Client().ApplyURI(uri)
 	clientOptions.SetTimeout(cfg.Timeout)
+	clientOptions.SetMonitor(commandMonitor(logger))
 
 	client, err := mongo.NewClient(clientOptions)
 	if err != nil {
@@ -49,9 +54,24 @@ func NewMongoDB(cfg app.DatabaseConfig) (*MongoDB, error) {
 		client: client,
 		dbName: cfg.Name,
 		cfg:    cfg,
+		logger: logger,
 	}, nil
 }
 
+// commandMonitor logs the names of the commands only, their documents hold the data of the players
+func commandMonitor(logger *slog.Logger) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			logger.DebugContext(ctx, "database command", "command", e.CommandName, "database", e.DatabaseName,
+				"duration", e.Duration)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			logger.WarnContext(ctx, "database command failed", "command", e.CommandName, "database", e.DatabaseName,
+				"duration", e.Duration, "error", e.Failure)
+		},
+	}
+}
+
 func (m *MongoDB) Connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
 	defer cancel()
@@ -59,6 +79,7 @@ func (m *MongoDB) Connect() error {
 	if err := m.client.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
+	m.logger.Debug("connected to database", "database", m.dbName)
 
 	return nil
 }
@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Tournament struct {
+	ID         primitive.ObjectID     `bson:"_id"`
+	Tournament *tournament.Tournament `bson:"tournament"`
+}
+
+// CreateTournament creates a new tournament in the database
+func (m *MongoDB) CreateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	objID := primitive.NewObjectID()
+
+	doc := Tournament{
+		ID:         objID,
+		Tournament: t,
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("tournaments")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tournament: %w", err)
+	}
+
+	doc.Tournament.ID = objID.Hex()
+	return doc.Tournament, nil
+}
+
+// FindTournamentByID retrieves a tournament by its ID
+func (m *MongoDB) FindTournamentByID(id string) (*tournament.Tournament, error) {
+	tournamentID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tournament ID: %w", err)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("tournaments")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	var doc Tournament
+	err = collection.FindOne(ctx, bson.D{primitive.E{Key: "_id", Value: tournamentID}}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, game.ErrorNotFound
+		}
+		return nil, fmt.Errorf("error finding tournament: %w", err)
+	}
+
+	doc.Tournament.ID = id
+	return doc.Tournament, nil
+}
+
+// QueryTournaments retrieves a list of tournaments with pagination, newest first
+func (m *MongoDB) QueryTournaments(page int, count int) ([]*tournament.Tournament, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("tournaments")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	skip := int64(page * count)
+	if skip < 0 {
+		skip = 0
+	}
+
+	opts := options.Find().SetSkip(skip).SetLimit(int64(count))
+	opts.SetSort(bson.D{primitive.E{Key: "_id", Value: -1}})
+
+	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tournaments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Tournament
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("error decoding tournaments: %w", err)
+	}
+
+	ret := make([]*tournament.Tournament, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.Tournament
+		ret[i].ID = doc.ID.Hex()
+	}
+	return ret, nil
+}
+
+// UpdateTournament updates an existing tournament in the database
+func (m *MongoDB) UpdateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	tournamentID, err := primitive.ObjectIDFromHex(t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tournament ID: %w", err)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("tournaments")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "tournament", Value: t},
+		}},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "_id", Value: tournamentID}}, update)
+	if err != nil {
+		return nil, fmt.Errorf("error updating tournament: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, game.ErrorNotFound
+	}
+
+	return t, nil
+}
+
+// UpdateTournamentRound atomically replaces a tournament which still has the given number of rounds. It
+// returns ErrorNotFound if the tournament doesn't exist or another request has paired a round first.
+func (m *MongoDB) UpdateTournamentRound(t *tournament.Tournament, rounds int) (*tournament.Tournament, error) {
+	tournamentID, err := primitive.ObjectIDFromHex(t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tournament ID: %w", err)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("tournaments")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: tournamentID},
+		primitive.E{Key: "tournament.rounds", Value: bson.D{
+			primitive.E{Key: "$size", Value: rounds},
+		}},
+	}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "tournament", Value: t},
+		}},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("error updating tournament: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, game.ErrorNotFound
+	}
+
+	return t, nil
+}
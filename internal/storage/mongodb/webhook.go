@@ -0,0 +1,189 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Webhook struct {
+	ID      primitive.ObjectID    `bson:"_id"`
+	Webhook *webhook.Subscription `bson:"webhook"`
+}
+
+type WebhookDelivery struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	Delivery *webhook.Delivery  `bson:"delivery"`
+}
+
+// CreateWebhook stores a new webhook subscription
+func (m *MongoDB) CreateWebhook(s *webhook.Subscription) (*webhook.Subscription, error) {
+	objID := primitive.NewObjectID()
+	s.ID = objID.Hex()
+
+	doc := Webhook{
+		ID:      objID,
+		Webhook: s,
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("webhooks")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return doc.Webhook, nil
+}
+
+// FindWebhookByID retrieves a webhook subscription by its ID
+func (m *MongoDB) FindWebhookByID(id string) (*webhook.Subscription, error) {
+	webhookID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("webhooks")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	var doc Webhook
+	err = collection.FindOne(ctx, bson.D{primitive.E{Key: "_id", Value: webhookID}}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, game.ErrorNotFound
+		}
+		return nil, fmt.Errorf("error finding webhook: %w", err)
+	}
+
+	return doc.Webhook, nil
+}
+
+// QueryWebhooks retrieves the webhook subscriptions of the given owners
+func (m *MongoDB) QueryWebhooks(owners ...string) ([]*webhook.Subscription, error) {
+	if len(owners) == 0 {
+		return []*webhook.Subscription{}, nil
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("webhooks")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	filter := bson.D{
+		primitive.E{Key: "webhook.owner", Value: bson.D{
+			primitive.E{Key: "$in", Value: owners},
+		}},
+	}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Webhook
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("error decoding webhooks: %w", err)
+	}
+
+	ret := make([]*webhook.Subscription, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.Webhook
+	}
+	return ret, nil
+}
+
+// DeleteWebhook deletes a webhook subscription and its delivery log
+func (m *MongoDB) DeleteWebhook(id string) error {
+	webhookID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	db := m.client.Database(m.cfg.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	result, err := db.Collection("webhooks").DeleteOne(ctx, bson.D{primitive.E{Key: "_id", Value: webhookID}})
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return game.ErrorNotFound
+	}
+
+	_, err = db.Collection("webhook_deliveries").DeleteMany(ctx, bson.D{primitive.E{Key: "delivery.subscription_id", Value: id}})
+	if err != nil {
+		return fmt.Errorf("error deleting webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWebhookDelivery adds an entry to the delivery log
+func (m *MongoDB) CreateWebhookDelivery(d *webhook.Delivery) (*webhook.Delivery, error) {
+	objID := primitive.NewObjectID()
+	d.ID = objID.Hex()
+
+	doc := WebhookDelivery{
+		ID:       objID,
+		Delivery: d,
+	}
+
+	collection := m.client.Database(m.cfg.Name).Collection("webhook_deliveries")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	_, err := collection.InsertOne(ctx, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook delivery: %w", err)
+	}
+
+	return doc.Delivery, nil
+}
+
+// QueryWebhookDeliveries retrieves the delivery log of a webhook with pagination, newest first
+func (m *MongoDB) QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*webhook.Delivery, error) {
+	collection := m.client.Database(m.cfg.Name).Collection("webhook_deliveries")
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+	defer cancel()
+
+	skip := int64(page * count)
+	if skip < 0 {
+		skip = 0
+	}
+
+	opts := options.Find().SetSkip(skip).SetLimit(int64(count))
+	opts.SetSort(bson.D{primitive.E{Key: "_id", Value: -1}})
+
+	cursor, err := collection.Find(ctx, bson.D{primitive.E{Key: "delivery.subscription_id", Value: subscriptionID}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []WebhookDelivery
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("error decoding webhook deliveries: %w", err)
+	}
+
+	ret := make([]*webhook.Delivery, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.Delivery
+	}
+	return ret, nil
+}
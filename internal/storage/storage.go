@@ -2,18 +2,26 @@ package storage
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/Jagreen1970/battleship/internal/app"
 	"github.com/Jagreen1970/battleship/internal/game"
 	"github.com/Jagreen1970/battleship/internal/storage/mongodb"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
 )
 
+// IndexStatus tells whether an index the queries rely on exists
+type IndexStatus = mongodb.IndexStatus
+
 // Storage defines the interface for storage operations
 type Storage interface {
 	Connect() error
 	Disconnect() error
 	Ping() error
 	Close() error
+	Indexes() ([]IndexStatus, error)
+	CreateIndexes() error
 
 	CreatePlayer(playerName string) (*game.Player, error)
 	FindPlayerByName(username string) (*game.Player, error)
@@ -22,16 +30,43 @@ type Storage interface {
 	CreateGame(game *game.Game) (*game.Game, error)
 	FindGameByID(id string) (*game.Game, error)
 	FindGameByName(name string) (*game.Game, error)
+	FindGameByInviteCode(code string) (*game.Game, error)
 	UpdateGame(game *game.Game) (*game.Game, error)
+	ClaimRematch(gameID string, rematchID string) error
 	DeleteGame(id string) error
 	DeleteAllGames() (int, error)
+	CountOpenGames(playerName string) (int, error)
+	CountGamesByStatus() (map[game.Status]int, error)
+
+	CreateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error)
+	FindMatchTicket(playerName string) (*game.MatchTicket, error)
+	QueryMatchTickets(preset game.RulePreset) ([]*game.MatchTicket, error)
+	ClaimMatchTicket(playerName string) (*game.MatchTicket, error)
+	UpdateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error)
+	DeleteMatchTicket(playerName string) error
+
+	CreateChatMessage(message *game.ChatMessage) (*game.ChatMessage, error)
+	QueryChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error)
+
+	CreateTournament(t *tournament.Tournament) (*tournament.Tournament, error)
+	FindTournamentByID(id string) (*tournament.Tournament, error)
+	QueryTournaments(page int, count int) ([]*tournament.Tournament, error)
+	UpdateTournament(t *tournament.Tournament) (*tournament.Tournament, error)
+	UpdateTournamentRound(t *tournament.Tournament, rounds int) (*tournament.Tournament, error)
+
+	CreateWebhook(s *webhook.Subscription) (*webhook.Subscription, error)
+	FindWebhookByID(id string) (*webhook.Subscription, error)
+	QueryWebhooks(owners ...string) ([]*webhook.Subscription, error)
+	DeleteWebhook(id string) error
+	CreateWebhookDelivery(d *webhook.Delivery) (*webhook.Delivery, error)
+	QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*webhook.Delivery, error)
 }
 
-// New creates a new storage instance based on the configuration
-func New(cfg app.DatabaseConfig) (Storage, error) {
+// New creates a new storage instance based on the configuration, which logs to the logger
+func New(cfg app.DatabaseConfig, logger *slog.Logger) (Storage, error) {
 	switch cfg.Driver {
 	case "mongo":
-		return mongodb.NewMongoDB(cfg)
+		return mongodb.NewMongoDB(cfg, logger)
 	default:
 		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
 	}
@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/tracing"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+// Trace returns a Storage creating a span for every operation of s. The operations of a Storage bound to a
+// context with WithContext are children of the span of the context, the others start traces of their own.
+func Trace(s Storage) Storage {
+	return &traced{Storage: s, ctx: context.Background()}
+}
+
+type traced struct {
+	Storage
+	ctx context.Context
+}
+
+// WithContext binds the operations to the context, see game.ContextDatabase
+func (tr *traced) WithContext(ctx context.Context) game.Database {
+	return &traced{Storage: tr.Storage, ctx: ctx}
+}
+
+func (tr *traced) start(operation string) trace.Span {
+	_, span := tracing.Start(tr.ctx, "storage."+operation, semconv.DBOperationName(operation))
+	return span
+}
+
+func (tr *traced) Connect() error {
+	span := tr.start("Connect")
+	err := tr.Storage.Connect()
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) Disconnect() error {
+	span := tr.start("Disconnect")
+	err := tr.Storage.Disconnect()
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) Ping() error {
+	span := tr.start("Ping")
+	err := tr.Storage.Ping()
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) Close() error {
+	span := tr.start("Close")
+	err := tr.Storage.Close()
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) Indexes() ([]IndexStatus, error) {
+	span := tr.start("Indexes")
+	result, err := tr.Storage.Indexes()
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CreateIndexes() error {
+	span := tr.start("CreateIndexes")
+	err := tr.Storage.CreateIndexes()
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) CreatePlayer(playerName string) (*game.Player, error) {
+	span := tr.start("CreatePlayer")
+	result, err := tr.Storage.CreatePlayer(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindPlayerByName(username string) (*game.Player, error) {
+	span := tr.start("FindPlayerByName")
+	result, err := tr.Storage.FindPlayerByName(username)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryGames(page int, count int) ([]*game.Game, error) {
+	span := tr.start("QueryGames")
+	result, err := tr.Storage.QueryGames(page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CreateGame(game *game.Game) (*game.Game, error) {
+	span := tr.start("CreateGame")
+	result, err := tr.Storage.CreateGame(game)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindGameByID(id string) (*game.Game, error) {
+	span := tr.start("FindGameByID")
+	result, err := tr.Storage.FindGameByID(id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindGameByName(name string) (*game.Game, error) {
+	span := tr.start("FindGameByName")
+	result, err := tr.Storage.FindGameByName(name)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindGameByInviteCode(code string) (*game.Game, error) {
+	span := tr.start("FindGameByInviteCode")
+	result, err := tr.Storage.FindGameByInviteCode(code)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) UpdateGame(game *game.Game) (*game.Game, error) {
+	span := tr.start("UpdateGame")
+	result, err := tr.Storage.UpdateGame(game)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) DeleteGame(id string) error {
+	span := tr.start("DeleteGame")
+	err := tr.Storage.DeleteGame(id)
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) DeleteAllGames() (int, error) {
+	span := tr.start("DeleteAllGames")
+	result, err := tr.Storage.DeleteAllGames()
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CountOpenGames(playerName string) (int, error) {
+	span := tr.start("CountOpenGames")
+	result, err := tr.Storage.CountOpenGames(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CountGamesByStatus() (map[game.Status]int, error) {
+	span := tr.start("CountGamesByStatus")
+	result, err := tr.Storage.CountGamesByStatus()
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CreateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	span := tr.start("CreateMatchTicket")
+	result, err := tr.Storage.CreateMatchTicket(ticket)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindMatchTicket(playerName string) (*game.MatchTicket, error) {
+	span := tr.start("FindMatchTicket")
+	result, err := tr.Storage.FindMatchTicket(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryMatchTickets(preset game.RulePreset) ([]*game.MatchTicket, error) {
+	span := tr.start("QueryMatchTickets")
+	result, err := tr.Storage.QueryMatchTickets(preset)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) ClaimRematch(gameID string, rematchID string) error {
+	span := tr.start("ClaimRematch")
+	err := tr.Storage.ClaimRematch(gameID, rematchID)
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) ClaimMatchTicket(playerName string) (*game.MatchTicket, error) {
+	span := tr.start("ClaimMatchTicket")
+	result, err := tr.Storage.ClaimMatchTicket(playerName)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) UpdateMatchTicket(ticket *game.MatchTicket) (*game.MatchTicket, error) {
+	span := tr.start("UpdateMatchTicket")
+	result, err := tr.Storage.UpdateMatchTicket(ticket)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) DeleteMatchTicket(playerName string) error {
+	span := tr.start("DeleteMatchTicket")
+	err := tr.Storage.DeleteMatchTicket(playerName)
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) CreateChatMessage(message *game.ChatMessage) (*game.ChatMessage, error) {
+	span := tr.start("CreateChatMessage")
+	result, err := tr.Storage.CreateChatMessage(message)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryChatMessages(gameID string, page int, count int) ([]*game.ChatMessage, error) {
+	span := tr.start("QueryChatMessages")
+	result, err := tr.Storage.QueryChatMessages(gameID, page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CreateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	span := tr.start("CreateTournament")
+	result, err := tr.Storage.CreateTournament(t)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindTournamentByID(id string) (*tournament.Tournament, error) {
+	span := tr.start("FindTournamentByID")
+	result, err := tr.Storage.FindTournamentByID(id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryTournaments(page int, count int) ([]*tournament.Tournament, error) {
+	span := tr.start("QueryTournaments")
+	result, err := tr.Storage.QueryTournaments(page, count)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) UpdateTournament(t *tournament.Tournament) (*tournament.Tournament, error) {
+	span := tr.start("UpdateTournament")
+	result, err := tr.Storage.UpdateTournament(t)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) UpdateTournamentRound(t *tournament.Tournament, rounds int) (*tournament.Tournament, error) {
+	span := tr.start("UpdateTournamentRound")
+	result, err := tr.Storage.UpdateTournamentRound(t, rounds)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) CreateWebhook(s *webhook.Subscription) (*webhook.Subscription, error) {
+	span := tr.start("CreateWebhook")
+	result, err := tr.Storage.CreateWebhook(s)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) FindWebhookByID(id string) (*webhook.Subscription, error) {
+	span := tr.start("FindWebhookByID")
+	result, err := tr.Storage.FindWebhookByID(id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryWebhooks(owners ...string) ([]*webhook.Subscription, error) {
+	span := tr.start("QueryWebhooks")
+	result, err := tr.Storage.QueryWebhooks(owners...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) DeleteWebhook(id string) error {
+	span := tr.start("DeleteWebhook")
+	err := tr.Storage.DeleteWebhook(id)
+	tracing.End(span, err)
+	return err
+}
+
+func (tr *traced) CreateWebhookDelivery(d *webhook.Delivery) (*webhook.Delivery, error) {
+	span := tr.start("CreateWebhookDelivery")
+	result, err := tr.Storage.CreateWebhookDelivery(d)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tr *traced) QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*webhook.Delivery, error) {
+	span := tr.start("QueryWebhookDeliveries")
+	result, err := tr.Storage.QueryWebhookDeliveries(subscriptionID, page, count)
+	tracing.End(span, err)
+	return result, err
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/tournament"
+	"github.com/Jagreen1970/battleship/internal/webhook"
+)
+
+func TestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	stub := &stubStorage{}
+	db := Trace(stub)
+	api := game.NewApi(db)
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	_, err := api.WithContext(ctx).GetGame("game1")
+	require.NoError(t, err)
+	request.End()
+
+	stub.err = errors.New("timeout")
+	assert.Error(t, db.DeleteGame("game1"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "storage.FindGameByID", spans[0].Name())
+	assert.Equal(t, request.SpanContext().SpanID(), spans[0].Parent().SpanID(),
+		"operations of the API bound to a context are children of its span")
+	assert.Equal(t, "storage.DeleteGame", spans[2].Name())
+	assert.False(t, spans[2].Parent().IsValid(), "unbound operations start traces of their own")
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
+
+func (s *stubStorage) FindTournamentByID(id string) (*tournament.Tournament, error) {
+	return &tournament.Tournament{ID: id}, nil
+}
+
+func (s *stubStorage) QueryWebhooks(owners ...string) ([]*webhook.Subscription, error) {
+	return nil, nil
+}
+
+func TestTraceTournamentsAndWebhooks(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	db := Trace(&stubStorage{})
+	api := game.NewApi(db)
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	_, err := tournament.NewApi(db, api).WithContext(ctx).Get("tournament1")
+	require.NoError(t, err)
+	_, err = webhook.NewApi(db, api).WithContext(ctx).Webhooks("alice")
+	require.NoError(t, err)
+	request.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, request.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+}
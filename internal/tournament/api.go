@@ -0,0 +1,291 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// Database is the storage a tournament API needs
+type Database interface {
+	CreateTournament(t *Tournament) (*Tournament, error)
+	FindTournamentByID(id string) (*Tournament, error)
+	QueryTournaments(page int, count int) ([]*Tournament, error)
+	UpdateTournament(t *Tournament) (*Tournament, error)
+	// UpdateTournamentRound replaces a tournament which still has the given number of rounds. It returns
+	// ErrorNotFound if there is no such tournament, e.g. because another request paired a round first.
+	UpdateTournamentRound(t *Tournament, rounds int) (*Tournament, error)
+}
+
+// GameAPI is the part of game.API used to create and follow the games of a tournament
+type GameAPI interface {
+	GetPlayer(playerName string) (*game.Player, error)
+	GetGame(id string) (*game.Game, error)
+	NewGameBetween(player1 string, player2 string, name string) (*game.Game, error)
+	DeleteGame(id string) error
+}
+
+const minPlayers = 2
+
+type API struct {
+	db    Database
+	games GameAPI
+}
+
+func NewApi(db Database, games GameAPI) *API {
+	return &API{
+		db:    db,
+		games: games,
+	}
+}
+
+// WithContext returns the API with its database operations and game API calls bound to the context, if they
+// support it, like game.API.WithContext
+func (A *API) WithContext(ctx context.Context) *API {
+	bound := *A
+	if db, ok := A.db.(game.ContextDatabase); ok {
+		if db, ok := db.WithContext(ctx).(Database); ok {
+			bound.db = db
+		}
+	}
+	if games, ok := A.games.(interface {
+		WithContext(ctx context.Context) *game.API
+	}); ok {
+		bound.games = games.WithContext(ctx)
+	}
+	return &bound
+}
+
+// Create opens the registration for a new tournament
+func (A *API) Create(name string, format Format, organizer string) (*Tournament, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tournament name cannot be empty: %w", game.ErrorInvalidInput)
+	}
+
+	if format != FormatRoundRobin && format != FormatSingleElimination {
+		return nil, fmt.Errorf("unknown tournament format %q: %w", format, game.ErrorInvalidInput)
+	}
+
+	if _, err := A.games.GetPlayer(organizer); err != nil {
+		return nil, err
+	}
+
+	return A.db.CreateTournament(NewTournament(name, format, organizer))
+}
+
+func (A *API) Get(id string) (*Tournament, error) {
+	return A.db.FindTournamentByID(id)
+}
+
+func (A *API) Tournaments(page int, count int) ([]*Tournament, error) {
+	return A.db.QueryTournaments(page, count)
+}
+
+// Join registers a player for a tournament that has not started yet
+func (A *API) Join(id string, playerName string) (*Tournament, error) {
+	t, err := A.db.FindTournamentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status != StatusRegistration {
+		return nil, fmt.Errorf("registration for %q is closed: %w", t.Name, game.ErrorIllegal)
+	}
+
+	if t.HasPlayer(playerName) {
+		return nil, fmt.Errorf("you already joined %q, %s: %w", t.Name, playerName, game.ErrorIllegal)
+	}
+
+	if _, err := A.games.GetPlayer(playerName); err != nil {
+		return nil, err
+	}
+
+	t.Players = append(t.Players, playerName)
+	return A.db.UpdateTournament(t)
+}
+
+// Start closes the registration and creates the games of the first round. Only the organizer may start.
+func (A *API) Start(id string, playerName string) (*Tournament, error) {
+	t, err := A.db.FindTournamentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Organizer != playerName {
+		return nil, fmt.Errorf("only the organizer can start %q: %w", t.Name, game.ErrorIllegal)
+	}
+
+	if t.Status != StatusRegistration {
+		return nil, fmt.Errorf("%q has already started: %w", t.Name, game.ErrorInvalid)
+	}
+
+	if len(t.Players) < minPlayers {
+		return nil, fmt.Errorf("%q needs at least %d players: %w", t.Name, minPlayers, game.ErrorNotReady)
+	}
+
+	t.Status = StatusRunning
+	if err := A.nextRound(t); err != nil {
+		return nil, err
+	}
+
+	return A.storeRound(t, 0)
+}
+
+// Advance collects the results of the current round from its games. When all games are decided, the next
+// round is paired or, after the last round, the tournament is finished. Results collected so far are kept
+// even if the round is not complete yet, which is reported as ErrorNotReady. Only the organizer and the
+// players of the tournament may advance it.
+func (A *API) Advance(id string, playerName string) (*Tournament, error) {
+	t, err := A.db.FindTournamentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Organizer != playerName && !t.HasPlayer(playerName) {
+		return nil, fmt.Errorf("only the organizer and the players can advance %q: %w", t.Name, game.ErrorIllegal)
+	}
+
+	if t.Status != StatusRunning {
+		return nil, fmt.Errorf("%q is not running: %w", t.Name, game.ErrorNotReady)
+	}
+
+	rounds := len(t.Rounds)
+	pending, err := A.collectResults(t.CurrentRound())
+	if err != nil {
+		return nil, err
+	}
+
+	if pending > 0 {
+		if _, err := A.storeRound(t, rounds); err != nil {
+			return nil, err
+		}
+		return t, fmt.Errorf("%d game(s) of round %d are still being played: %w", pending, t.CurrentRound().Number, game.ErrorNotReady)
+	}
+
+	if A.finalRound(t) {
+		t.Status = StatusFinished
+		t.Champion = A.champion(t)
+	} else if err := A.nextRound(t); err != nil {
+		return nil, err
+	}
+
+	return A.storeRound(t, rounds)
+}
+
+// storeRound stores the tournament if no other request has paired a round since it had the given number of
+// rounds. Otherwise the games of the round paired by this request are deleted again.
+func (A *API) storeRound(t *Tournament, rounds int) (*Tournament, error) {
+	stored, err := A.db.UpdateTournamentRound(t, rounds)
+	if err == nil {
+		return stored, nil
+	}
+
+	if errors.Is(err, game.ErrorNotFound) {
+		err = fmt.Errorf("%q was changed by another request, try again: %w", t.Name, game.ErrorNotReady)
+	}
+	if len(t.Rounds) > rounds {
+		if deleteErr := A.deleteGames(t.CurrentRound()); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+	}
+	return nil, err
+}
+
+// deleteGames deletes the games created for the pairings of a round
+func (A *API) deleteGames(round *Round) error {
+	var errs []error
+	for _, p := range round.Pairings {
+		if p.GameID == "" {
+			continue
+		}
+		if err := A.games.DeleteGame(p.GameID); err != nil {
+			errs = append(errs, fmt.Errorf("could not delete game %s: %w", p.GameID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// collectResults records the winners of all finished games of a round and returns the number of games
+// which are still being played
+func (A *API) collectResults(round *Round) (int, error) {
+	pending := 0
+	for _, p := range round.Pairings {
+		if p.Decided() {
+			continue
+		}
+
+		g, err := A.games.GetGame(p.GameID)
+		if err != nil {
+			return 0, err
+		}
+
+		if !g.Finished() {
+			pending++
+			continue
+		}
+
+		p.Winner = g.Winner()
+		p.Shots = 0
+		for _, move := range g.History {
+			if move.Player == p.Winner {
+				p.Shots++
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+func (A *API) finalRound(t *Tournament) bool {
+	switch t.Format {
+	case FormatRoundRobin:
+		return len(t.Rounds) >= roundRobinRounds(len(t.Players))
+	default:
+		return len(t.CurrentRound().Winners()) < minPlayers
+	}
+}
+
+func (A *API) champion(t *Tournament) string {
+	if t.Format == FormatSingleElimination {
+		return t.CurrentRound().Winners()[0]
+	}
+	return t.Standings()[0].Player
+}
+
+// nextRound pairs the next round and creates a game for every pairing that is not a bye. If a game cannot be
+// created, the games created before it are deleted again.
+func (A *API) nextRound(t *Tournament) error {
+	var pairings []*Pairing
+	switch {
+	case t.Format == FormatRoundRobin:
+		pairings = roundRobinPairings(t.Players, len(t.Rounds))
+	case len(t.Rounds) == 0:
+		pairings = eliminationPairings(t.Players)
+	default:
+		pairings = eliminationPairings(t.CurrentRound().Winners())
+	}
+
+	round := &Round{
+		Number:   len(t.Rounds) + 1,
+		Pairings: pairings,
+	}
+
+	for _, p := range round.Pairings {
+		if p.Bye() {
+			continue
+		}
+
+		name := fmt.Sprintf("%s round %d: %s vs %s", t.Name, round.Number, p.Player1, p.Player2)
+		g, err := A.games.NewGameBetween(p.Player1, p.Player2, name)
+		if err != nil {
+			err = fmt.Errorf("could not create game for %s vs %s: %w", p.Player1, p.Player2, err)
+			return errors.Join(err, A.deleteGames(round))
+		}
+		p.GameID = g.ID
+	}
+
+	t.Rounds = append(t.Rounds, round)
+	return nil
+}
@@ -0,0 +1,228 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundRobinTournament(t *testing.T) {
+	games, gamesDB := gametest.NewAPI(t, "alice", "bob", "carol")
+	api := NewApi(newMockDatabase(), games)
+
+	tournament, err := api.Create("office cup", FormatRoundRobin, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, StatusRegistration, tournament.Status)
+
+	_, err = api.Start(tournament.ID, "alice")
+	assert.ErrorIs(t, err, game.ErrorNotReady, "not enough players")
+
+	for _, player := range []string{"alice", "bob", "carol"} {
+		_, err = api.Join(tournament.ID, player)
+		require.NoError(t, err)
+	}
+
+	_, err = api.Join(tournament.ID, "alice")
+	assert.ErrorIs(t, err, game.ErrorIllegal)
+
+	_, err = api.Start(tournament.ID, "bob")
+	assert.ErrorIs(t, err, game.ErrorIllegal, "only the organizer can start")
+
+	tournament, err = api.Start(tournament.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, tournament.Status)
+
+	_, err = api.Join(tournament.ID, "dave")
+	assert.ErrorIs(t, err, game.ErrorIllegal, "registration is closed")
+
+	// Three players play three rounds with one game and one bye each
+	for round := 1; round <= 3; round++ {
+		current := tournament.CurrentRound()
+		require.Equal(t, round, current.Number)
+
+		_, err = api.Advance(tournament.ID, "bob")
+		assert.ErrorIs(t, err, game.ErrorNotReady, "the game of round %d is not over", round)
+
+		for _, p := range current.Pairings {
+			if !p.Bye() {
+				finish(t, gamesDB, p, p.Player1, 40+round)
+			}
+		}
+
+		tournament, err = api.Advance(tournament.ID, "bob")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, StatusFinished, tournament.Status)
+	assert.Len(t, tournament.Rounds, 3)
+	assert.NotEmpty(t, tournament.Champion)
+	assert.Equal(t, tournament.Standings()[0].Player, tournament.Champion)
+
+	_, err = api.Advance(tournament.ID, "bob")
+	assert.ErrorIs(t, err, game.ErrorNotReady)
+}
+
+func TestSingleEliminationTournament(t *testing.T) {
+	games, gamesDB := gametest.NewAPI(t, "a", "b", "c", "d", "e")
+	api := NewApi(newMockDatabase(), games)
+
+	tournament, err := api.Create("knockout", FormatSingleElimination, "a")
+	require.NoError(t, err)
+	for _, player := range []string{"a", "b", "c", "d", "e"} {
+		_, err = api.Join(tournament.ID, player)
+		require.NoError(t, err)
+	}
+
+	tournament, err = api.Start(tournament.ID, "a")
+	require.NoError(t, err)
+
+	// Round 1: a-b, c-d, e has a bye
+	round := tournament.CurrentRound()
+	require.Len(t, round.Pairings, 3)
+	finish(t, gamesDB, round.Pairings[0], "b", 50)
+	finish(t, gamesDB, round.Pairings[1], "c", 50)
+
+	tournament, err = api.Advance(tournament.ID, "a")
+	require.NoError(t, err)
+
+	// Round 2: b-c, e has a bye
+	round = tournament.CurrentRound()
+	require.Equal(t, 2, round.Number)
+	require.Len(t, round.Pairings, 2)
+	assert.Equal(t, "b", round.Pairings[0].Player1)
+	assert.Equal(t, "c", round.Pairings[0].Player2)
+	assert.True(t, round.Pairings[1].Bye())
+	finish(t, gamesDB, round.Pairings[0], "c", 45)
+
+	tournament, err = api.Advance(tournament.ID, "a")
+	require.NoError(t, err)
+
+	// Final: c-e
+	round = tournament.CurrentRound()
+	require.Equal(t, 3, round.Number)
+	require.Len(t, round.Pairings, 1)
+	finish(t, gamesDB, round.Pairings[0], "e", 35)
+
+	tournament, err = api.Advance(tournament.ID, "a")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFinished, tournament.Status)
+	assert.Equal(t, "e", tournament.Champion)
+	assert.Equal(t, 35, tournament.CurrentRound().Pairings[0].Shots)
+}
+
+func TestCreateTournamentInvalid(t *testing.T) {
+	games, _ := gametest.NewAPI(t, "alice")
+	api := NewApi(newMockDatabase(), games)
+
+	_, err := api.Create("", FormatRoundRobin, "alice")
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+
+	_, err = api.Create("cup", "swiss", "alice")
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+
+	_, err = api.Create("cup", FormatRoundRobin, "nobody")
+	assert.ErrorIs(t, err, game.ErrorNotFound)
+}
+
+func TestAdvanceTournamentOnlyByItsPlayers(t *testing.T) {
+	games, _ := gametest.NewAPI(t, "alice", "bob", "carol")
+	api := NewApi(newMockDatabase(), games)
+
+	tournament, err := api.Create("cup", FormatRoundRobin, "carol")
+	require.NoError(t, err)
+	for _, player := range []string{"alice", "bob"} {
+		_, err = api.Join(tournament.ID, player)
+		require.NoError(t, err)
+	}
+	_, err = api.Start(tournament.ID, "carol")
+	require.NoError(t, err)
+
+	_, err = api.Advance(tournament.ID, "mallory")
+	assert.ErrorIs(t, err, game.ErrorIllegal)
+
+	_, err = api.Advance(tournament.ID, "carol")
+	assert.ErrorIs(t, err, game.ErrorNotReady, "the organizer may advance")
+	_, err = api.Advance(tournament.ID, "alice")
+	assert.ErrorIs(t, err, game.ErrorNotReady, "a player may advance")
+}
+
+func TestStartTournamentDeletesGamesOnError(t *testing.T) {
+	games, gamesDB := gametest.NewAPI(t, "a", "b", "c", "d", "e")
+	_, err := games.NewGameBetween("c", "e", "friendly")
+	require.NoError(t, err)
+	games.MaxOpenGames = 1
+	db := newMockDatabase()
+	api := NewApi(db, games)
+
+	tournament, err := api.Create("knockout", FormatSingleElimination, "a")
+	require.NoError(t, err)
+	for _, player := range []string{"a", "b", "c", "d"} {
+		_, err = api.Join(tournament.ID, player)
+		require.NoError(t, err)
+	}
+
+	// a-b is created before c-d fails
+	_, err = api.Start(tournament.ID, "a")
+	assert.ErrorIs(t, err, game.ErrorLimitExceeded)
+	open, err := gamesDB.CountOpenGames("a")
+	require.NoError(t, err)
+	assert.Zero(t, open, "the game of a-b is deleted again")
+	assert.Equal(t, StatusRegistration, db.tournaments[tournament.ID].Status)
+}
+
+// racingDatabase runs another request right before the first update of a round is stored
+type racingDatabase struct {
+	*mockDatabase
+	race func()
+}
+
+func (r *racingDatabase) UpdateTournamentRound(t *Tournament, rounds int) (*Tournament, error) {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.mockDatabase.UpdateTournamentRound(t, rounds)
+}
+
+func TestConcurrentAdvanceTournament(t *testing.T) {
+	games, gamesDB := gametest.NewAPI(t, "alice", "bob", "carol")
+	db := &racingDatabase{mockDatabase: newMockDatabase()}
+	api := NewApi(db, games)
+
+	tournament, err := api.Create("cup", FormatRoundRobin, "alice")
+	require.NoError(t, err)
+	for _, player := range []string{"alice", "bob", "carol"} {
+		_, err = api.Join(tournament.ID, player)
+		require.NoError(t, err)
+	}
+	tournament, err = api.Start(tournament.ID, "alice")
+	require.NoError(t, err)
+	for _, p := range tournament.CurrentRound().Pairings {
+		if !p.Bye() {
+			finish(t, gamesDB, p, p.Player1, 40)
+		}
+	}
+
+	var raced error
+	db.race = func() {
+		_, raced = api.Advance(tournament.ID, "bob")
+	}
+	_, err = api.Advance(tournament.ID, "alice")
+	assert.ErrorIs(t, err, game.ErrorNotReady, "the round was paired by the other request")
+	require.NoError(t, raced)
+
+	stored := db.tournaments[tournament.ID]
+	assert.Len(t, stored.Rounds, 2, "only one second round is paired")
+	counts, err := gamesDB.CountGamesByStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[game.StatusSetup], "the game of the second round that was not stored is deleted")
+	for _, p := range stored.CurrentRound().Pairings {
+		if !p.Bye() {
+			_, err := gamesDB.FindGameByID(p.GameID)
+			assert.NoError(t, err)
+		}
+	}
+}
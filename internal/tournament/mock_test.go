@@ -0,0 +1,86 @@
+package tournament
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+// mockDatabase is an in-memory implementation of the Database interface for testing
+type mockDatabase struct {
+	tournaments map[string]*Tournament
+}
+
+func newMockDatabase() *mockDatabase {
+	return &mockDatabase{
+		tournaments: make(map[string]*Tournament),
+	}
+}
+
+func (m *mockDatabase) CreateTournament(t *Tournament) (*Tournament, error) {
+	t.ID = fmt.Sprintf("tournament%d", len(m.tournaments)+1)
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+// FindTournamentByID returns a copy, so that changes to the tournament are only stored by an update, like
+// with a real database
+func (m *mockDatabase) FindTournamentByID(id string) (*Tournament, error) {
+	t, ok := m.tournaments[id]
+	if !ok {
+		return nil, game.ErrorNotFound
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var copied Tournament
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+func (m *mockDatabase) QueryTournaments(_ int, _ int) ([]*Tournament, error) {
+	var tournaments []*Tournament
+	for _, t := range m.tournaments {
+		tournaments = append(tournaments, t)
+	}
+	return tournaments, nil
+}
+
+func (m *mockDatabase) UpdateTournament(t *Tournament) (*Tournament, error) {
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+func (m *mockDatabase) UpdateTournamentRound(t *Tournament, rounds int) (*Tournament, error) {
+	stored, ok := m.tournaments[t.ID]
+	if !ok || len(stored.Rounds) != rounds {
+		return nil, game.ErrorNotFound
+	}
+	m.tournaments[t.ID] = t
+	return t, nil
+}
+
+// finish ends the game of a pairing with the given winner, who needed the given number of shots
+func finish(t *testing.T, db *gametest.Database, p *Pairing, winner string, shots int) {
+	g, err := db.FindGameByID(p.GameID)
+	require.NoError(t, err)
+	for range shots {
+		g.History = append(g.History, game.Move{Player: winner})
+	}
+
+	g.Status = game.StatusWon
+	if winner == g.Player2.Name {
+		g.Status = game.StatusLost
+	}
+	_, err = db.UpdateGame(g)
+	require.NoError(t, err)
+}
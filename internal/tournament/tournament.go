@@ -0,0 +1,250 @@
+package tournament
+
+import (
+	"sort"
+	"time"
+)
+
+type Format string
+
+const (
+	// FormatRoundRobin lets every player play every other player once
+	FormatRoundRobin Format = "round-robin"
+	// FormatSingleElimination knocks out the loser of every game until one player is left
+	FormatSingleElimination Format = "single-elimination"
+)
+
+type Status string
+
+const (
+	StatusRegistration Status = "registration"
+	StatusRunning      Status = "running"
+	StatusFinished     Status = "finished"
+)
+
+// Pairing is a single game of a round. A pairing without a second player is a bye, which counts as a win.
+type Pairing struct {
+	Player1 string `json:"player_1" bson:"player1"`
+	Player2 string `json:"player_2,omitempty" bson:"player2,omitempty"`
+	GameID  string `json:"game_id,omitempty" bson:"game_id,omitempty"`
+	Winner  string `json:"winner,omitempty" bson:"winner,omitempty"`
+	// Shots is the number of shots the winner needed
+	Shots int `json:"shots" bson:"shots"`
+}
+
+func (p *Pairing) Bye() bool {
+	return p.Player2 == ""
+}
+
+func (p *Pairing) Decided() bool {
+	return p.Winner != ""
+}
+
+// Loser returns the player who lost the pairing, or an empty string for byes and undecided pairings
+func (p *Pairing) Loser() string {
+	switch {
+	case !p.Decided() || p.Bye():
+		return ""
+	case p.Winner == p.Player1:
+		return p.Player2
+	default:
+		return p.Player1
+	}
+}
+
+type Round struct {
+	Number   int        `json:"number" bson:"number"`
+	Pairings []*Pairing `json:"pairings" bson:"pairings"`
+}
+
+// Complete reports whether all games of the round have a winner
+func (r *Round) Complete() bool {
+	for _, p := range r.Pairings {
+		if !p.Decided() {
+			return false
+		}
+	}
+	return true
+}
+
+// Winners returns the winners of the round in bracket order
+func (r *Round) Winners() []string {
+	winners := make([]string, 0, len(r.Pairings))
+	for _, p := range r.Pairings {
+		if p.Decided() {
+			winners = append(winners, p.Winner)
+		}
+	}
+	return winners
+}
+
+type Tournament struct {
+	ID        string    `json:"_id,omitempty" bson:"_id,omitempty"`
+	Name      string    `json:"name" bson:"name"`
+	Format    Format    `json:"format" bson:"format"`
+	Status    Status    `json:"status" bson:"status"`
+	Organizer string    `json:"organizer" bson:"organizer"`
+	Players   []string  `json:"players" bson:"players"`
+	Rounds    []*Round  `json:"rounds" bson:"rounds"`
+	Champion  string    `json:"champion,omitempty" bson:"champion,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+func NewTournament(name string, format Format, organizer string) *Tournament {
+	return &Tournament{
+		Name:      name,
+		Format:    format,
+		Status:    StatusRegistration,
+		Organizer: organizer,
+		Players:   make([]string, 0),
+		Rounds:    make([]*Round, 0),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// CurrentRound returns the round being played, or nil before the tournament has started
+func (t *Tournament) CurrentRound() *Round {
+	if len(t.Rounds) == 0 {
+		return nil
+	}
+	return t.Rounds[len(t.Rounds)-1]
+}
+
+// HasPlayer reports whether a player is registered for the tournament
+func (t *Tournament) HasPlayer(playerName string) bool {
+	for _, p := range t.Players {
+		if p == playerName {
+			return true
+		}
+	}
+	return false
+}
+
+// Standing is a player's record in the tournament
+type Standing struct {
+	Player string `json:"player"`
+	Played int    `json:"played"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Byes   int    `json:"byes"`
+	Points int    `json:"points"`
+	// SonnebornBerger is the sum of the points of all opponents the player has beaten
+	SonnebornBerger int `json:"sonneborn_berger"`
+	// Shots is the number of shots the player needed for all wins
+	Shots int `json:"shots"`
+}
+
+// Standings ranks the players by points. Ties are broken by the Sonneborn-Berger score, then by fewer shots
+// needed to win, and finally by name to keep the order stable.
+func (t *Tournament) Standings() []Standing {
+	records := make(map[string]*Standing, len(t.Players))
+	for _, name := range t.Players {
+		records[name] = &Standing{Player: name}
+	}
+
+	for _, round := range t.Rounds {
+		for _, p := range round.Pairings {
+			if !p.Decided() {
+				continue
+			}
+
+			winner := records[p.Winner]
+			winner.Points++
+			if p.Bye() {
+				winner.Byes++
+				continue
+			}
+
+			loser := records[p.Loser()]
+			winner.Played++
+			winner.Wins++
+			winner.Shots += p.Shots
+			loser.Played++
+			loser.Losses++
+		}
+	}
+
+	for _, round := range t.Rounds {
+		for _, p := range round.Pairings {
+			if p.Decided() && !p.Bye() {
+				records[p.Winner].SonnebornBerger += records[p.Loser()].Points
+			}
+		}
+	}
+
+	standings := make([]Standing, 0, len(records))
+	for _, name := range t.Players {
+		standings = append(standings, *records[name])
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.SonnebornBerger != b.SonnebornBerger {
+			return a.SonnebornBerger > b.SonnebornBerger
+		}
+		if a.Shots != b.Shots {
+			return a.Shots < b.Shots
+		}
+		return a.Player < b.Player
+	})
+
+	return standings
+}
+
+// roundRobinRounds returns the number of rounds needed for every player to meet every other player
+func roundRobinRounds(players int) int {
+	if players%2 == 1 {
+		return players
+	}
+	return players - 1
+}
+
+// roundRobinPairings schedules the given round (0-based) with the circle method: the first player stays in
+// place while all others rotate by one seat per round. With an odd number of players, one player has a bye.
+func roundRobinPairings(players []string, round int) []*Pairing {
+	seats := append([]string{}, players...)
+	if len(seats)%2 == 1 {
+		seats = append(seats, "")
+	}
+
+	n := len(seats)
+	rotated := make([]string, n)
+	rotated[0] = seats[0]
+	for i := 1; i < n; i++ {
+		rotated[1+(i-1+round)%(n-1)] = seats[i]
+	}
+
+	pairings := make([]*Pairing, 0, n/2)
+	for i := 0; i < n/2; i++ {
+		pairings = append(pairings, newPairing(rotated[i], rotated[n-1-i]))
+	}
+	return pairings
+}
+
+// eliminationPairings pairs neighbours in bracket order. With an odd number of players, the last one has a bye.
+func eliminationPairings(players []string) []*Pairing {
+	pairings := make([]*Pairing, 0, (len(players)+1)/2)
+	for i := 0; i < len(players); i += 2 {
+		opponent := ""
+		if i+1 < len(players) {
+			opponent = players[i+1]
+		}
+		pairings = append(pairings, newPairing(players[i], opponent))
+	}
+	return pairings
+}
+
+func newPairing(player1, player2 string) *Pairing {
+	if player1 == "" {
+		player1, player2 = player2, player1
+	}
+
+	p := &Pairing{Player1: player1, Player2: player2}
+	if p.Bye() {
+		p.Winner = player1
+	}
+	return p
+}
@@ -0,0 +1,91 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundRobinPairings(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 6} {
+		t.Run(fmt.Sprintf("%d players", n), func(t *testing.T) {
+			players := make([]string, n)
+			for i := range players {
+				players[i] = fmt.Sprintf("player%d", i+1)
+			}
+
+			met := make(map[string]int)
+			byes := make(map[string]int)
+			for round := range roundRobinRounds(n) {
+				seen := make(map[string]bool)
+				for _, p := range roundRobinPairings(players, round) {
+					assert.False(t, seen[p.Player1], "%s plays twice in round %d", p.Player1, round)
+					seen[p.Player1] = true
+					if p.Bye() {
+						byes[p.Player1]++
+						continue
+					}
+					assert.False(t, seen[p.Player2], "%s plays twice in round %d", p.Player2, round)
+					seen[p.Player2] = true
+					met[p.Player1+"-"+p.Player2]++
+					met[p.Player2+"-"+p.Player1]++
+				}
+			}
+
+			// everybody meets everybody else exactly once
+			for _, a := range players {
+				for _, b := range players {
+					if a != b {
+						assert.Equal(t, 1, met[a+"-"+b], "%s vs %s", a, b)
+					}
+				}
+				if n%2 == 1 {
+					assert.Equal(t, 1, byes[a], "byes of %s", a)
+				}
+			}
+		})
+	}
+}
+
+func TestEliminationPairings(t *testing.T) {
+	pairings := eliminationPairings([]string{"a", "b", "c", "d", "e"})
+	assert.Len(t, pairings, 3)
+	assert.Equal(t, "a", pairings[0].Player1)
+	assert.Equal(t, "b", pairings[0].Player2)
+	assert.True(t, pairings[2].Bye())
+	assert.Equal(t, "e", pairings[2].Winner)
+}
+
+func TestStandings(t *testing.T) {
+	tournament := NewTournament("cup", FormatRoundRobin, "a")
+	tournament.Players = []string{"a", "b", "c", "d"}
+	tournament.Rounds = []*Round{
+		{Number: 1, Pairings: []*Pairing{
+			{Player1: "a", Player2: "b", Winner: "a", Shots: 50},
+			{Player1: "c", Player2: "d", Winner: "c", Shots: 40},
+		}},
+		{Number: 2, Pairings: []*Pairing{
+			{Player1: "a", Player2: "c", Winner: "c", Shots: 45},
+			{Player1: "b", Player2: "d", Winner: "b", Shots: 60},
+		}},
+		{Number: 3, Pairings: []*Pairing{
+			{Player1: "a", Player2: "d", Winner: "a", Shots: 30},
+			{Player1: "b", Player2: "c", Winner: "b", Shots: 70},
+		}},
+	}
+
+	standings := tournament.Standings()
+	names := make([]string, len(standings))
+	for i, s := range standings {
+		names[i] = s.Player
+	}
+
+	// a, b and c have 2 points each. a beat b (2) and d (0), b beat d (0) and c (2), c beat d (0) and a (2).
+	// All have a Sonneborn-Berger score of 2, so fewer shots decide: a 80, c 85, b 130.
+	assert.Equal(t, []string{"a", "c", "b", "d"}, names)
+	assert.Equal(t, 2, standings[0].Points)
+	assert.Equal(t, 2, standings[0].SonnebornBerger)
+	assert.Equal(t, 80, standings[0].Shots)
+	assert.Equal(t, 3, standings[3].Losses)
+}
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Jagreen1970/battleship/internal/app"
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// record installs a tracer provider recording the ended spans for the test
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	return recorder
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := record(t)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/api/games/:id", func(c *gin.Context) {
+		_, span := Start(c.Request.Context(), "game.API.GetGame")
+		End(span, game.ErrorNotFound)
+		c.Status(http.StatusNotFound)
+	})
+	engine.GET("/api/fail", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/games/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	call, request := spans[0], spans[1]
+	assert.Equal(t, "game.API.GetGame", call.Name())
+	assert.Equal(t, codes.Unset, call.Status().Code, "not found is no failure")
+	assert.Equal(t, request.SpanContext().SpanID(), call.Parent().SpanID())
+	assert.Equal(t, "GET /api/games/:id", request.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", request.SpanContext().TraceID().String(),
+		"the trace of the caller is continued")
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/fail", nil))
+	spans = recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.False(t, spans[2].Parent().IsValid())
+}
+
+func TestEnd(t *testing.T) {
+	recorder := record(t)
+	_, span := Start(context.Background(), "storage.UpdateGame")
+	End(span, errors.New("timeout"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "timeout", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestSetupFileExporter(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	propagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	file := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), app.TracingConfig{
+		Exporter:    file,
+		SampleRatio: 1,
+		ServiceName: "battleship-test",
+	})
+	require.NoError(t, err)
+
+	_, span := Start(context.Background(), "storage.FindGameByID")
+	End(span, nil)
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"storage.FindGameByID"`)
+	assert.Contains(t, string(data), "battleship-test")
+}
+
+func TestSetupWithoutExporter(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagator) })
+
+	shutdown, err := Setup(context.Background(), app.TracingConfig{Exporter: app.TracingExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), app.TracingConfig{Exporter: filepath.Join(t.TempDir(), "missing", "traces.json")})
+	assert.ErrorContains(t, err, "could not open trace file")
+}
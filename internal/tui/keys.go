@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"bufio"
+	"io"
+)
+
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyRotate
+	keyNextShip
+	keyRemove
+	keyStart
+	keyQuit
+)
+
+// readKeys decodes the input into keys until it ends, then closes the channel
+func readKeys(in io.Reader, keys chan<- key) {
+	defer close(keys)
+
+	r := bufio.NewReader(in)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		if k := decodeKey(b, r); k != keyNone {
+			keys <- k
+		}
+	}
+}
+
+func decodeKey(b byte, r *bufio.Reader) key {
+	switch b {
+	case '\033':
+		return decodeEscape(r)
+	case 'k':
+		return keyUp
+	case 'j':
+		return keyDown
+	case 'h':
+		return keyLeft
+	case 'l':
+		return keyRight
+	case '\r', '\n', ' ', 'f':
+		return keyEnter
+	case 'r':
+		return keyRotate
+	case '\t', 'n':
+		return keyNextShip
+	case 'x', '\b', 127:
+		return keyRemove
+	case 's':
+		return keyStart
+	case 'q', 3: // 3 is Ctrl-C in raw mode
+		return keyQuit
+	default:
+		return keyNone
+	}
+}
+
+// decodeEscape decodes the arrow keys, which terminals send as ESC [ A to ESC [ D (or ESC O A in
+// application mode)
+func decodeEscape(r *bufio.Reader) key {
+	prefix, err := r.ReadByte()
+	if err != nil || (prefix != '[' && prefix != 'O') {
+		return keyNone
+	}
+
+	code, err := r.ReadByte()
+	if err != nil {
+		return keyNone
+	}
+
+	switch code {
+	case 'A':
+		return keyUp
+	case 'B':
+		return keyDown
+	case 'C':
+		return keyRight
+	case 'D':
+		return keyLeft
+	default:
+		return keyNone
+	}
+}
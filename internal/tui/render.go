@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// logSize is the number of moves shown in the move log
+const logSize = 8
+
+// gridWidth is the visible width of a rendered grid: the row labels and ten fields of two characters
+const gridWidth = 22
+
+// render draws the screen: the player's fleet next to the opponent's waters, the move log and the status bar.
+// Lines end with \r\n, as the terminal doesn't translate \n in raw mode.
+func (u *UI) render() {
+	var s strings.Builder
+	s.WriteString(clearScreen)
+
+	fmt.Fprintf(&s, "%sBattleship%s - %s\r\n\r\n", bold, reset, u.title())
+
+	fleet := grid(u.fleetField)
+	target := grid(u.targetField)
+	fmt.Fprintf(&s, "%-*s    %s\r\n", gridWidth, "Your fleet", u.targetTitle())
+	for i := range fleet {
+		fmt.Fprintf(&s, "%s    %s\r\n", fleet[i], target[i])
+	}
+	s.WriteString("\r\nO ship  X hit  - miss  . water\r\n")
+
+	s.WriteString("\r\nMoves\r\n")
+	for _, line := range u.moveLog() {
+		fmt.Fprintf(&s, "  %s\r\n", line)
+	}
+
+	s.WriteString("\r\n")
+	if u.message != "" {
+		fmt.Fprintf(&s, "%s\r\n", u.message)
+	}
+	fmt.Fprintf(&s, "%s %s %s\r\n", reverse, u.status(), reset)
+	fmt.Fprintf(&s, "%s\r\n", u.keyHelp())
+
+	fmt.Fprint(u.out, s.String())
+}
+
+func (u *UI) title() string {
+	name := u.game.ID
+	if u.game.Name != "" {
+		name = u.game.Name
+	}
+	if !u.joined() {
+		return fmt.Sprintf("%s - game %s", u.player, name)
+	}
+	return fmt.Sprintf("%s vs %s - game %s", u.player, u.opponent(), name)
+}
+
+func (u *UI) targetTitle() string {
+	if !u.joined() {
+		return "Opponent"
+	}
+	return fmt.Sprintf("%s's waters", u.opponent())
+}
+
+// grid renders the column header and the rows of a map, field returns how a field is shown
+func grid(field func(x, y int) string) []string {
+	lines := make([]string, 0, 11)
+
+	header := " "
+	for x := 0; x < 10; x++ {
+		header += " " + game.ColumnLabel(x)
+	}
+	lines = append(lines, fmt.Sprintf("%-*s", gridWidth, header))
+
+	for y := 0; y < 10; y++ {
+		line := fmt.Sprintf("%2s", game.RowLabel(y))
+		for x := 0; x < 10; x++ {
+			line += " " + field(x, y)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fleetField shows the player's ships with the opponent's shots. During the setup it shows the cursor, while
+// placing as the ship to place.
+func (u *UI) fleetField(x, y int) string {
+	symbol := fieldSymbol(u.board().ShipsMap().FieldState(x, y))
+	switch {
+	case u.placing() && u.inPreview(x, y):
+		return reverse + symbol + reset
+	case u.game.Status == game.StatusSetup && !u.placing() && x == u.cursorX && y == u.cursorY:
+		return reverse + symbol + reset
+	default:
+		return symbol
+	}
+}
+
+// targetField shows the player's shots with the cursor to aim
+func (u *UI) targetField(x, y int) string {
+	symbol := fieldSymbol(u.board().ShotsMap().FieldState(x, y))
+	if u.game.Status == game.StatusPlaying && x == u.cursorX && y == u.cursorY {
+		return reverse + symbol + reset
+	}
+	return symbol
+}
+
+// inPreview reports whether the ship to place covers a field when placed at the cursor
+func (u *UI) inPreview(x, y int) bool {
+	length := game.NewShip(u.ship, u.cursorX, u.cursorY, u.orientation.IsVertical()).Length
+	if u.orientation.IsVertical() {
+		return x == u.cursorX && y >= u.cursorY && y < u.cursorY+length
+	}
+	return y == u.cursorY && x >= u.cursorX && x < u.cursorX+length
+}
+
+func fieldSymbol(state game.FieldState) string {
+	if state == game.FieldStateEmpty {
+		return "."
+	}
+	return string(state)
+}
+
+// moveLog lists the latest moves, oldest first
+func (u *UI) moveLog() []string {
+	history := u.game.History
+	if len(history) == 0 {
+		return []string{"no shots fired yet"}
+	}
+
+	first := max(len(history)-logSize, 0)
+	lines := make([]string, 0, logSize)
+	for i, move := range history[first:] {
+		lines = append(lines, fmt.Sprintf("%3d. %-12s %-3s %s", first+i+1, move.Player, game.FormatCoordinate(move.X, move.Y), result(move.Hit)))
+	}
+	return lines
+}
+
+func (u *UI) status() string {
+	switch {
+	case !u.joined() && u.placing():
+		return fmt.Sprintf("Waiting for an opponent to join game %s - place your %s meanwhile (%d left)", u.game.ID, u.ship, u.board().ShipsToPlace()[u.ship])
+	case !u.joined():
+		return fmt.Sprintf("Waiting for an opponent to join game %s", u.game.ID)
+	case u.placing():
+		return fmt.Sprintf("Place your %s (%d left)", u.ship, u.board().ShipsToPlace()[u.ship])
+	case u.game.Status == game.StatusSetup && u.game.Boards[u.opponent()].PinsAvailable > 0:
+		return fmt.Sprintf("Fleet complete, waiting for %s to place their fleet", u.opponent())
+	case u.game.Status == game.StatusSetup:
+		return "Both fleets are ready, press s to start the game"
+	case u.myTurn():
+		return fmt.Sprintf("Your turn, aim at %s's waters and fire", u.opponent())
+	case u.game.Status == game.StatusPlaying:
+		return fmt.Sprintf("Waiting for %s to fire", u.game.PlayerToMove)
+	case u.game.Winner() == u.player:
+		return "You won!"
+	default:
+		return fmt.Sprintf("Game over, %s won", u.game.Winner())
+	}
+}
+
+func (u *UI) keyHelp() string {
+	switch {
+	case u.placing():
+		return "arrows/hjkl move  r rotate  Tab next ship  Enter place  x remove  q quit"
+	case u.game.Status == game.StatusSetup:
+		return "x remove the ship at the cursor  s start  q quit"
+	case u.myTurn():
+		return "arrows/hjkl aim  Enter fire  q quit"
+	default:
+		return "q quit"
+	}
+}
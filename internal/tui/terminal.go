@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to draw the screen
+const (
+	clearScreen  = "\033[H\033[2J"
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
+	hideCursor   = "\033[?25l"
+	showCursor   = "\033[?25h"
+	reverse      = "\033[7m"
+	bold         = "\033[1m"
+	reset        = "\033[0m"
+)
+
+// enterRawMode switches the terminal to raw mode, so single key presses can be read, and to the alternate
+// screen. The returned function restores the terminal. Input that is no terminal, like a pipe, is used as
+// it is.
+func enterRawMode(in io.Reader, out io.Writer) (func(), error) {
+	f, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return func() {}, nil
+	}
+
+	fd := int(f.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("error switching the terminal to raw mode: %w", err)
+	}
+
+	fmt.Fprint(out, altScreenOn+hideCursor)
+	return func() {
+		fmt.Fprint(out, showCursor+altScreenOff)
+		_ = term.Restore(fd, state)
+	}, nil
+}
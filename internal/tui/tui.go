@@ -0,0 +1,340 @@
+// Package tui is a full-screen terminal interface to play a game as one player. Ships are placed and shots
+// fired by moving a cursor over the grids. It works on game.API, so the opponent may play through the web
+// UI, the CLI or another terminal on the same storage; the screen is refreshed periodically to show their
+// moves.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// DefaultRefresh is the interval in which the game is reloaded to show the opponent's actions
+const DefaultRefresh = time.Second
+
+type UI struct {
+	api     *game.API
+	player  string
+	refresh time.Duration
+
+	in  io.Reader
+	out io.Writer
+
+	game             *game.Game
+	cursorX, cursorY int
+	ship             game.ShipType
+	orientation      game.ShipOrientation
+	message          string
+}
+
+func New(api *game.API, player string) *UI {
+	return &UI{
+		api:         api,
+		player:      player,
+		refresh:     DefaultRefresh,
+		in:          os.Stdin,
+		out:         os.Stdout,
+		ship:        game.ShipTypes[0],
+		orientation: game.OrientationHorizontal,
+	}
+}
+
+// SetIO sets the input and output of the UI
+func (u *UI) SetIO(in io.Reader, out io.Writer) {
+	u.in = in
+	u.out = out
+}
+
+// SetRefresh sets the interval in which the game is reloaded
+func (u *UI) SetRefresh(refresh time.Duration) {
+	u.refresh = refresh
+}
+
+// Open joins the game with the given ID or name, or continues it if the player already joined. Without a
+// game a new one is created for an opponent to join.
+func (u *UI) Open(gameIDOrName string) error {
+	player, err := u.api.NewPlayer(u.player)
+	if err != nil {
+		return fmt.Errorf("error getting player: %w", err)
+	}
+
+	if gameIDOrName == "" {
+		g, err := u.api.NewGame(u.player, "")
+		if err != nil {
+			return fmt.Errorf("error creating game: %w", err)
+		}
+		u.setGame(g)
+		return nil
+	}
+
+	g, err := u.api.GetGame(gameIDOrName)
+	if errors.Is(err, game.ErrorNotFound) || (err != nil && strings.Contains(err.Error(), "invalid game ID")) {
+		g, err = u.api.GetGameByName(gameIDOrName)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting game: %w", err)
+	}
+
+	if _, ok := g.Boards[u.player]; !ok {
+		if err := g.Join(player); err != nil {
+			return fmt.Errorf("error joining game: %w", err)
+		}
+		if g, err = u.api.UpdateGame(g); err != nil {
+			return fmt.Errorf("error updating game: %w", err)
+		}
+	}
+
+	u.setGame(g)
+	return nil
+}
+
+// Game returns the game as last loaded
+func (u *UI) Game() *game.Game {
+	return u.game
+}
+
+// Run shows the game until the player quits or the input ends
+func (u *UI) Run() error {
+	if u.game == nil {
+		return errors.New("no game opened")
+	}
+
+	restore, err := enterRawMode(u.in, u.out)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	keys := make(chan key)
+	go readKeys(u.in, keys)
+
+	ticker := time.NewTicker(u.refresh)
+	defer ticker.Stop()
+
+	u.render()
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok || k == keyQuit {
+				return nil
+			}
+			u.handleKey(k)
+		case <-ticker.C:
+			u.reload()
+		}
+		u.render()
+	}
+}
+
+func (u *UI) handleKey(k key) {
+	u.message = ""
+
+	switch k {
+	case keyUp:
+		u.moveCursor(0, -1)
+	case keyDown:
+		u.moveCursor(0, 1)
+	case keyLeft:
+		u.moveCursor(-1, 0)
+	case keyRight:
+		u.moveCursor(1, 0)
+	case keyRotate:
+		if u.orientation.IsVertical() {
+			u.orientation = game.OrientationHorizontal
+		} else {
+			u.orientation = game.OrientationVertical
+		}
+	case keyNextShip:
+		u.nextShip()
+	case keyEnter:
+		switch {
+		case u.placing():
+			u.placeShip()
+		case u.myTurn():
+			u.fire()
+		}
+	case keyRemove:
+		if u.game.Status == game.StatusSetup {
+			u.removeShip()
+		}
+	case keyStart:
+		u.start()
+	}
+}
+
+func (u *UI) moveCursor(dx, dy int) {
+	u.cursorX = min(max(u.cursorX+dx, 0), 9)
+	u.cursorY = min(max(u.cursorY+dy, 0), 9)
+}
+
+// nextShip selects the next ship type that still has to be placed
+func (u *UI) nextShip() {
+	board := u.board()
+	if board == nil {
+		return
+	}
+
+	toPlace := board.ShipsToPlace()
+	current := 0
+	for i, shipType := range game.ShipTypes {
+		if shipType == u.ship {
+			current = i
+		}
+	}
+	for i := 1; i <= len(game.ShipTypes); i++ {
+		shipType := game.ShipTypes[(current+i)%len(game.ShipTypes)]
+		if toPlace[shipType] > 0 {
+			u.ship = shipType
+			return
+		}
+	}
+}
+
+func (u *UI) placeShip() {
+	g, err := u.api.GetGame(u.game.ID)
+	if err != nil {
+		u.message = fmt.Sprintf("Error getting game: %v", err)
+		return
+	}
+
+	ship := u.ship
+	if err := g.PlaceShip(u.player, ship, u.cursorX, u.cursorY, u.orientation); err != nil {
+		u.message = fmt.Sprintf("Can't place %s at %s: %v", ship, game.FormatCoordinate(u.cursorX, u.cursorY), err)
+		return
+	}
+
+	// like the CLI, the game starts with the player completing the last fleet. A game can't start before
+	// the opponent joined.
+	started := len(g.Boards) == 2 && g.Start(u.player) == nil
+	if !u.save(g) {
+		return
+	}
+
+	u.message = fmt.Sprintf("%s placed at %s", ship, game.FormatCoordinate(u.cursorX, u.cursorY))
+	if started {
+		u.message = "All ships placed. The game started, you move first!"
+	}
+}
+
+func (u *UI) removeShip() {
+	g, err := u.api.GetGame(u.game.ID)
+	if err != nil {
+		u.message = fmt.Sprintf("Error getting game: %v", err)
+		return
+	}
+
+	if err := g.RemoveShip(u.player, u.cursorX, u.cursorY); err != nil {
+		u.message = fmt.Sprintf("Can't remove a ship at %s: %v", game.FormatCoordinate(u.cursorX, u.cursorY), err)
+		return
+	}
+
+	if u.save(g) {
+		u.message = fmt.Sprintf("Ship at %s removed", game.FormatCoordinate(u.cursorX, u.cursorY))
+	}
+}
+
+func (u *UI) start() {
+	g, err := u.api.GetGame(u.game.ID)
+	if err != nil {
+		u.message = fmt.Sprintf("Error getting game: %v", err)
+		return
+	}
+
+	if len(g.Boards) < 2 {
+		u.message = "Can't start the game before an opponent joined"
+		return
+	}
+	if err := g.Start(u.player); err != nil {
+		u.message = fmt.Sprintf("Can't start the game: %v", err)
+		return
+	}
+
+	if u.save(g) {
+		u.message = fmt.Sprintf("The game started, %s moves first", g.PlayerToMove)
+	}
+}
+
+func (u *UI) fire() {
+	g, err := u.api.GetGame(u.game.ID)
+	if err != nil {
+		u.message = fmt.Sprintf("Error getting game: %v", err)
+		return
+	}
+
+	coordinate := game.FormatCoordinate(u.cursorX, u.cursorY)
+	if err := g.MakeMove(game.Move{Player: u.player, X: u.cursorX, Y: u.cursorY}); err != nil {
+		u.message = fmt.Sprintf("Can't fire at %s: %v", coordinate, err)
+		return
+	}
+
+	if u.save(g) {
+		u.message = fmt.Sprintf("You fired at %s - %s", coordinate, result(g.History[len(g.History)-1].Hit))
+	}
+}
+
+func (u *UI) save(g *game.Game) bool {
+	g, err := u.api.UpdateGame(g)
+	if err != nil {
+		u.message = fmt.Sprintf("Error updating game: %v", err)
+		return false
+	}
+
+	u.setGame(g)
+	return true
+}
+
+// reload fetches the game to show what the opponent did in the meantime
+func (u *UI) reload() {
+	g, err := u.api.GetGame(u.game.ID)
+	if err != nil {
+		u.message = fmt.Sprintf("Error getting game: %v", err)
+		return
+	}
+	u.setGame(g)
+}
+
+func (u *UI) setGame(g *game.Game) {
+	u.game = g
+	if board := u.board(); board != nil && board.ShipsToPlace()[u.ship] == 0 {
+		u.nextShip()
+	}
+}
+
+// board returns the board of the player
+func (u *UI) board() *game.Board {
+	return u.game.Boards[u.player]
+}
+
+func (u *UI) opponent() string {
+	if u.game.Player1.Name == u.player {
+		return u.game.Player2.Name
+	}
+	return u.game.Player1.Name
+}
+
+// joined reports whether both players joined the game
+func (u *UI) joined() bool {
+	return len(u.game.Boards) == 2
+}
+
+// placing reports whether the player still places ships
+func (u *UI) placing() bool {
+	return u.game.Status == game.StatusSetup && u.board().PinsAvailable > 0
+}
+
+func (u *UI) myTurn() bool {
+	return u.game.Status == game.StatusPlaying && u.game.PlayerToMove == u.player
+}
+
+func result(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
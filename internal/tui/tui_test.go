@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+// moveKeys returns the arrow keys moving the cursor from one field to another
+func moveKeys(fromX, fromY, toX, toY int) string {
+	var keys strings.Builder
+	for ; fromX < toX; fromX++ {
+		keys.WriteString("\033[C")
+	}
+	for ; fromX > toX; fromX-- {
+		keys.WriteString("\033[D")
+	}
+	for ; fromY < toY; fromY++ {
+		keys.WriteString("\033[B")
+	}
+	for ; fromY > toY; fromY-- {
+		keys.WriteString("\033[A")
+	}
+	return keys.String()
+}
+
+func TestPlaceFleetAndFire(t *testing.T) {
+	api, db, g := gametest.NewGame(t)
+
+	var keys strings.Builder
+	x, y := 0, 0
+	for _, p := range gametest.Fleet {
+		keys.WriteString(moveKeys(x, y, p.X, p.Y))
+		keys.WriteString("\r")
+		x, y = p.X, p.Y
+	}
+	keys.WriteString(moveKeys(x, y, 0, 0))
+	keys.WriteString(" q")
+
+	var output bytes.Buffer
+	ui := New(api, "alice")
+	ui.SetIO(strings.NewReader(keys.String()), &output)
+	ui.SetRefresh(time.Hour)
+	require.NoError(t, ui.Open(g.ID))
+	require.NoError(t, ui.Run())
+
+	g, err := db.FindGameByID(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, game.StatusPlaying, g.Status)
+	assert.Equal(t, 0, g.Boards["alice"].PinsAvailable)
+	require.Len(t, g.History, 1)
+	assert.Equal(t, game.Move{Player: "alice", X: 0, Y: 0, Hit: true}, g.History[0])
+
+	assert.Contains(t, output.String(), "All ships placed. The game started, you move first!")
+	assert.Contains(t, output.String(), "You fired at A1 - hit")
+	assert.Contains(t, output.String(), "Waiting for bob to fire")
+	assert.Contains(t, output.String(), "  1. alice        A1  hit")
+}
+
+func TestPlacement(t *testing.T) {
+	api, _, g := gametest.NewGame(t)
+	ui := New(api, "alice")
+	require.NoError(t, ui.Open(g.ID))
+
+	for i := 0; i < 9; i++ {
+		ui.handleKey(keyRight)
+	}
+	ui.handleKey(keyEnter)
+	assert.Contains(t, ui.message, "Can't place Battleship at J1")
+
+	ui.handleKey(keyRotate)
+	ui.handleKey(keyEnter)
+	assert.Equal(t, "Battleship placed at J1", ui.message)
+	assert.Equal(t, game.Cruiser, ui.ship, "the next ship type is selected when all ships of a type are placed")
+
+	ui.handleKey(keyNextShip)
+	assert.Equal(t, game.Destroyer, ui.ship)
+
+	ui.handleKey(keyDown)
+	ui.handleKey(keyRemove)
+	assert.Equal(t, "Ship at J2 removed", ui.message)
+	assert.Equal(t, 30, ui.Game().Boards["alice"].PinsAvailable)
+
+	ui.handleKey(keyEnter)
+	assert.Equal(t, "Destroyer placed at J2", ui.message)
+
+	ui.handleKey(keyStart)
+	assert.Contains(t, ui.message, "Can't start the game")
+}
+
+func TestOpenCreatesAndJoinsGame(t *testing.T) {
+	db := gametest.NewDatabase()
+	api := game.NewApi(db)
+
+	var output bytes.Buffer
+	alice := New(api, "alice")
+	alice.SetIO(strings.NewReader(""), &output)
+	require.NoError(t, alice.Open(""))
+	require.NoError(t, alice.Run())
+	assert.Contains(t, output.String(), "Waiting for an opponent to join game game1 - place your Battleship meanwhile (1 left)")
+
+	bob := New(api, "bob")
+	require.NoError(t, bob.Open("game1"))
+	g, err := db.FindGameByID("game1")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", g.Player2.Name)
+	assert.Equal(t, "Place your Battleship (1 left)", bob.status())
+
+	// the opponent's actions show up with the next reload
+	alice.reload()
+	assert.Equal(t, "alice vs bob - game game1", alice.title())
+
+	assert.ErrorIs(t, New(api, "carol").Open("game1"), game.ErrorIllegal)
+	assert.ErrorIs(t, New(api, "carol").Open("unknown"), game.ErrorNotFound)
+}
+
+func TestGameOver(t *testing.T) {
+	api, _, g := gametest.NewGame(t)
+	g.Status = game.StatusLost
+	_, err := api.UpdateGame(g)
+	require.NoError(t, err)
+
+	alice := New(api, "alice")
+	require.NoError(t, alice.Open(g.ID))
+	assert.Equal(t, "Game over, bob won", alice.status())
+
+	bob := New(api, "bob")
+	require.NoError(t, bob.Open(g.ID))
+	assert.Equal(t, "You won!", bob.status())
+}
+
+func TestDecodeKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  key
+	}{
+		{"\033[A", keyUp},
+		{"\033OB", keyDown},
+		{"\033[C", keyRight},
+		{"h", keyLeft},
+		{"\r", keyEnter},
+		{" ", keyEnter},
+		{"r", keyRotate},
+		{"\t", keyNextShip},
+		{"\x7f", keyRemove},
+		{"s", keyStart},
+		{"\x03", keyQuit},
+		{"\033[Z", keyNone},
+		{"?", keyNone},
+	}
+	for _, tt := range tests {
+		r := bufio.NewReader(strings.NewReader(tt.input))
+		b, err := r.ReadByte()
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, decodeKey(b, r), "%q", tt.input)
+	}
+}
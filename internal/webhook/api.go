@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+// Database is the storage the webhook API and the dispatcher need
+type Database interface {
+	CreateWebhook(s *Subscription) (*Subscription, error)
+	FindWebhookByID(id string) (*Subscription, error)
+	// QueryWebhooks returns the subscriptions of the given owners
+	QueryWebhooks(owners ...string) ([]*Subscription, error)
+	DeleteWebhook(id string) error
+
+	FindGameByID(id string) (*game.Game, error)
+
+	CreateWebhookDelivery(d *Delivery) (*Delivery, error)
+	QueryWebhookDeliveries(subscriptionID string, page int, count int) ([]*Delivery, error)
+}
+
+// PlayerAPI is the part of game.API used to check the owner of a subscription
+type PlayerAPI interface {
+	GetPlayer(playerName string) (*game.Player, error)
+}
+
+const secretLength = 32
+
+type API struct {
+	db      Database
+	players PlayerAPI
+	// AllowInternal permits subscriptions to loopback, private and link-local addresses
+	AllowInternal bool
+}
+
+func NewApi(db Database, players PlayerAPI) *API {
+	return &API{
+		db:      db,
+		players: players,
+	}
+}
+
+// WithContext returns the API with its database operations and game API calls bound to the context, if they
+// support it, like game.API.WithContext
+func (A *API) WithContext(ctx context.Context) *API {
+	bound := *A
+	if db, ok := A.db.(game.ContextDatabase); ok {
+		if db, ok := db.WithContext(ctx).(Database); ok {
+			bound.db = db
+		}
+	}
+	if players, ok := A.players.(interface {
+		WithContext(ctx context.Context) *game.API
+	}); ok {
+		bound.players = players.WithContext(ctx)
+	}
+	return &bound
+}
+
+// Subscribe registers a webhook for a player. If no secret is given, a random one is generated; it is only
+// returned here, so the owner has to keep it to verify signatures.
+func (A *API) Subscribe(owner string, rawURL string, secret string, events []game.EventType) (*Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook URL must be an absolute http(s) URL, got %q: %w", rawURL, game.ErrorInvalidInput)
+	}
+	if !A.AllowInternal {
+		if err := checkTarget(context.Background(), u); err != nil {
+			return nil, fmt.Errorf("%v: %w", err, game.ErrorInvalidInput)
+		}
+	}
+
+	for _, e := range events {
+		if !slices.Contains(EventTypes, e) {
+			return nil, fmt.Errorf("unknown event type %q: %w", e, game.ErrorInvalidInput)
+		}
+	}
+
+	if _, err := A.players.GetPlayer(owner); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := A.db.CreateWebhook(&Subscription{
+		Owner:     owner,
+		URL:       u.String(),
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Webhooks returns the subscriptions of a player
+func (A *API) Webhooks(owner string) ([]*Subscription, error) {
+	subscriptions, err := A.db.QueryWebhooks(owner)
+	if err != nil {
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = make([]*Subscription, 0)
+	}
+	return subscriptions, nil
+}
+
+// Unsubscribe removes a subscription. Only its owner may remove it.
+func (A *API) Unsubscribe(id string, owner string) error {
+	if _, err := A.owned(id, owner); err != nil {
+		return err
+	}
+	return A.db.DeleteWebhook(id)
+}
+
+// Deliveries returns a page of the delivery log of a subscription, newest first
+func (A *API) Deliveries(id string, owner string, page int, count int) ([]*Delivery, error) {
+	if _, err := A.owned(id, owner); err != nil {
+		return nil, err
+	}
+	return A.db.QueryWebhookDeliveries(id, page, count)
+}
+
+func (A *API) owned(id string, owner string) (*Subscription, error) {
+	s, err := A.db.FindWebhookByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Owner != owner {
+		return nil, fmt.Errorf("webhook %s does not belong to %s: %w", id, owner, game.ErrorIllegal)
+	}
+	return s, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,201 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+const (
+	DefaultMaxAttempts = 4
+	DefaultBackoff     = time.Second
+	DefaultTimeout     = 10 * time.Second
+)
+
+// Dispatcher delivers game events to the matching subscriptions of the players of the game. Failed deliveries are retried with
+// exponential backoff; every delivery is written to the delivery log once it succeeded or gave up.
+type Dispatcher struct {
+	db     Database
+	client *http.Client
+	// MaxAttempts is the number of tries per delivery, including the first one
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles with every further retry
+	Backoff time.Duration
+	// Logger reports the deliveries and the events which could not be dispatched
+	Logger *slog.Logger
+	// AllowInternal permits deliveries to loopback, private and link-local addresses
+	AllowInternal bool
+
+	wg sync.WaitGroup
+}
+
+func NewDispatcher(db Database) *Dispatcher {
+	d := &Dispatcher{
+		db:          db,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+		Logger:      slog.Default(),
+	}
+	d.client = newClient(func() bool { return d.AllowInternal })
+	return d
+}
+
+// Run delivers events until the channel is closed or the context is cancelled, then waits for deliveries
+// which are still in progress. Retries are cut short by the cancellation.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan game.Event) {
+	defer d.wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			d.Dispatch(ctx, e)
+		}
+	}
+}
+
+// Dispatch starts the delivery of an event to every subscription that wants it and whose owner plays in
+// the game of the event. Only the subscriptions of the players are loaded.
+func (d *Dispatcher) Dispatch(ctx context.Context, e game.Event) {
+	players, err := d.players(e)
+	if err != nil {
+		d.Logger.Error("could not load the players of the webhook event", "event", e.Type, "game", e.GameID,
+			"error", err)
+		return
+	}
+
+	subscriptions, err := d.db.QueryWebhooks(players...)
+	if err != nil {
+		d.Logger.Error("could not load webhook subscriptions", "error", err)
+		return
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		d.Logger.Error("could not encode webhook event", "event", e.Type, "error", err)
+		return
+	}
+
+	for _, s := range subscriptions {
+		if !s.Wants(e.Type) {
+			continue
+		}
+
+		d.wg.Add(1)
+		go func(s *Subscription) {
+			defer d.wg.Done()
+			d.deliver(ctx, s, e, body)
+		}(s)
+	}
+}
+
+// players returns the names of the players of the event's game. Game events carry their players, for the
+// others the game is loaded.
+func (d *Dispatcher) players(e game.Event) ([]string, error) {
+	summary := e.Game
+	if summary == nil {
+		g, err := d.db.FindGameByID(e.GameID)
+		if err != nil {
+			return nil, err
+		}
+		loaded := g.Summary()
+		summary = &loaded
+	}
+
+	var names []string
+	for _, p := range []*game.Player{summary.Player1, summary.Player2} {
+		if p != nil && p.Name != "" {
+			names = append(names, p.Name)
+		}
+	}
+	return names, nil
+}
+
+// Wait blocks until all started deliveries are logged
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, s *Subscription, e game.Event, body []byte) {
+	delivery := &Delivery{
+		SubscriptionID: s.ID,
+		Event:          e.Type,
+		GameID:         e.GameID,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	backoff := d.Backoff
+	for delivery.Attempts < d.MaxAttempts {
+		if delivery.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				delivery.Error = fmt.Sprintf("gave up: %v", ctx.Err())
+				d.log(delivery)
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		delivery.Attempts++
+		retry := d.post(ctx, s, e, body, delivery)
+		if delivery.Delivered || !retry {
+			break
+		}
+	}
+
+	d.log(delivery)
+}
+
+// post sends the event once and records the outcome. It reports whether a failure is worth retrying:
+// network errors, rate limiting and server errors are, other client errors and internal targets are not.
+func (d *Dispatcher) post(ctx context.Context, s *Subscription, e game.Event, body []byte, delivery *Delivery) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(e.Type))
+	req.Header.Set(HeaderSignature, Sign(s.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		delivery.StatusCode = 0
+		delivery.Error = err.Error()
+		return !errors.Is(err, errInternalTarget)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		delivery.Delivered = true
+		delivery.Error = ""
+		return false
+	}
+
+	delivery.Error = resp.Status
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func (d *Dispatcher) log(delivery *Delivery) {
+	d.Logger.Debug("webhook delivery", "subscription", delivery.SubscriptionID, "event", delivery.Event,
+		"delivered", delivery.Delivered, "attempts", delivery.Attempts)
+	if _, err := d.db.CreateWebhookDelivery(delivery); err != nil {
+		d.Logger.Error("could not log webhook delivery", "subscription", delivery.SubscriptionID, "error", err)
+	}
+}
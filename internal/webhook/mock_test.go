@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+)
+
+// mockDatabase is an in-memory implementation of the Database interface for testing. The games are kept by
+// the embedded gametest.Database.
+type mockDatabase struct {
+	*gametest.Database
+
+	mu            sync.Mutex
+	subscriptions map[string]*Subscription
+	deliveries    []*Delivery
+	created       int
+	// queried holds the owners of every QueryWebhooks call
+	queried [][]string
+}
+
+func newMockDatabase() *mockDatabase {
+	return &mockDatabase{
+		Database:      gametest.NewDatabase(),
+		subscriptions: make(map[string]*Subscription),
+	}
+}
+
+// addGame stores a game between two players and returns its ID
+func (m *mockDatabase) addGame(t *testing.T, player1 string, player2 string) string {
+	g := game.NewGame(&game.Player{Name: player1}, "")
+	g.Player2 = &game.Player{Name: player2}
+	g, err := m.CreateGame(g)
+	require.NoError(t, err)
+	return g.ID
+}
+
+func (m *mockDatabase) CreateWebhook(s *Subscription) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created++
+	s.ID = fmt.Sprintf("webhook%d", m.created)
+	m.subscriptions[s.ID] = s
+	return s, nil
+}
+
+func (m *mockDatabase) FindWebhookByID(id string) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.subscriptions[id]
+	if !ok {
+		return nil, game.ErrorNotFound
+	}
+	return s, nil
+}
+
+func (m *mockDatabase) QueryWebhooks(owners ...string) ([]*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queried = append(m.queried, owners)
+	var subscriptions []*Subscription
+	for _, s := range m.subscriptions {
+		if slices.Contains(owners, s.Owner) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (m *mockDatabase) DeleteWebhook(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[id]; !ok {
+		return game.ErrorNotFound
+	}
+	delete(m.subscriptions, id)
+	return nil
+}
+
+func (m *mockDatabase) CreateWebhookDelivery(d *Delivery) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d.ID = fmt.Sprintf("delivery%d", len(m.deliveries)+1)
+	m.deliveries = append(m.deliveries, d)
+	return d, nil
+}
+
+func (m *mockDatabase) QueryWebhookDeliveries(subscriptionID string, _ int, _ int) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*Delivery
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		if m.deliveries[i].SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, m.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
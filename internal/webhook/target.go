@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// errInternalTarget is returned when a webhook would be posted to an address inside the server's network
+var errInternalTarget = errors.New("webhook target is a loopback, private or link-local address")
+
+// internalAddr reports whether an address is loopback, private or link-local, which webhooks may not reach
+func internalAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified()
+}
+
+// checkTarget rejects URLs whose host is or resolves to an internal address. A host which cannot be
+// resolved yet is accepted; the dispatcher checks the address again when it connects.
+func checkTarget(ctx context.Context, u *url.URL) error {
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if internalAddr(addr) {
+			return fmt.Errorf("%w: %s", errInternalTarget, host)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if internalAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", errInternalTarget, host, addr)
+		}
+	}
+	return nil
+}
+
+// newClient returns a client which refuses to connect to internal addresses unless allowed. The check runs
+// on the resolved address of every connection, so it also covers redirects and DNS changes after subscribing.
+// Proxies are not used, they would hide the address the delivery ends up at.
+func newClient(allowInternal func() bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: DefaultTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			if allowInternal() {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if internalAddr(addr) {
+				return fmt.Errorf("%w: %s", errInternalTarget, addr)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: DefaultTimeout, Transport: transport}
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+)
+
+const (
+	// HeaderSignature carries the HMAC-SHA256 of the request body, signed with the secret of the subscription
+	HeaderSignature = "X-Battleship-Signature"
+	// HeaderEvent carries the type of the delivered event
+	HeaderEvent = "X-Battleship-Event"
+
+	signaturePrefix = "sha256="
+)
+
+// EventTypes are the events a webhook can subscribe to
+var EventTypes = []game.EventType{
+	game.EventGameCreated,
+	game.EventGameUpdated,
+	game.EventChatMessage,
+}
+
+// Subscription posts the events of the games its owner plays in to a URL. A subscription without event types receives all events.
+type Subscription struct {
+	ID        string           `json:"id,omitempty" bson:"id,omitempty"`
+	Owner     string           `json:"owner" bson:"owner"`
+	URL       string           `json:"url" bson:"url"`
+	Secret    string           `json:"-" bson:"secret"`
+	Events    []game.EventType `json:"events" bson:"events"`
+	CreatedAt time.Time        `json:"created_at" bson:"created_at"`
+}
+
+// Wants reports whether the subscription receives events of the given type
+func (s *Subscription) Wants(eventType game.EventType) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, eventType)
+}
+
+// Delivery is the log entry of an event sent to a subscription, including all retries
+type Delivery struct {
+	ID             string         `json:"id,omitempty" bson:"id,omitempty"`
+	SubscriptionID string         `json:"subscription_id" bson:"subscription_id"`
+	Event          game.EventType `json:"event" bson:"event"`
+	GameID         string         `json:"game_id" bson:"game_id"`
+	Attempts       int            `json:"attempts" bson:"attempts"`
+	StatusCode     int            `json:"status_code,omitempty" bson:"status_code,omitempty"`
+	Error          string         `json:"error,omitempty" bson:"error,omitempty"`
+	Delivered      bool           `json:"delivered" bson:"delivered"`
+	CreatedAt      time.Time      `json:"created_at" bson:"created_at"`
+}
+
+// Sign returns the signature header value of a body. Receivers compute it from the raw request body with
+// their copy of the secret and compare it to the X-Battleship-Signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header value in constant time
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
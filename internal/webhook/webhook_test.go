@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Jagreen1970/battleship/internal/game"
+	"github.com/Jagreen1970/battleship/internal/game/gametest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"type":"game.updated"}`)
+	signature := Sign("secret", body)
+
+	assert.True(t, Verify("secret", body, signature))
+	assert.False(t, Verify("other", body, signature))
+	assert.False(t, Verify("secret", []byte(`{}`), signature))
+	assert.False(t, Verify("secret", body, signature[len(signaturePrefix):]))
+}
+
+func TestSubscribe(t *testing.T) {
+	db := newMockDatabase()
+	players, _ := gametest.NewAPI(t, "alice", "bob")
+	api := NewApi(db, players)
+
+	_, err := api.Subscribe("alice", "ftp://example.com", "", nil)
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+
+	_, err = api.Subscribe("alice", "http://example.com", "", []game.EventType{"game.deleted"})
+	assert.ErrorIs(t, err, game.ErrorInvalidInput)
+
+	_, err = api.Subscribe("nobody", "http://example.com", "", nil)
+	assert.ErrorIs(t, err, game.ErrorNotFound)
+
+	for _, target := range []string{"http://127.0.0.1/hook", "http://localhost:8080", "https://10.0.0.1", "http://[::1]/",
+		"http://169.254.169.254/latest/meta-data", "http://0.0.0.0"} {
+		_, err = api.Subscribe("alice", target, "", nil)
+		assert.ErrorIs(t, err, game.ErrorInvalidInput, target)
+	}
+
+	s, err := api.Subscribe("alice", "http://example.com/hook", "", []game.EventType{game.EventChatMessage})
+	require.NoError(t, err)
+	assert.Len(t, s.Secret, 2*secretLength)
+	assert.True(t, s.Wants(game.EventChatMessage))
+	assert.False(t, s.Wants(game.EventGameUpdated))
+
+	webhooks, err := api.Webhooks("bob")
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	err = api.Unsubscribe(s.ID, "bob")
+	assert.ErrorIs(t, err, game.ErrorIllegal)
+
+	require.NoError(t, api.Unsubscribe(s.ID, "alice"))
+	webhooks, err = api.Webhooks("alice")
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+}
+
+func TestDispatcher(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan game.Event, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify("secret", body, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// the first attempt fails to exercise the retry
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var e game.Event
+		_ = json.Unmarshal(body, &e)
+		assert.Equal(t, string(game.EventGameUpdated), r.Header.Get(HeaderEvent))
+		received <- e
+	}))
+	defer receiver.Close()
+
+	db := newMockDatabase()
+	game1 := db.addGame(t, "alice", "bob")
+	game2 := db.addGame(t, "bob", "carol")
+	players, _ := gametest.NewAPI(t, "alice")
+	api := NewApi(db, players)
+	api.AllowInternal = true
+	hook, err := api.Subscribe("alice", receiver.URL, "secret", []game.EventType{game.EventGameUpdated})
+	require.NoError(t, err)
+	wrongSecret, err := api.Subscribe("alice", receiver.URL, "wrong", nil)
+	require.NoError(t, err)
+
+	dispatcher := NewDispatcher(db)
+	dispatcher.Backoff = time.Millisecond
+	dispatcher.AllowInternal = true
+
+	// alice does not play in game2, so its events are not delivered to her
+	summary := &game.Summary{ID: game1, Player1: &game.Player{Name: "alice"}, Player2: &game.Player{Name: "bob"}}
+	dispatcher.Dispatch(context.Background(), game.Event{Type: game.EventChatMessage, GameID: game2})
+	dispatcher.Dispatch(context.Background(), game.Event{Type: game.EventGameUpdated, GameID: game2})
+	dispatcher.Dispatch(context.Background(), game.Event{Type: game.EventChatMessage, GameID: game1})
+	dispatcher.Dispatch(context.Background(), game.Event{Type: game.EventGameUpdated, GameID: game1, Game: summary})
+	dispatcher.Wait()
+
+	// only the subscriptions of the players of the game are loaded
+	assert.Equal(t, [][]string{{"bob", "carol"}, {"bob", "carol"}, {"alice", "bob"}, {"alice", "bob"}}, db.queried)
+
+	e := <-received
+	assert.Equal(t, game1, e.GameID)
+
+	deliveries, err := api.Deliveries(hook.ID, "alice", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Delivered)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+
+	// a rejected signature is a client error and is not retried
+	deliveries, err = api.Deliveries(wrongSecret.ID, "alice", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	for _, d := range deliveries {
+		assert.False(t, d.Delivered)
+		assert.Equal(t, 1, d.Attempts)
+		assert.Equal(t, http.StatusUnauthorized, d.StatusCode)
+	}
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	db := newMockDatabase()
+	gameID := db.addGame(t, "alice", "bob")
+	hook, err := db.CreateWebhook(&Subscription{Owner: "alice", URL: receiver.URL, Secret: "secret"})
+	require.NoError(t, err)
+
+	dispatcher := NewDispatcher(db)
+	dispatcher.Backoff = time.Millisecond
+	dispatcher.AllowInternal = true
+
+	events := make(chan game.Event, 1)
+	events <- game.Event{Type: game.EventGameCreated, GameID: gameID}
+	close(events)
+	dispatcher.Run(context.Background(), events)
+
+	deliveries, err := db.QueryWebhookDeliveries(hook.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.False(t, deliveries[0].Delivered)
+	assert.Equal(t, DefaultMaxAttempts, deliveries[0].Attempts)
+	assert.Equal(t, "500 Internal Server Error", deliveries[0].Error)
+}
+
+func TestDispatcherRejectsInternalTargets(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer receiver.Close()
+
+	db := newMockDatabase()
+	gameID := db.addGame(t, "alice", "bob")
+	hook, err := db.CreateWebhook(&Subscription{Owner: "alice", URL: receiver.URL, Secret: "secret"})
+	require.NoError(t, err)
+
+	dispatcher := NewDispatcher(db)
+	dispatcher.Backoff = time.Millisecond
+	dispatcher.Dispatch(context.Background(), game.Event{Type: game.EventChatMessage, GameID: gameID})
+	dispatcher.Wait()
+
+	assert.Zero(t, calls.Load())
+	deliveries, err := db.QueryWebhookDeliveries(hook.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.False(t, deliveries[0].Delivered)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Contains(t, deliveries[0].Error, errInternalTarget.Error())
+}